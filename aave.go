@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// Aave V3 Pool on Ethereum mainnet
+const aaveV3PoolAddress = "0x87870Bca3F3fD6335C3F4ce8392D69350B4fA4E2"
+
+const aaveV3PoolABI = `[
+  {
+    "inputs": [
+      { "name": "receiverAddress", "type": "address" },
+      { "name": "asset", "type": "address" },
+      { "name": "amount", "type": "uint256" },
+      { "name": "params", "type": "bytes" },
+      { "name": "referralCode", "type": "uint16" }
+    ],
+    "name": "flashLoanSimple",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "asset", "type": "address" }],
+    "name": "getReserveData",
+    "outputs": [
+      {
+        "components": [
+          { "name": "configuration", "type": "uint256" },
+          { "name": "liquidityIndex", "type": "uint128" },
+          { "name": "currentLiquidityRate", "type": "uint128" },
+          { "name": "variableBorrowIndex", "type": "uint128" },
+          { "name": "currentVariableBorrowRate", "type": "uint128" },
+          { "name": "currentStableBorrowRate", "type": "uint128" },
+          { "name": "lastUpdateTimestamp", "type": "uint40" },
+          { "name": "id", "type": "uint16" },
+          { "name": "aTokenAddress", "type": "address" },
+          { "name": "stableDebtTokenAddress", "type": "address" },
+          { "name": "variableDebtTokenAddress", "type": "address" },
+          { "name": "interestRateStrategyAddress", "type": "address" },
+          { "name": "accruedToTreasury", "type": "uint128" },
+          { "name": "unbacked", "type": "uint128" },
+          { "name": "isolationModeTotalDebt", "type": "uint128" }
+        ],
+        "name": "",
+        "type": "tuple"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "FLASHLOAN_PREMIUM_TOTAL",
+    "outputs": [{ "name": "", "type": "uint128" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var aaveV3Pool = mustParseABI(aaveV3PoolABI)
+
+// aaveReserveData mirrors the DataTypes.ReserveData struct of the Aave V3 Pool
+type aaveReserveData struct {
+	Configuration               *big.Int
+	LiquidityIndex              *big.Int
+	CurrentLiquidityRate        *big.Int
+	VariableBorrowIndex         *big.Int
+	CurrentVariableBorrowRate   *big.Int
+	CurrentStableBorrowRate     *big.Int
+	LastUpdateTimestamp         uint64
+	Id                          uint16
+	ATokenAddress               common.Address
+	StableDebtTokenAddress      common.Address
+	VariableDebtTokenAddress    common.Address
+	InterestRateStrategyAddress common.Address
+	AccruedToTreasury           *big.Int
+	Unbacked                    *big.Int
+	IsolationModeTotalDebt      *big.Int
+}
+
+func flashLoanTest(c *cli.Context) error {
+	fromIndex := c.Int("from")
+	pool, err := parseAddress(c.String("pool"))
+	if err != nil {
+		return err
+	}
+	asset, err := parseAddress(c.String("asset"))
+	if err != nil {
+		return err
+	}
+	receiver, err := parseAddress(c.String("receiver"))
+	if err != nil {
+		return err
+	}
+
+	params := []byte{}
+	if raw := c.String("params"); raw != "" {
+		if !strings.HasPrefix(raw, "0x") {
+			raw = "0x" + raw
+		}
+		params, err = hexutil.Decode(raw)
+		if err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	tokenContract, err := Token.ERCToken(asset.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	decimals, err := tokenContract.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	amountInUnits, err := parseAmount(c.String("amount"), int(decimals))
+	if err != nil {
+		return err
+	}
+
+	if result, err := callContract(client, pool, aaveV3Pool, "FLASHLOAN_PREMIUM_TOTAL"); err == nil {
+		premium := new(big.Int).Mul(amountInUnits, result[0].(*big.Int))
+		premium.Div(premium, big.NewInt(10000))
+		fmt.Printf("Expected premium: %s\n", formatBigIntToDecimal(premium, int(decimals)))
+	}
+
+	balanceBefore, err := tokenContract.BalanceOf(receiver.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get receiver balance: %w", err)
+	}
+
+	txData, err := aaveV3Pool.Pack("flashLoanSimple", receiver, asset, amountInUnits, params, uint16(0))
+	if err != nil {
+		return fmt.Errorf("failed to pack flashLoanSimple data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, pool, big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Flash loan transaction sent: %s\n", signedTx.Hash().Hex())
+
+	receipt, err := waitForSuccess(client, signedTx)
+	if err != nil {
+		return err
+	}
+
+	balanceAfter, err := tokenContract.BalanceOf(receiver.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get receiver balance: %w", err)
+	}
+
+	change := new(big.Int).Sub(balanceAfter, balanceBefore)
+	fmt.Printf("Mined in block %s, gas used: %d\n", receipt.BlockNumber.String(), receipt.GasUsed)
+	fmt.Printf("Receiver balance change: %s\n", formatBigIntToDecimal(change, int(decimals)))
+	return nil
+}
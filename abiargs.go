@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// parseABIArgument converts a user-supplied string into the Go value the ABI
+// encoder expects for typ. Arrays are given as JSON arrays, e.g. ["1","2"].
+// Tuples are not supported.
+func parseABIArgument(typ abi.Type, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+
+	switch typ.T {
+	case abi.AddressTy:
+		return parseAddress(input)
+
+	case abi.BoolTy:
+		switch strings.ToLower(input) {
+		case "true", "1", "yes":
+			return true, nil
+		case "false", "0", "no":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid bool %q", input)
+
+	case abi.StringTy:
+		return input, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(input)
+
+	case abi.FixedBytesTy:
+		data, err := hexutil.Decode(input)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(data))
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(data))
+		return value.Interface(), nil
+
+	case abi.UintTy, abi.IntTy:
+		number, ok := new(big.Int).SetString(input, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", input)
+		}
+		if typ.T == abi.UintTy && number.Sign() < 0 {
+			return nil, fmt.Errorf("%s cannot be negative", typ.String())
+		}
+		if typ.Size > 64 {
+			return number, nil
+		}
+		// Small integer types are packed from the matching Go integer type
+		value := reflect.New(typ.GetType()).Elem()
+		if typ.T == abi.UintTy {
+			if !number.IsUint64() || value.OverflowUint(number.Uint64()) {
+				return nil, fmt.Errorf("%s out of range for %s", input, typ.String())
+			}
+			value.SetUint(number.Uint64())
+		} else {
+			if !number.IsInt64() || value.OverflowInt(number.Int64()) {
+				return nil, fmt.Errorf("%s out of range for %s", input, typ.String())
+			}
+			value.SetInt(number.Int64())
+		}
+		return value.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal([]byte(input), &items); err != nil {
+			return nil, fmt.Errorf("expected a JSON array for %s: %w", typ.String(), err)
+		}
+		if typ.T == abi.ArrayTy && len(items) != typ.Size {
+			return nil, fmt.Errorf("expected %d elements for %s, got %d", typ.Size, typ.String(), len(items))
+		}
+
+		var value reflect.Value
+		if typ.T == abi.SliceTy {
+			value = reflect.MakeSlice(typ.GetType(), len(items), len(items))
+		} else {
+			value = reflect.New(typ.GetType()).Elem()
+		}
+		for i, item := range items {
+			// Accept both quoted and bare JSON scalars
+			var text string
+			if json.Unmarshal(item, &text) != nil {
+				text = string(item)
+			}
+			element, err := parseABIArgument(*typ.Elem, text)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			value.Index(i).Set(reflect.ValueOf(element))
+		}
+		return value.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %s", typ.String())
+}
+
+// parseABIArguments converts a JSON array of arguments, given as quoted or
+// bare JSON values, into the values the ABI encoder expects for inputs.
+func parseABIArguments(inputs abi.Arguments, jsonArgs string) ([]interface{}, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(jsonArgs), &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of arguments: %w", err)
+	}
+	if len(items) != len(inputs) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(inputs), len(items))
+	}
+
+	args := make([]interface{}, len(items))
+	for i, item := range items {
+		// Nested arrays are passed on as JSON
+		var text string
+		if json.Unmarshal(item, &text) != nil {
+			text = string(item)
+		}
+		value, err := parseABIArgument(inputs[i].Type, text)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		args[i] = value
+	}
+	return args, nil
+}
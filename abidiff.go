@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
+// abiFunctionChange is a function whose definition differs between two ABIs
+type abiFunctionChange struct {
+	Name string   `json:"name"`
+	Old  []string `json:"old"`
+	New  []string `json:"new"`
+}
+
+// abiDiffReport lists how the functions of a contract ABI changed
+type abiDiffReport struct {
+	Added   []string            `json:"added"`
+	Removed []string            `json:"removed"`
+	Changed []abiFunctionChange `json:"changed"`
+}
+
+// describeMethod renders a method's full interface: parameters, return
+// values and state mutability.
+func describeMethod(method abi.Method) string {
+	outputs := make([]string, len(method.Outputs))
+	for i, output := range method.Outputs {
+		outputs[i] = output.Type.String()
+	}
+
+	description := method.Sig
+	if len(outputs) > 0 {
+		description += " returns (" + strings.Join(outputs, ",") + ")"
+	}
+	return description + " " + method.StateMutability
+}
+
+// methodsByName groups the described methods of an ABI by name so
+// overloads are compared together.
+func methodsByName(contractABI abi.ABI) map[string][]string {
+	methods := map[string][]string{}
+	for _, method := range contractABI.Methods {
+		methods[method.RawName] = append(methods[method.RawName], describeMethod(method))
+	}
+	for name := range methods {
+		sort.Strings(methods[name])
+	}
+	return methods
+}
+
+// diffABIs compares the functions of two ABIs
+func diffABIs(oldABI, newABI abi.ABI) abiDiffReport {
+	oldMethods := methodsByName(oldABI)
+	newMethods := methodsByName(newABI)
+	report := abiDiffReport{Added: []string{}, Removed: []string{}, Changed: []abiFunctionChange{}}
+
+	for name, described := range newMethods {
+		if _, ok := oldMethods[name]; !ok {
+			report.Added = append(report.Added, described...)
+		}
+	}
+	for name, described := range oldMethods {
+		newDescribed, ok := newMethods[name]
+		if !ok {
+			report.Removed = append(report.Removed, described...)
+			continue
+		}
+		if strings.Join(described, "\n") != strings.Join(newDescribed, "\n") {
+			report.Changed = append(report.Changed, abiFunctionChange{Name: name, Old: described, New: newDescribed})
+		}
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Slice(report.Changed, func(i, j int) bool { return report.Changed[i].Name < report.Changed[j].Name })
+	return report
+}
+
+// stdoutIsTerminal reports whether colored output can be used
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func abiDiff(c *cli.Context) error {
+	output := c.String("output")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format %q, expected text or json", output)
+	}
+
+	oldABI, err := loadABIFile(c.String("old-abi"))
+	if err != nil {
+		return err
+	}
+	newABI, err := loadABIFile(c.String("new-abi"))
+	if err != nil {
+		return err
+	}
+
+	report := diffABIs(oldABI, newABI)
+
+	if output == "json" {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	colored := stdoutIsTerminal()
+	paint := func(color, text string) string {
+		if !colored {
+			return text
+		}
+		return color + text + colorReset
+	}
+
+	if len(report.Added)+len(report.Removed)+len(report.Changed) == 0 {
+		fmt.Println("No function changes")
+		return nil
+	}
+	for _, added := range report.Added {
+		fmt.Println(paint(colorGreen, "+ "+added))
+	}
+	for _, removed := range report.Removed {
+		fmt.Println(paint(colorRed, "- "+removed))
+	}
+	for _, change := range report.Changed {
+		fmt.Println(paint(colorYellow, "~ "+change.Name))
+		for _, old := range change.Old {
+			fmt.Println(paint(colorYellow, "    old: "+old))
+		}
+		for _, updated := range change.New {
+			fmt.Println(paint(colorYellow, "    new: "+updated))
+		}
+	}
+	return nil
+}
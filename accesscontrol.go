@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+const accessControlABI = `[
+  {
+    "inputs": [
+      { "name": "role", "type": "bytes32" },
+      { "name": "account", "type": "address" }
+    ],
+    "name": "hasRole",
+    "outputs": [{ "name": "", "type": "bool" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "paused",
+    "outputs": [{ "name": "", "type": "bool" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var accessControl = mustParseABI(accessControlABI)
+
+// Words that may follow a function's parameter list but are not modifiers
+var functionKeywords = map[string]bool{
+	"public": true, "external": true, "internal": true, "private": true,
+	"view": true, "pure": true, "payable": true, "nonpayable": true,
+	"virtual": true, "override": true, "returns": true,
+}
+
+var modifierPattern = regexp.MustCompile(`([A-Za-z_$][A-Za-z0-9_$]*)\s*(\(([^()]*)\))?`)
+
+// functionModifiers finds the definitions of function name in the sources
+// and returns the modifiers applied to each, with their arguments.
+func functionModifiers(sources map[string]string, name string) [][][2]string {
+	definition := regexp.MustCompile(`function\s+` + regexp.QuoteMeta(name) + `\s*\(`)
+
+	var found [][][2]string
+	for _, source := range sources {
+		for _, loc := range definition.FindAllStringIndex(source, -1) {
+			// Skip the balanced parameter list
+			depth, i := 1, loc[1]
+			for ; i < len(source) && depth > 0; i++ {
+				switch source[i] {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+			}
+
+			end := strings.IndexAny(source[i:], "{;")
+			if end < 0 {
+				continue
+			}
+			header := source[i : i+end]
+			// Return values look like a modifier with arguments
+			if r := strings.Index(header, "returns"); r >= 0 {
+				header = header[:r]
+			}
+
+			var modifiers [][2]string
+			for _, match := range modifierPattern.FindAllStringSubmatch(header, -1) {
+				if !functionKeywords[match[1]] {
+					modifiers = append(modifiers, [2]string{match[1], strings.TrimSpace(match[3])})
+				}
+			}
+			found = append(found, modifiers)
+		}
+	}
+	return found
+}
+
+// callGetter calls a parameterless view function by signature and returns
+// the raw 32-byte result.
+func callGetter(client *ethclient.Client, contract common.Address, signature string) ([]byte, error) {
+	msg := ethereum.CallMsg{
+		To:   &contract,
+		Data: crypto.Keccak256([]byte(signature))[:4],
+	}
+	result, err := client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 32 {
+		return nil, fmt.Errorf("%s returned %d bytes", signature, len(result))
+	}
+	return result, nil
+}
+
+// checkModifier reports whether account passes the modifier, or an
+// explanation when the modifier is not understood.
+func checkModifier(client *ethclient.Client, contract, account common.Address, name, args string) (bool, string) {
+	switch name {
+	case "nonReentrant":
+		return true, "reentrancy guard, not access control"
+	case "whenNotPaused", "whenPaused":
+		result, err := callContract(client, contract, accessControl, "paused")
+		if err != nil {
+			return false, fmt.Sprintf("could not read paused(): %v", err)
+		}
+		paused := result[0].(bool)
+		return paused == (name == "whenPaused"), fmt.Sprintf("paused() = %t", paused)
+	case "onlyRole":
+		var role common.Hash
+		switch {
+		case args == "DEFAULT_ADMIN_ROLE":
+			// bytes32(0)
+		case strings.HasPrefix(args, "0x"):
+			role = common.HexToHash(args)
+		default:
+			// Public role constants have getters; fall back to the usual keccak definition
+			if result, err := callGetter(client, contract, args+"()"); err == nil {
+				role = common.BytesToHash(result)
+			} else {
+				role = crypto.Keccak256Hash([]byte(args))
+			}
+		}
+		result, err := callContract(client, contract, accessControl, "hasRole", role, account)
+		if err != nil {
+			return false, fmt.Sprintf("could not read hasRole(): %v", err)
+		}
+		return result[0].(bool), fmt.Sprintf("hasRole(%s) = %t", args, result[0].(bool))
+	}
+
+	// onlyOwner, onlyAdmin, onlyGovernance, ... compare against owner(), admin(), governance()
+	if strings.HasPrefix(name, "only") && len(name) > 4 {
+		getter := strings.ToLower(name[4:5]) + name[5:]
+		result, err := callGetter(client, contract, getter+"()")
+		if err != nil || new(big.Int).SetBytes(result).BitLen() > 160 {
+			return false, fmt.Sprintf("no %s() address getter, check manually", getter)
+		}
+		holder := common.BytesToAddress(result)
+		return holder == account, fmt.Sprintf("%s() = %s", getter, holder.Hex())
+	}
+	return false, "unrecognised modifier, check manually"
+}
+
+func checkAccessControl(c *cli.Context) error {
+	index := c.Int("from")
+	function := c.String("function")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	account := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	contractABI, err := fetchSourcifyABI(chainID.Int64(), contractAddress)
+	if err != nil {
+		return err
+	}
+
+	// Accept a selector, a name or a full signature
+	var method *abi.Method
+	for _, m := range contractABI.Methods {
+		if strings.EqualFold(function, fmt.Sprintf("0x%x", m.ID)) || function == m.Name || function == m.Sig {
+			method = &m
+			break
+		}
+	}
+	if method == nil {
+		return fmt.Errorf("function %s not found in the verified ABI", function)
+	}
+
+	sources, err := fetchSourcifySources(chainID.Int64(), contractAddress)
+	if err != nil {
+		return err
+	}
+
+	definitions := functionModifiers(sources, method.Name)
+	if len(definitions) == 0 {
+		return fmt.Errorf("definition of %s not found in the verified sources", method.Name)
+	}
+	if len(definitions) > 1 {
+		fmt.Printf("Found %d definitions of %s (overloads or overrides), checking each\n", len(definitions), method.Name)
+	}
+
+	fmt.Printf("Function: %s (0x%x)\n", method.Sig, method.ID)
+	fmt.Printf("Account:  %s\n", account.Hex())
+
+	allowed := true
+	for _, modifiers := range definitions {
+		if len(modifiers) == 0 {
+			fmt.Println("No modifiers; access checks may still be inside the function body")
+			continue
+		}
+		for _, modifier := range modifiers {
+			passed, detail := checkModifier(client, contractAddress, account, modifier[0], modifier[1])
+			status := "PASS"
+			if !passed {
+				status = "FAIL"
+				allowed = false
+			}
+			label := modifier[0]
+			if modifier[1] != "" {
+				label += "(" + modifier[1] + ")"
+			}
+			fmt.Printf("  %-4s %s: %s\n", status, label, detail)
+		}
+	}
+
+	if allowed {
+		fmt.Println("Account has access")
+	} else {
+		fmt.Println("Account does not have access")
+	}
+	return nil
+}
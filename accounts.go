@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+
+	Signer "eth-manage/signer"
+)
+
+// hsmSigner signs all transactions when --hsm-lib is given
+var hsmSigner *Signer.PKCS11Signer
+
+// openKeyStore opens the keystore in the configured keystore directory
+func openKeyStore() *keystore.KeyStore {
+	return keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// unlockAccount loads the keystore account at index and unlocks it with the
+// configured keystore password. When an HSM is configured its key is the
+// sender instead and index is ignored.
+func unlockAccount(keyStore *keystore.KeyStore, index int) (accounts.Account, error) {
+	if hsmSigner != nil {
+		return accounts.Account{Address: hsmSigner.Address()}, nil
+	}
+
+	accountList := keyStore.Accounts()
+	if index < 0 || index >= len(accountList) {
+		return accounts.Account{}, fmt.Errorf("invalid sender account index")
+	}
+
+	account := accountList[index]
+	if err := keyStore.Unlock(account, keystorePassword); err != nil {
+		return accounts.Account{}, fmt.Errorf("failed to unlock account: %w", err)
+	}
+	return account, nil
+}
+
+// senderAddress returns the address transactions from the account at index
+// are sent from, without unlocking it: the HSM key when one is configured,
+// otherwise the keystore account.
+func senderAddress(keyStore *keystore.KeyStore, index int) (common.Address, error) {
+	if hsmSigner != nil {
+		return hsmSigner.Address(), nil
+	}
+
+	accountList := keyStore.Accounts()
+	if index < 0 || index >= len(accountList) {
+		return common.Address{}, fmt.Errorf("invalid sender account index")
+	}
+	return accountList[index].Address, nil
+}
+
+// accountSigner returns the configured HSM, or the unlocked keystore account
+// when no HSM is configured
+func accountSigner(keyStore *keystore.KeyStore, account accounts.Account) Signer.Signer {
+	if hsmSigner != nil {
+		return hsmSigner
+	}
+	return Signer.NewKeystoreSigner(keyStore, account)
+}
+
+// signHash signs a 32-byte hash with the account returned by unlockAccount.
+// V is the recovery ID (0 or 1).
+func signHash(keyStore *keystore.KeyStore, account accounts.Account, hash []byte) ([]byte, error) {
+	return accountSigner(keyStore, account).SignHash(hash)
+}
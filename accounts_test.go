@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestSenderAddress(t *testing.T) {
+	chain := newTestChain(t, 2, nil)
+
+	for i, account := range chain.accounts {
+		from, err := senderAddress(chain.keyStore, i)
+		if err != nil || from != account.Address {
+			t.Errorf("senderAddress(%d) = %s, %v, want %s", i, from.Hex(), err, account.Address.Hex())
+		}
+	}
+	for _, index := range []int{-1, 2} {
+		if _, err := senderAddress(chain.keyStore, index); err == nil {
+			t.Errorf("senderAddress(%d) succeeded, want an error", index)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// formatAllowance prints the maximum allowance as unlimited
+func formatAllowance(allowance *big.Int, decimal int) string {
+	if allowance.Cmp(math.MaxBig256) == 0 {
+		return "unlimited"
+	}
+	return formatBigIntToDecimal(allowance, decimal)
+}
+
+func approveToken(c *cli.Context) error {
+	if c.IsSet("amount") == c.Bool("unlimited") {
+		return fmt.Errorf("exactly one of --amount and --unlimited is required")
+	}
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	spender, err := resolveAddress(context.Background(), client, c.String("spender"))
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("from"))
+	if err != nil {
+		return err
+	}
+
+	decimal, err := decimalsFlag(c, client, tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	amount := math.MaxBig256
+	if c.IsSet("amount") {
+		amount, err = parseAmount(c.String("amount"), decimal)
+		if err != nil {
+			return err
+		}
+	}
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	data, err := tokenContract.ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack approve data: %w", err)
+	}
+
+	tx, err := sendTransaction(client, keyStore, account, tokenAddress, big.NewInt(0), data)
+	if err != nil {
+		return fmt.Errorf("failed to approve %s: %w", spender.Hex(), err)
+	}
+	fmt.Printf("Approval of %s for %s sent: %s\n", formatAllowance(amount, decimal), spender.Hex(), tx.Hash().Hex())
+
+	if c.Bool("wait") {
+		if _, err := waitMined(client, tx, c.Duration("timeout")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkAllowance(c *cli.Context) error {
+	index := c.Int("index")
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	owner := accountList[index].Address
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	spender, err := resolveAddress(context.Background(), client, c.String("spender"))
+	if err != nil {
+		return err
+	}
+
+	decimal, err := decimalsFlag(c, client, tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	allowance, err := tokenContract.Allowance(owner, spender)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Allowance of %s for %s: %s\n", spender.Hex(), owner.Hex(), formatAllowance(allowance, decimal))
+	return nil
+}
+
+func transferFrom(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	owner, err := resolveAddress(context.Background(), client, c.String("owner"))
+	if err != nil {
+		return err
+	}
+	toAddress, err := resolveAddress(context.Background(), client, c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("from-index"))
+	if err != nil {
+		return err
+	}
+
+	decimal, err := decimalsFlag(c, client, tokenAddress)
+	if err != nil {
+		return err
+	}
+	amount, err := parseAmount(c.String("amount"), decimal)
+	if err != nil {
+		return err
+	}
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+
+	// Fail here rather than on chain
+	allowance, err := tokenContract.Allowance(owner, account.Address)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) < 0 {
+		return fmt.Errorf("allowance of %s for %s is %s, less than the %s requested; the owner must approve-token first",
+			account.Address.Hex(), owner.Hex(), formatAllowance(allowance, decimal), formatBigIntToDecimal(amount, decimal))
+	}
+	balance, err := tokenContract.BalanceOf(owner.Hex())
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("owner %s holds %s, less than the %s requested", owner.Hex(), formatBigIntToDecimal(balance, decimal), formatBigIntToDecimal(amount, decimal))
+	}
+
+	data, err := tokenContract.ABI.Pack("transferFrom", owner, toAddress, amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack transferFrom data: %w", err)
+	}
+
+	tx, err := sendTransaction(client, keyStore, account, tokenAddress, big.NewInt(0), data)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("transferFrom transaction sent: %s\n", tx.Hash().Hex())
+
+	if c.Bool("wait") {
+		if _, err := waitMined(client, tx, c.Duration("timeout")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Compound V3 USDC market (Comet) on Ethereum mainnet
+const compoundV3USDCAddress = "0xc3d688B66703497DAA19211EEdff47f25384cdc3"
+
+const cometABI = `[
+  {
+    "inputs": [],
+    "name": "getUtilization",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "utilization", "type": "uint256" }],
+    "name": "getSupplyRate",
+    "outputs": [{ "name": "", "type": "uint64" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var comet = mustParseABI(cometABI)
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// apyPrecision is the mantissa size used for interest calculations
+const apyPrecision = 256
+
+// compoundingPeriods maps --compound-frequency to periods per year
+var compoundingPeriods = map[string]float64{
+	"daily":   365,
+	"weekly":  52,
+	"monthly": 12,
+}
+
+func newAPYFloat(x float64) *big.Float {
+	return new(big.Float).SetPrec(apyPrecision).SetFloat64(x)
+}
+
+// bigExp computes e^x with a Taylor series, adequate for interest rates
+func bigExp(x *big.Float) *big.Float {
+	sum := newAPYFloat(1)
+	term := newAPYFloat(1)
+	for k := 1; k < 200; k++ {
+		term.Mul(term, x)
+		term.Quo(term, newAPYFloat(float64(k)))
+		sum.Add(sum, term)
+		if term.Sign() == 0 || term.MantExp(nil)-sum.MantExp(nil) < -apyPrecision {
+			break
+		}
+	}
+	return sum
+}
+
+// bigLog1p computes ln(1+x) for |x| < 1 with its Taylor series
+func bigLog1p(x *big.Float) *big.Float {
+	sum := newAPYFloat(0)
+	power := newAPYFloat(1)
+	for k := 1; k < 2000; k++ {
+		power.Mul(power, x)
+		term := new(big.Float).SetPrec(apyPrecision).Quo(power, newAPYFloat(float64(k)))
+		if k%2 == 0 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+		if term.Sign() == 0 || term.MantExp(nil)-sum.MantExp(nil) < -apyPrecision {
+			break
+		}
+	}
+	return sum
+}
+
+// growthFactor returns the factor by which a balance grows at the nominal
+// annual rate over the given number of years. periodsPerYear of 0 means
+// continuous compounding.
+func growthFactor(rate *big.Float, years *big.Float, periodsPerYear float64) *big.Float {
+	if periodsPerYear == 0 {
+		return bigExp(new(big.Float).SetPrec(apyPrecision).Mul(rate, years))
+	}
+
+	// (1 + r/n)^(n*t) = exp(n*t * ln(1 + r/n))
+	periodRate := new(big.Float).SetPrec(apyPrecision).Quo(rate, newAPYFloat(periodsPerYear))
+	periods := new(big.Float).SetPrec(apyPrecision).Mul(years, newAPYFloat(periodsPerYear))
+	return bigExp(periods.Mul(periods, bigLog1p(periodRate)))
+}
+
+// aaveSupplyRate returns the nominal annual supply rate of an Aave V3 reserve
+func aaveSupplyRate(client *ethclient.Client, asset common.Address) (*big.Float, error) {
+	result, err := callContract(client, common.HexToAddress(aaveV3PoolAddress), aaveV3Pool, "getReserveData", asset)
+	if err != nil {
+		return nil, err
+	}
+
+	reserve := *abi.ConvertType(result[0], new(aaveReserveData)).(*aaveReserveData)
+	if reserve.ATokenAddress == (common.Address{}) {
+		return nil, fmt.Errorf("%s is not an Aave V3 reserve", asset.Hex())
+	}
+
+	// currentLiquidityRate is an annual rate in ray (1e27)
+	rate := new(big.Float).SetPrec(apyPrecision).SetInt(reserve.CurrentLiquidityRate)
+	return rate.Quo(rate, new(big.Float).SetPrec(apyPrecision).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil))), nil
+}
+
+// compoundSupplyRate returns the nominal annual supply rate of a Compound V3
+// market
+func compoundSupplyRate(client *ethclient.Client, market common.Address) (*big.Float, error) {
+	result, err := callContract(client, market, comet, "getUtilization")
+	if err != nil {
+		return nil, err
+	}
+	result, err = callContract(client, market, comet, "getSupplyRate", result[0].(*big.Int))
+	if err != nil {
+		return nil, err
+	}
+
+	// The supply rate is per second, scaled by 1e18
+	rate := newAPYFloat(float64(result[0].(uint64)))
+	rate.Mul(rate, newAPYFloat(secondsPerYear))
+	return rate.Quo(rate, newAPYFloat(1e18)), nil
+}
+
+func compoundAPY(c *cli.Context) error {
+	principal := c.Float64("principal")
+	days := c.Int("days")
+	frequency := c.String("compound-frequency")
+	protocol := c.String("protocol")
+
+	periodsPerYear, ok := compoundingPeriods[frequency]
+	if !ok && frequency != "continuously" {
+		return fmt.Errorf("invalid compound frequency %q, expected daily, weekly, monthly or continuously", frequency)
+	}
+	if days < 0 {
+		return fmt.Errorf("days must not be negative")
+	}
+
+	var rate *big.Float
+	switch protocol {
+	case "":
+		if !c.IsSet("apy") {
+			return fmt.Errorf("either --apy or --protocol is required")
+		}
+		rate = newAPYFloat(c.Float64("apy") / 100)
+
+	case "aave", "compound":
+		client, err := dialClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+		}
+
+		if protocol == "aave" {
+			asset, err := parseAddress(c.String("asset"))
+			if err != nil {
+				return fmt.Errorf("--asset is required for aave: %w", err)
+			}
+			rate, err = aaveSupplyRate(client, asset)
+			if err != nil {
+				return err
+			}
+		} else {
+			market, err := parseAddress(c.String("market"))
+			if err != nil {
+				return err
+			}
+			rate, err = compoundSupplyRate(client, market)
+			if err != nil {
+				return err
+			}
+		}
+		rateFloat, _ := rate.Float64()
+		fmt.Printf("Current %s supply rate: %.4f%%\n", protocol, rateFloat*100)
+
+	default:
+		return fmt.Errorf("invalid protocol %q, expected aave or compound", protocol)
+	}
+
+	years := new(big.Float).SetPrec(apyPrecision).Quo(newAPYFloat(float64(days)), newAPYFloat(365))
+	futureValue := growthFactor(rate, years, periodsPerYear)
+	futureValue.Mul(futureValue, newAPYFloat(principal))
+	interest := new(big.Float).SetPrec(apyPrecision).Sub(futureValue, newAPYFloat(principal))
+
+	effective := growthFactor(rate, newAPYFloat(1), periodsPerYear)
+	effective.Sub(effective, newAPYFloat(1))
+	effective.Mul(effective, newAPYFloat(100))
+
+	fmt.Printf("Principal:      %s\n", newAPYFloat(principal).Text('f', 6))
+	fmt.Printf("Period:         %d days, compounded %s\n", days, frequency)
+	fmt.Printf("Future value:   %s\n", futureValue.Text('f', 6))
+	fmt.Printf("Interest:       %s\n", interest.Text('f', 6))
+	fmt.Printf("Effective APY:  %s%%\n", effective.Text('f', 4))
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Arbitrum precompile addresses. NodeInterface is not deployed on chain;
+// the node answers eth_call to its address.
+const (
+	arbSysAddress        = "0x0000000000000000000000000000000000000064"
+	arbGasInfoAddress    = "0x000000000000000000000000000000000000006C"
+	nodeInterfaceAddress = "0x00000000000000000000000000000000000000C8"
+)
+
+const arbSysABI = `[
+  {
+    "inputs": [],
+    "name": "arbBlockNumber",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "getStorageGasAvailable",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+const arbGasInfoABI = `[
+  {
+    "inputs": [],
+    "name": "getPricesInWei",
+    "outputs": [
+      { "name": "perL2Tx", "type": "uint256" },
+      { "name": "perL1CalldataByte", "type": "uint256" },
+      { "name": "perStorageAllocation", "type": "uint256" },
+      { "name": "perArbGasBase", "type": "uint256" },
+      { "name": "perArbGasCongestion", "type": "uint256" },
+      { "name": "perArbGasTotal", "type": "uint256" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "getL1BaseFeeEstimate",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+const nodeInterfaceABI = `[
+  {
+    "inputs": [
+      { "name": "to", "type": "address" },
+      { "name": "contractCreation", "type": "bool" },
+      { "name": "data", "type": "bytes" }
+    ],
+    "name": "gasEstimateL1Component",
+    "outputs": [
+      { "name": "gasEstimateForL1", "type": "uint64" },
+      { "name": "baseFee", "type": "uint256" },
+      { "name": "l1BaseFeeEstimate", "type": "uint256" }
+    ],
+    "stateMutability": "payable",
+    "type": "function"
+  }
+]`
+
+var (
+	arbSys        = mustParseABI(arbSysABI)
+	arbGasInfo    = mustParseABI(arbGasInfoABI)
+	nodeInterface = mustParseABI(nodeInterfaceABI)
+)
+
+// isArbitrumChain reports whether chainID is Arbitrum One or Arbitrum Sepolia
+func isArbitrumChain(chainID *big.Int) bool {
+	return chainID.Cmp(big.NewInt(42161)) == 0 || chainID.Cmp(big.NewInt(421614)) == 0
+}
+
+// printArbitrumInfo reports L2 block and gas pricing from the Arbitrum
+// precompiles.
+func printArbitrumInfo(client *ethclient.Client) error {
+	sys := common.HexToAddress(arbSysAddress)
+	gasInfo := common.HexToAddress(arbGasInfoAddress)
+
+	result, err := callContract(client, sys, arbSys, "arbBlockNumber")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Arbitrum block number:     %s\n", result[0].(*big.Int).String())
+
+	result, err = callContract(client, gasInfo, arbGasInfo, "getPricesInWei")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("L2 gas price:              %s gwei\n", formatBigIntToDecimal(result[5].(*big.Int), 9))
+	fmt.Printf("L1 calldata price/byte:    %s gwei\n", formatBigIntToDecimal(result[1].(*big.Int), 9))
+	fmt.Printf("L2 price per transaction:  %s gwei\n", formatBigIntToDecimal(result[0].(*big.Int), 9))
+
+	result, err = callContract(client, gasInfo, arbGasInfo, "getL1BaseFeeEstimate")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("L1 base fee estimate:      %s gwei\n", formatBigIntToDecimal(result[0].(*big.Int), 9))
+
+	result, err = callContract(client, sys, arbSys, "getStorageGasAvailable")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Storage gas available:     %s\n", result[0].(*big.Int).String())
+	return nil
+}
+
+// arbitrumL1Gas returns the part of an Arbitrum gas estimate that pays for
+// posting the transaction's calldata to L1, as reported by NodeInterface.
+// eth_estimateGas on Arbitrum already includes it.
+func arbitrumL1Gas(client *ethclient.Client, to common.Address, data []byte) (uint64, error) {
+	result, err := callContract(client, common.HexToAddress(nodeInterfaceAddress), nodeInterface, "gasEstimateL1Component", to, false, data)
+	if err != nil {
+		return 0, err
+	}
+	return result[0].(uint64), nil
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Balancer V2 Vault, deployed at the same address on every supported chain
+var balancerVaultAddress = common.HexToAddress("0xBA12222222228d8Ba445958a75a0704d566BF2C8")
+
+const balancerVaultABI = `[
+  {
+    "inputs": [{ "name": "poolId", "type": "bytes32" }],
+    "name": "getPoolTokens",
+    "outputs": [
+      { "name": "tokens", "type": "address[]" },
+      { "name": "balances", "type": "uint256[]" },
+      { "name": "lastChangeBlock", "type": "uint256" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "poolId", "type": "bytes32" }],
+    "name": "getPool",
+    "outputs": [
+      { "name": "", "type": "address" },
+      { "name": "", "type": "uint8" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+const balancerPoolABI = `[
+  {
+    "inputs": [],
+    "name": "getSwapFeePercentage",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "getNormalizedWeights",
+    "outputs": [{ "name": "", "type": "uint256[]" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	balancerVault = mustParseABI(balancerVaultABI)
+	balancerPool  = mustParseABI(balancerPoolABI)
+)
+
+// balancerToken is a token of a Balancer pool with its balance in whole
+// tokens and normalized weight, which is zero for pools without weights
+type balancerToken struct {
+	address  common.Address
+	symbol   string
+	decimals int
+	balance  *big.Int
+	amount   float64
+	weight   float64
+}
+
+// balancerPoolState is what the price calculations need to know of a pool
+type balancerPoolState struct {
+	address  common.Address
+	tokens   []balancerToken
+	fee      float64
+	weighted bool
+}
+
+// parsePoolID parses a 32-byte Balancer pool ID
+func parsePoolID(value string) ([32]byte, error) {
+	var poolID [32]byte
+	decoded, err := hexutil.Decode(value)
+	if err != nil || len(decoded) != 32 {
+		return poolID, fmt.Errorf("invalid pool ID, expected 32 bytes of hex")
+	}
+	copy(poolID[:], decoded)
+	return poolID, nil
+}
+
+// loadBalancerPool reads the tokens, balances, swap fee and, for weighted
+// pools, the weights of a pool
+func loadBalancerPool(c *cli.Context, client *ethclient.Client, poolID [32]byte) (*balancerPoolState, error) {
+	result, err := callContract(client, balancerVaultAddress, balancerVault, "getPool", poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool: %w", err)
+	}
+	pool := &balancerPoolState{address: result[0].(common.Address)}
+
+	result, err = callContract(client, balancerVaultAddress, balancerVault, "getPoolTokens", poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool tokens: %w", err)
+	}
+	addresses := result[0].([]common.Address)
+	balances := result[1].([]*big.Int)
+
+	result, err = callContract(client, pool.address, balancerPool, "getSwapFeePercentage")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swap fee: %w", err)
+	}
+	pool.fee = normalizedAmount(result[0].(*big.Int), 18)
+
+	// Only weighted pools report weights
+	var weights []*big.Int
+	if result, err := callContract(client, pool.address, balancerPool, "getNormalizedWeights"); err == nil {
+		weights = result[0].([]*big.Int)
+		pool.weighted = len(weights) == len(addresses)
+	}
+
+	id, err := resolveChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, address := range addresses {
+		symbol, decimals, err := tokenDisplayInfo(c, client, id.Int64(), address)
+		if err != nil {
+			return nil, err
+		}
+		if symbol == "" {
+			symbol = address.Hex()
+		}
+		token := balancerToken{
+			address:  address,
+			symbol:   symbol,
+			decimals: decimals,
+			balance:  balances[i],
+			amount:   normalizedAmount(balances[i], decimals),
+		}
+		if pool.weighted {
+			token.weight = normalizedAmount(weights[i], 18)
+		}
+		pool.tokens = append(pool.tokens, token)
+	}
+	return pool, nil
+}
+
+// weightedSpotPrice is the price of one whole token out in tokens in, before
+// fees: (Bi / Wi) / (Bo / Wo)
+func weightedSpotPrice(in, out balancerToken) float64 {
+	return (in.amount / in.weight) / (out.amount / out.weight)
+}
+
+// weightedOutGivenIn is the amount of token out a swap of amountIn returns:
+// Bo * (1 - (Bi / (Bi + Ai * (1 - fee))) ^ (Wi / Wo))
+func weightedOutGivenIn(in, out balancerToken, amountIn, fee float64) float64 {
+	amountInAfterFee := amountIn * (1 - fee)
+	return out.amount * (1 - math.Pow(in.amount/(in.amount+amountInAfterFee), in.weight/out.weight))
+}
+
+func balancerPoolInfo(c *cli.Context) error {
+	poolID, err := parsePoolID(c.String("pool-id"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	pool, err := loadBalancerPool(c, client, poolID)
+	if err != nil {
+		return err
+	}
+	if len(pool.tokens) == 0 {
+		return fmt.Errorf("pool has no tokens")
+	}
+
+	base := pool.tokens[0]
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "TOKEN\tADDRESS\tBALANCE\tWEIGHT\tPRICE (%s)\n", base.symbol)
+	for _, token := range pool.tokens {
+		weight, price := "-", "-"
+		if pool.weighted {
+			weight = fmt.Sprintf("%.2f%%", token.weight*100)
+			if token.amount > 0 {
+				price = fmt.Sprintf("%.6g", weightedSpotPrice(base, token))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", token.symbol, token.address.Hex(), formatBigIntToDecimal(token.balance, token.decimals), weight, price)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Pool: %s\n", pool.address.Hex())
+	fmt.Printf("Swap fee: %.4f%%\n", pool.fee*100)
+	if !pool.weighted {
+		fmt.Println("Not a weighted pool; prices depend on the pool's own invariant and are not shown")
+	}
+	return nil
+}
+
+func balancerPriceImpact(c *cli.Context) error {
+	poolID, err := parsePoolID(c.String("pool-id"))
+	if err != nil {
+		return err
+	}
+	tokenIn, err := parseAddress(c.String("token-in"))
+	if err != nil {
+		return err
+	}
+	var tokenOut *common.Address
+	if c.IsSet("token-out") {
+		address, err := parseAddress(c.String("token-out"))
+		if err != nil {
+			return err
+		}
+		tokenOut = &address
+	}
+	amountIn := c.Float64("amount")
+	if amountIn <= 0 {
+		return fmt.Errorf("--amount must be positive")
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	pool, err := loadBalancerPool(c, client, poolID)
+	if err != nil {
+		return err
+	}
+	if !pool.weighted {
+		return fmt.Errorf("price impact is only calculated for weighted pools")
+	}
+
+	var in, out *balancerToken
+	for i := range pool.tokens {
+		token := &pool.tokens[i]
+		switch {
+		case token.address == tokenIn:
+			in = token
+		case tokenOut != nil && token.address == *tokenOut:
+			out = token
+		case tokenOut == nil && out == nil:
+			// The first other token of the pool
+			out = token
+		}
+	}
+	if in == nil {
+		return fmt.Errorf("%s is not a token of the pool", tokenIn.Hex())
+	}
+	if out == nil {
+		return fmt.Errorf("token out is not a token of the pool")
+	}
+
+	spot := weightedSpotPrice(*in, *out)
+	amountOut := weightedOutGivenIn(*in, *out, amountIn, pool.fee)
+	execution := amountIn / amountOut
+	impact := (execution/spot - 1) * 100
+	feeFree := amountIn * (1 - pool.fee) / amountOut
+	slippage := (feeFree/spot - 1) * 100
+
+	fmt.Printf("Swap:            %g %s -> %s\n", amountIn, in.symbol, out.symbol)
+	fmt.Printf("Amount out:      %.6f %s\n", amountOut, out.symbol)
+	fmt.Printf("Spot price:      %.6g %s per %s\n", spot, in.symbol, out.symbol)
+	fmt.Printf("Execution price: %.6g %s per %s\n", execution, in.symbol, out.symbol)
+	fmt.Printf("Price impact:    %.4f%% (%.4f%% excluding the %.4f%% swap fee)\n", impact, slippage, pool.fee*100)
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// tokenDisplayInfo returns the symbol and decimals used to print balances of
+// a token, preferring the cached token list over on-chain queries. The symbol
+// is empty when the token does not report one.
+func tokenDisplayInfo(c *cli.Context, client *ethclient.Client, chainID int64, tokenAddress common.Address) (string, int, error) {
+	if entry, ok := lookupTokenList(chainID, tokenAddress); ok && !c.IsSet("decimal") {
+		return entry.Symbol, int(entry.Decimals), nil
+	}
+
+	decimal, err := decimalsFlag(c, client, tokenAddress)
+	if err != nil {
+		return "", 0, err
+	}
+	if tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client); err == nil {
+		if symbol, err := tokenContract.Symbol(); err == nil {
+			return symbol, decimal, nil
+		}
+	}
+	return "", decimal, nil
+}
+
+// runConcurrently calls fn for every index in [0, n) with at most concurrency
+// calls in flight and returns their errors by index.
+func runConcurrently(n, concurrency int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// checkBalances prints a table of ETH and token balances for several accounts
+// and tokens. Every failed call is listed and makes the command fail.
+func checkBalances(c *cli.Context) error {
+	concurrency := c.Int("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	accountList := openKeyStore().Accounts()
+	var addresses []common.Address
+	if c.Bool("all-accounts") {
+		for _, account := range accountList {
+			addresses = append(addresses, account.Address)
+		}
+	} else {
+		index := c.Int("index")
+		if !c.IsSet("index") || index < 0 || index >= len(accountList) {
+			return fmt.Errorf("invalid account index")
+		}
+		addresses = append(addresses, accountList[index].Address)
+	}
+	if len(addresses) == 0 {
+		return fmt.Errorf("no accounts in keystore")
+	}
+
+	var tokens []common.Address
+	inputs := strings.Split(c.String("token-addresses"), ",")
+	if c.IsSet("token-address") {
+		inputs = append(inputs, c.String("token-address"))
+	}
+	for _, input := range inputs {
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+		tokenAddress, err := parseAddress(strings.TrimSpace(input))
+		if err != nil {
+			return err
+		}
+		tokens = append(tokens, tokenAddress)
+	}
+	if c.IsSet("decimal") && len(tokens) > 1 {
+		return fmt.Errorf("--decimal cannot be used with more than one token")
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	var failures []string
+
+	symbols := make([]string, len(tokens))
+	decimals := make([]int, len(tokens))
+	tokenErrs := runConcurrently(len(tokens), concurrency, func(i int) error {
+		var err error
+		symbols[i], decimals[i], err = tokenDisplayInfo(c, client, chainID.Int64(), tokens[i])
+		return err
+	})
+	for i, err := range tokenErrs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", tokens[i].Hex(), err))
+		}
+		if symbols[i] == "" {
+			symbols[i] = tokens[i].Hex()
+		}
+	}
+
+	// One cell per account for ETH followed by one per token
+	columns := len(tokens) + 1
+	balances := make([]*big.Int, len(addresses)*columns)
+	errs := runConcurrently(len(balances), concurrency, func(i int) error {
+		address, column := addresses[i/columns], i%columns
+		if column == 0 {
+			balance, err := client.BalanceAt(context.Background(), address, nil)
+			if err != nil {
+				return fmt.Errorf("failed to get ETH balance: %w", err)
+			}
+			balances[i] = balance
+			return nil
+		}
+
+		// Tokens without metadata were already reported
+		tokenAddress := tokens[column-1]
+		if tokenErrs[column-1] != nil {
+			return nil
+		}
+		balance, err := getTokenBalance(client, tokenAddress.Hex(), decimals[column-1], address)
+		if err != nil {
+			return fmt.Errorf("failed to get %s balance: %w", symbols[column-1], err)
+		}
+		balances[i] = balance
+		return nil
+	})
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", addresses[i/columns].Hex(), err))
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "ACCOUNT\tETH")
+	for _, symbol := range symbols {
+		fmt.Fprintf(w, "\t%s", symbol)
+	}
+	fmt.Fprintln(w)
+	for a, address := range addresses {
+		fmt.Fprint(w, address.Hex())
+		for column := 0; column < columns; column++ {
+			balance := balances[a*columns+column]
+			switch {
+			case balance == nil:
+				fmt.Fprint(w, "\terror")
+			case column == 0:
+				fmt.Fprintf(w, "\t%s", formatBigIntToDecimal(balance, 18))
+			default:
+				fmt.Fprintf(w, "\t%s", formatBigIntToDecimal(balance, decimals[column-1]))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		for _, failure := range failures {
+			fmt.Fprintln(os.Stderr, failure)
+		}
+		return fmt.Errorf("%d balance calls failed", len(failures))
+	}
+	return nil
+}
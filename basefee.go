@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+)
+
+// nextBaseFee applies the EIP-1559 base fee adjustment for a parent block
+// with the given base fee, gas used and gas limit (elasticity multiplier 2,
+// max change denominator 8).
+func nextBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	target := gasLimit / 2
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+
+	if gasUsed > target {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, big.NewInt(8))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(8))
+	return delta.Sub(baseFee, delta)
+}
+
+func predictNextBaseFee(c *cli.Context) error {
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if header.BaseFee == nil {
+		return fmt.Errorf("network does not use EIP-1559 base fees")
+	}
+
+	fmt.Printf("Block:            %s\n", header.Number.String())
+	fmt.Printf("Gas used:         %d of %d (%.1f%%)\n", header.GasUsed, header.GasLimit, float64(header.GasUsed)/float64(header.GasLimit)*100)
+	fmt.Printf("Current base fee: %s gwei\n", formatBigIntToDecimal(header.BaseFee, 9))
+
+	// Later blocks assume gas usage stays at the latest block's level
+	baseFee := header.BaseFee
+	for n := 1; n <= 10; n++ {
+		baseFee = nextBaseFee(baseFee, header.GasUsed, header.GasLimit)
+		switch n {
+		case 1:
+			fmt.Printf("Next block:       %s gwei\n", formatBigIntToDecimal(baseFee, 9))
+		case 5, 10:
+			fmt.Printf("In %2d blocks:     %s gwei\n", n, formatBigIntToDecimal(baseFee, 9))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// mintRow is one line of the batch mint recipients CSV
+type mintRow struct {
+	to      common.Address
+	tokenID *big.Int
+	amount  *big.Int
+}
+
+// readMintRecipients parses a CSV with address,token_id,amount columns. A
+// header line is skipped if present.
+func readMintRecipients(path string) ([]mintRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recipients file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipients file: %w", err)
+	}
+
+	var rows []mintRow
+	for i, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("line %d: expected address,token_id,amount", i+1)
+		}
+		address, err := parseAddress(strings.TrimSpace(record[0]))
+		if err != nil && i == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		tokenID, err := parseUint256(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		amount, err := parseUint256(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rows = append(rows, mintRow{to: address, tokenID: tokenID, amount: amount})
+	}
+	return rows, nil
+}
+
+// packMintCall packs a mint-style call, appending empty calldata for
+// implementations that take a trailing `bytes data` argument.
+func packMintCall(contractABI abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	m, ok := contractABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in ABI", method)
+	}
+	if len(m.Inputs) == len(args)+1 && m.Inputs[len(args)].Type.T == abi.BytesTy {
+		args = append(args, []byte{})
+	}
+	return contractABI.Pack(method, args...)
+}
+
+func batchMintEstimate(c *cli.Context) error {
+	fromIndex := c.Int("from-index")
+	mintMethod := c.String("mint-method")
+	batchMethod := c.String("batch-method")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	contractABI, err := loadABIFile(c.String("abi"))
+	if err != nil {
+		return err
+	}
+
+	rows, err := readMintRecipients(c.String("recipients"))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no recipients found")
+	}
+
+	accountList := openKeyStore().Accounts()
+	if fromIndex < 0 || fromIndex >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	from := accountList[fromIndex].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	estimate := func(data []byte) (uint64, error) {
+		return client.EstimateGas(context.Background(), ethereum.CallMsg{
+			From: from,
+			To:   &contractAddress,
+			Data: data,
+		})
+	}
+
+	// One mint per row
+	var individualGas uint64
+	for _, row := range rows {
+		data, err := packMintCall(contractABI, mintMethod, row.to, row.tokenID, row.amount)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s call: %w", mintMethod, err)
+		}
+		gas, err := estimate(data)
+		if err != nil {
+			return fmt.Errorf("failed to estimate %s for %s: %w", mintMethod, row.to.Hex(), err)
+		}
+		individualGas += gas
+	}
+
+	// Batch mints take a single recipient, so group the rows per recipient
+	var recipients []common.Address
+	ids := map[common.Address][]*big.Int{}
+	amounts := map[common.Address][]*big.Int{}
+	for _, row := range rows {
+		if _, seen := ids[row.to]; !seen {
+			recipients = append(recipients, row.to)
+		}
+		ids[row.to] = append(ids[row.to], row.tokenID)
+		amounts[row.to] = append(amounts[row.to], row.amount)
+	}
+
+	var batchGas uint64
+	for _, recipient := range recipients {
+		data, err := packMintCall(contractABI, batchMethod, recipient, ids[recipient], amounts[recipient])
+		if err != nil {
+			return fmt.Errorf("failed to pack %s call: %w", batchMethod, err)
+		}
+		gas, err := estimate(data)
+		if err != nil {
+			return fmt.Errorf("failed to estimate %s for %s: %w", batchMethod, recipient.Hex(), err)
+		}
+		batchGas += gas
+	}
+
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	individualCost := new(big.Int).Mul(new(big.Int).SetUint64(individualGas), gasPrice)
+	batchCost := new(big.Int).Mul(new(big.Int).SetUint64(batchGas), gasPrice)
+	savedGas := int64(individualGas) - int64(batchGas)
+	savedCost := new(big.Int).Sub(individualCost, batchCost)
+
+	fmt.Printf("Individual %s calls: %d, gas: %d, cost: %s ETH\n", mintMethod, len(rows), individualGas, formatBigIntToDecimal(individualCost, 18))
+	fmt.Printf("Batched %s calls:    %d, gas: %d, cost: %s ETH\n", batchMethod, len(recipients), batchGas, formatBigIntToDecimal(batchCost, 18))
+	fmt.Printf("Gas saved: %d (%.2f%%)\n", savedGas, float64(savedGas)/float64(individualGas)*100)
+	fmt.Printf("Cost saved: %s ETH\n", formatBigIntToDecimal(savedCost, 18))
+
+	ethPrice, err := ethUSDPrice(client)
+	if err != nil {
+		fmt.Printf("Cost saved (USD): unavailable (%v)\n", err)
+		return nil
+	}
+	fmt.Printf("Cost saved (USD): $%.2f\n", weiToUSD(savedCost, ethPrice))
+	return nil
+}
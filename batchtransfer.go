@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// transferRow is one line of a batch transfer recipients CSV
+type transferRow struct {
+	to     common.Address
+	amount string
+	value  *big.Int
+}
+
+// transferResult is the outcome of one transfer of a batch
+type transferResult struct {
+	nonce   uint64
+	hash    string
+	tx      *types.Transaction
+	receipt *types.Receipt
+	err     error
+}
+
+// readTransferRecipients parses a CSV with address,amount columns, amounts
+// in units of the given decimals. A header line is skipped if present.
+func readTransferRecipients(path string, decimals int) ([]transferRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recipients file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipients file: %w", err)
+	}
+
+	var rows []transferRow
+	for i, record := range records {
+		if len(record) != 2 {
+			return nil, fmt.Errorf("line %d: expected address,amount", i+1)
+		}
+		address, err := parseAddress(strings.TrimSpace(record[0]))
+		if err != nil && i == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		amount := strings.TrimSpace(record[1])
+		value, err := parseAmount(amount, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		rows = append(rows, transferRow{to: address, amount: amount, value: value})
+	}
+	return rows, nil
+}
+
+// sendBatch signs and sends one transaction per row from an unlocked
+// account, preparing at most
+// concurrency rows at once. Nonces come from a local counter starting at the
+// pending nonce. Building, signing and broadcasting happen one row at a time
+// under a lock, and the counter only advances once a broadcast succeeds, so
+// a failed row never leaves a nonce gap behind the rows sent after it.
+func sendBatch(c *cli.Context, client *ethclient.Client, keyStore *keystore.KeyStore, account accounts.Account, rows []transferRow, concurrency int,
+	build func(i int, row transferRow) (to common.Address, value *big.Int, gasLimit uint64, data []byte, err error)) ([]transferResult, error) {
+	pending, err := client.PendingNonceAt(context.Background(), account.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	nextNonce := pending
+	var sendLock sync.Mutex
+
+	results := make([]transferResult, len(rows))
+	runConcurrently(len(rows), concurrency, func(i int) error {
+		to, value, gasLimit, data, err := build(i, rows[i])
+		if err != nil {
+			results[i].err = err
+			return err
+		}
+
+		sendLock.Lock()
+		defer sendLock.Unlock()
+
+		tx, err := buildTransaction(c, client, nextNonce, to, value, gasLimit, data)
+		if err == nil {
+			tx, err = signTransaction(keyStore, account, tx)
+		}
+		if err == nil {
+			err = broadcastTransaction(client, tx)
+		}
+		if err != nil {
+			results[i].err = err
+			return err
+		}
+		results[i].nonce = nextNonce
+		results[i].hash = tx.Hash().Hex()
+		results[i].tx = tx
+		nextNonce++
+		return nil
+	})
+	return results, nil
+}
+
+// writeBatchResults writes the outcome of every row to a CSV file and
+// returns the number of failed transfers.
+func writeBatchResults(path string, rows []transferRow, results []transferResult) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create results file: %w", err)
+	}
+	defer file.Close()
+
+	failed := 0
+	w := csv.NewWriter(file)
+	w.Write([]string{"address", "amount", "nonce", "tx_hash", "error"})
+	for i, row := range rows {
+		errText := ""
+		if results[i].err != nil {
+			errText = results[i].err.Error()
+			failed++
+		}
+		nonce := ""
+		if results[i].hash != "" {
+			nonce = strconv.FormatUint(results[i].nonce, 10)
+		}
+		w.Write([]string{row.to.Hex(), row.amount, nonce, results[i].hash, errText})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return failed, fmt.Errorf("failed to write results file: %w", err)
+	}
+	return failed, nil
+}
+
+// reportBatch writes the results file and summarizes the batch
+func reportBatch(path string, rows []transferRow, results []transferResult) error {
+	failed, err := writeBatchResults(path, rows, results)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sent %d of %d transfers, results written to %s\n", len(rows)-failed, len(rows), path)
+	if failed > 0 {
+		return fmt.Errorf("%d transfers failed", failed)
+	}
+	return nil
+}
+
+func batchTransferEth(c *cli.Context) error {
+	concurrency := c.Int("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	rows, err := readTransferRecipients(c.String("recipients-file"), 18)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no recipients found")
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("from"))
+	if err != nil {
+		return err
+	}
+
+	results, err := sendBatch(c, client, keyStore, account, rows, concurrency,
+		func(i int, row transferRow) (common.Address, *big.Int, uint64, []byte, error) {
+			return row.to, row.value, 21000, nil, nil
+		})
+	if err != nil {
+		return err
+	}
+	return reportBatch(c.String("results-file"), rows, results)
+}
+
+func batchTransferToken(c *cli.Context) error {
+	fromIndex := c.Int("from")
+	concurrency := c.Int("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	decimal, err := decimalsFlag(c, client, tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	rows, err := readTransferRecipients(c.String("recipients-file"), decimal)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no recipients found")
+	}
+
+	// The sender is the account that signs, which is the HSM key when one
+	// is configured
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+	from := account.Address
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	balance, err := tokenContract.BalanceOf(from.Hex())
+	if err != nil {
+		return err
+	}
+
+	// Rows are funded in file order; the rest would revert on chain
+	funded := map[int]bool{}
+	remaining := new(big.Int).Set(balance)
+	for i, row := range rows {
+		if remaining.Cmp(row.value) < 0 {
+			if !c.Bool("continue-on-insufficient") {
+				return fmt.Errorf("balance of %s covers only the first %d of %d transfers (line for %s); pass --continue-on-insufficient to send those",
+					formatBigIntToDecimal(balance, decimal), i, len(rows), row.to.Hex())
+			}
+			fmt.Printf("Warning: insufficient balance for %s %s to %s, skipping\n", row.amount, tokenAddress.Hex(), row.to.Hex())
+			continue
+		}
+		remaining.Sub(remaining, row.value)
+		funded[i] = true
+	}
+
+	results, err := sendBatch(c, client, keyStore, account, rows, concurrency,
+		func(i int, row transferRow) (common.Address, *big.Int, uint64, []byte, error) {
+			if !funded[i] {
+				return common.Address{}, nil, 0, nil, fmt.Errorf("insufficient token balance")
+			}
+			data, err := tokenContract.ABI.Pack("transfer", row.to, row.value)
+			if err != nil {
+				return common.Address{}, nil, 0, nil, fmt.Errorf("failed to pack transfer data: %w", err)
+			}
+			gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{From: from, To: &tokenAddress, Data: data})
+			if err != nil {
+				gasLimit = fallbackTokenTransferGas
+			}
+			return tokenAddress, big.NewInt(0), gasLimit, data, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("wait") {
+		waitBatch(client, results, c.Duration("timeout"))
+	}
+	printBatchSummary(rows, results, c.Bool("wait"))
+	return reportBatch(c.String("results-file"), rows, results)
+}
+
+// waitBatch waits up to timeout for the receipt of each sent transfer. A
+// reverted or unmined transfer is recorded as failed.
+func waitBatch(client *ethclient.Client, results []transferResult, timeout time.Duration) {
+	fmt.Println("Waiting for receipts...")
+	runConcurrently(len(results), 10, func(i int) error {
+		if results[i].tx == nil {
+			return nil
+		}
+		receipt, err := waitMined(client, results[i].tx, timeout)
+		if receipt != nil {
+			results[i].receipt = receipt
+		}
+		if err != nil {
+			results[i].err = err
+		}
+		return err
+	})
+}
+
+// printBatchSummary prints the outcome of every transfer and, once receipts
+// are known, the gas they spent.
+func printBatchSummary(rows []transferRow, results []transferResult, mined bool) {
+	var gasUsed uint64
+	fee := new(big.Int)
+	succeeded, failed := 0, 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RECIPIENT\tAMOUNT\tSTATUS\tTX")
+	for i, row := range rows {
+		result := results[i]
+		status := "sent"
+		switch {
+		case result.err != nil:
+			status = "failed: " + result.err.Error()
+			failed++
+		case result.receipt != nil:
+			status = "mined"
+			succeeded++
+			gasUsed += result.receipt.GasUsed
+			if result.receipt.EffectiveGasPrice != nil {
+				fee.Add(fee, new(big.Int).Mul(result.receipt.EffectiveGasPrice, new(big.Int).SetUint64(result.receipt.GasUsed)))
+			}
+		default:
+			succeeded++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", row.to.Hex(), row.amount, status, result.hash)
+	}
+	w.Flush()
+
+	fmt.Printf("\nSucceeded: %d, failed: %d\n", succeeded, failed)
+	if mined {
+		fmt.Printf("Gas spent: %d (%s ETH)\n", gasUsed, formatBigIntToDecimal(fee, 18))
+	}
+}
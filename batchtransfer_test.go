@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSendBatchLeavesNoNonceGap(t *testing.T) {
+	chain := newTestChain(t, 1, nil)
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("batch-transfer-eth", flag.ContinueOnError), nil)
+
+	rows := make([]transferRow, 6)
+	for i := range rows {
+		rows[i] = transferRow{to: common.BigToAddress(big.NewInt(int64(0x1000 + i))), amount: "1", value: big.NewInt(1)}
+	}
+
+	// The node rejects rows 1 and 4, which cost more than the account holds,
+	// and row 2 fails before it is signed
+	rows[1].value = bigInt(t, "1000000000000000000000000")
+	rows[4].value = rows[1].value
+	failed := map[int]bool{1: true, 2: true, 4: true}
+	results, err := sendBatch(c, chain.client, chain.keyStore, chain.accounts[0], rows, 3,
+		func(i int, row transferRow) (common.Address, *big.Int, uint64, []byte, error) {
+			if i == 2 {
+				return common.Address{}, nil, 0, nil, errors.New("skipped")
+			}
+			return row.to, row.value, 21000, nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonces := map[uint64]bool{}
+	for i, result := range results {
+		if failed[i] != (result.err != nil) {
+			t.Fatalf("row %d: error %v", i, result.err)
+		}
+		if result.err == nil {
+			nonces[result.nonce] = true
+		}
+	}
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		if !nonces[nonce] {
+			t.Fatalf("nonces %v are not contiguous from 0", nonces)
+		}
+	}
+
+	chain.backend.Commit()
+	waitBatch(chain.client, results, 10*time.Second)
+	for i, result := range results {
+		if result.err == nil && result.receipt == nil {
+			t.Errorf("row %d: sent but not mined", i)
+		}
+	}
+	if nonce, err := chain.client.NonceAt(context.Background(), chain.accounts[0].Address, nil); err != nil || nonce != 3 {
+		t.Errorf("account nonce after the batch = %d, %v, want 3", nonce, err)
+	}
+}
+
+func TestWaitBatchTimeout(t *testing.T) {
+	chain := newTestChain(t, 1, nil)
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("batch-transfer-token", flag.ContinueOnError), nil)
+
+	rows := []transferRow{{to: common.HexToAddress("0x1000"), amount: "1", value: big.NewInt(1)}}
+	results, err := sendBatch(c, chain.client, chain.keyStore, chain.accounts[0], rows, 1,
+		func(i int, row transferRow) (common.Address, *big.Int, uint64, []byte, error) {
+			return row.to, row.value, 21000, nil, nil
+		})
+	if err != nil || results[0].err != nil {
+		t.Fatal(err, results[0].err)
+	}
+
+	// No block is mined, so the wait has to give up
+	start := time.Now()
+	waitBatch(chain.client, results, 100*time.Millisecond)
+	if results[0].err == nil {
+		t.Error("unmined transfer not reported as failed")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waitBatch took %s with a 100ms timeout", elapsed)
+	}
+}
@@ -0,0 +1,248 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when the beacon node has no such object, such as a
+// block for a missed slot
+var ErrNotFound = errors.New("not found")
+
+// Client struct
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Validator as returned by /eth/v1/beacon/states/{state_id}/validators/{validator_id}
+type Validator struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Status    string `json:"status"`
+	Validator struct {
+		Pubkey                     string `json:"pubkey"`
+		WithdrawalCredentials      string `json:"withdrawal_credentials"`
+		EffectiveBalance           string `json:"effective_balance"`
+		Slashed                    bool   `json:"slashed"`
+		ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+		ActivationEpoch            string `json:"activation_epoch"`
+		ExitEpoch                  string `json:"exit_epoch"`
+		WithdrawableEpoch          string `json:"withdrawable_epoch"`
+	} `json:"validator"`
+}
+
+// Block as returned by /eth/v2/beacon/blocks/{block_id}
+type Block struct {
+	Version   string `json:"version"`
+	Finalized bool   `json:"finalized"`
+	Message   struct {
+		Slot          string `json:"slot"`
+		ProposerIndex string `json:"proposer_index"`
+		ParentRoot    string `json:"parent_root"`
+		StateRoot     string `json:"state_root"`
+		Body          struct {
+			Graffiti          string             `json:"graffiti"`
+			Attestations      []json.RawMessage  `json:"attestations"`
+			Deposits          []json.RawMessage  `json:"deposits"`
+			VoluntaryExits    []json.RawMessage  `json:"voluntary_exits"`
+			ProposerSlashings []ProposerSlashing `json:"proposer_slashings"`
+			AttesterSlashings []AttesterSlashing `json:"attester_slashings"`
+			ExecutionPayload  *struct {
+				BlockNumber  string   `json:"block_number"`
+				BlockHash    string   `json:"block_hash"`
+				FeeRecipient string   `json:"fee_recipient"`
+				GasUsed      string   `json:"gas_used"`
+				Transactions []string `json:"transactions"`
+			} `json:"execution_payload"`
+		} `json:"body"`
+	} `json:"message"`
+}
+
+// ProposerSlashing proves a proposer signed two different blocks for a slot
+type ProposerSlashing struct {
+	SignedHeader1 struct {
+		Message struct {
+			Slot          string `json:"slot"`
+			ProposerIndex string `json:"proposer_index"`
+		} `json:"message"`
+	} `json:"signed_header_1"`
+}
+
+// AttesterSlashing proves validators made two conflicting attestations. The
+// slashed validators are those attesting in both.
+type AttesterSlashing struct {
+	Attestation1 IndexedAttestation `json:"attestation_1"`
+	Attestation2 IndexedAttestation `json:"attestation_2"`
+}
+
+// IndexedAttestation lists the validators behind an attestation
+type IndexedAttestation struct {
+	AttestingIndices []string `json:"attesting_indices"`
+}
+
+// SlashedIndices returns the validators slashed by an attester slashing
+func (s AttesterSlashing) SlashedIndices() []string {
+	inFirst := map[string]bool{}
+	for _, index := range s.Attestation1.AttestingIndices {
+		inFirst[index] = true
+	}
+
+	var slashed []string
+	for _, index := range s.Attestation2.AttestingIndices {
+		if inFirst[index] {
+			slashed = append(slashed, index)
+		}
+	}
+	return slashed
+}
+
+// AttestationReward is a validator's reward for its attestation duties in an
+// epoch, in Gwei, as returned by /eth/v1/beacon/rewards/attestations/{epoch}.
+// Penalties are negative.
+type AttestationReward struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	InclusionDelay string `json:"inclusion_delay"`
+	Inactivity     string `json:"inactivity"`
+}
+
+// NewClient creates a client for the beacon node REST API at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Validator fetches a validator from the head state by index or public key
+func (c *Client) Validator(id string) (*Validator, error) {
+	var validator Validator
+	if err := c.get("/eth/v1/beacon/states/head/validators/"+id, &validator); err != nil {
+		return nil, err
+	}
+	return &validator, nil
+}
+
+// Block fetches a signed beacon block by slot, root, or "head"/"finalized"
+func (c *Client) Block(id string) (*Block, error) {
+	var response struct {
+		Version   string `json:"version"`
+		Finalized bool   `json:"finalized"`
+		Data      struct {
+			Message json.RawMessage `json:"message"`
+		} `json:"data"`
+	}
+
+	if err := c.getRaw("/eth/v2/beacon/blocks/"+id, &response); err != nil {
+		return nil, err
+	}
+
+	block := Block{
+		Version:   response.Version,
+		Finalized: response.Finalized,
+	}
+	if err := json.Unmarshal(response.Data.Message, &block.Message); err != nil {
+		return nil, fmt.Errorf("failed to decode beacon block: %w", err)
+	}
+	return &block, nil
+}
+
+// AttestationRewards fetches the attestation rewards of the given validator
+// indices for an epoch
+func (c *Client) AttestationRewards(epoch uint64, indices []string) ([]AttestationReward, error) {
+	var rewards struct {
+		TotalRewards []AttestationReward `json:"total_rewards"`
+	}
+	if err := c.post(fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), indices, &rewards); err != nil {
+		return nil, err
+	}
+	return rewards.TotalRewards, nil
+}
+
+// get fetches path and decodes the "data" field of the response into out
+func (c *Client) get(path string, out interface{}) error {
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := c.getRaw(path, &response); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to decode beacon response: %w", err)
+	}
+	return nil
+}
+
+// post sends payload as JSON to path and decodes the "data" field of the
+// response into out
+func (c *Client) post(path string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode beacon request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(c.baseURL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to query beacon node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := decodeResponse(path, resp, &response); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to decode beacon response: %w", err)
+	}
+	return nil
+}
+
+// getRaw fetches path and decodes the full response body into out
+func (c *Client) getRaw(path string, out interface{}) error {
+	resp, err := c.httpClient.Get(c.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("failed to query beacon node: %w", err)
+	}
+	defer resp.Body.Close()
+	return decodeResponse(path, resp, out)
+}
+
+// decodeResponse checks the status of a beacon node response and decodes its
+// body into out
+func decodeResponse(path string, resp *http.Response, out interface{}) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read beacon response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Beacon APIs report failures as {"code": ..., "message": ...}
+		var apiError struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiError) == nil && apiError.Message != "" {
+			return fmt.Errorf("beacon node returned %s: %s", resp.Status, apiError.Message)
+		}
+		return fmt.Errorf("beacon node returned %s", resp.Status)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode beacon response: %w", err)
+	}
+	return nil
+}
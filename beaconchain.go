@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+
+	Beacon "eth-manage/beacon"
+)
+
+// newBeaconClient creates a client for the beacon node configured through
+// BEACON_NODE_URL.
+func newBeaconClient() (*Beacon.Client, error) {
+	if beaconNodeURL == "" {
+		return nil, fmt.Errorf("BEACON_NODE_URL is not set")
+	}
+	return Beacon.NewClient(beaconNodeURL), nil
+}
+
+// formatGwei formats a decimal Gwei amount returned by the beacon API as ETH
+func formatGwei(amount string) string {
+	gwei, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return amount
+	}
+	return formatBigIntToDecimal(gwei, 9)
+}
+
+func beaconStatus(c *cli.Context) error {
+	validatorIndex := c.String("validator-index")
+
+	client, err := newBeaconClient()
+	if err != nil {
+		return err
+	}
+
+	validator, err := client.Validator(validatorIndex)
+	if err != nil {
+		return fmt.Errorf("failed to get validator: %w", err)
+	}
+
+	fmt.Printf("Validator:          %s\n", validator.Index)
+	fmt.Printf("Public key:         %s\n", validator.Validator.Pubkey)
+	fmt.Printf("Status:             %s\n", validator.Status)
+	fmt.Printf("Balance:            %s ETH\n", formatGwei(validator.Balance))
+	fmt.Printf("Effective balance:  %s ETH\n", formatGwei(validator.Validator.EffectiveBalance))
+	fmt.Printf("Activation epoch:   %s\n", validator.Validator.ActivationEpoch)
+	fmt.Printf("Exit epoch:         %s\n", validator.Validator.ExitEpoch)
+	fmt.Printf("Slashed:            %t\n", validator.Validator.Slashed)
+	return nil
+}
+
+func beaconBlock(c *cli.Context) error {
+	slot := c.String("slot")
+
+	client, err := newBeaconClient()
+	if err != nil {
+		return err
+	}
+
+	block, err := client.Block(slot)
+	if err != nil {
+		return fmt.Errorf("failed to get beacon block: %w", err)
+	}
+
+	fmt.Printf("Slot:           %s\n", block.Message.Slot)
+	fmt.Printf("Fork:           %s\n", block.Version)
+	fmt.Printf("Finalized:      %t\n", block.Finalized)
+	fmt.Printf("Proposer:       %s\n", block.Message.ProposerIndex)
+	fmt.Printf("Parent root:    %s\n", block.Message.ParentRoot)
+	fmt.Printf("State root:     %s\n", block.Message.StateRoot)
+	fmt.Printf("Attestations:   %d\n", len(block.Message.Body.Attestations))
+	fmt.Printf("Deposits:       %d\n", len(block.Message.Body.Deposits))
+	fmt.Printf("Exits:          %d\n", len(block.Message.Body.VoluntaryExits))
+
+	if payload := block.Message.Body.ExecutionPayload; payload != nil {
+		fmt.Printf("Execution block: %s (%s)\n", payload.BlockNumber, payload.BlockHash)
+		fmt.Printf("Fee recipient:   %s\n", payload.FeeRecipient)
+		fmt.Printf("Transactions:    %d\n", len(payload.Transactions))
+	}
+	return nil
+}
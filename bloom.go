@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+// parseTopic parses a 32-byte log topic
+func parseTopic(input string) (common.Hash, error) {
+	topic, err := hexutil.Decode(input)
+	if err != nil || len(topic) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid topic %q, expected 32 bytes of hex", input)
+	}
+	return common.BytesToHash(topic), nil
+}
+
+// parseBloom parses a 256-byte logs bloom as found in block headers
+func parseBloom(input string) (types.Bloom, error) {
+	if !strings.HasPrefix(input, "0x") {
+		input = "0x" + input
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil || len(data) != types.BloomByteLength {
+		return types.Bloom{}, fmt.Errorf("invalid bloom, expected %d hex characters", types.BloomByteLength*2)
+	}
+	return types.BytesToBloom(data), nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func bloomCheck(c *cli.Context) error {
+	if !c.IsSet("address") && !c.IsSet("topic") {
+		return fmt.Errorf("at least one of --address and --topic is required")
+	}
+
+	bloom, err := parseBloom(c.String("bloom"))
+	if err != nil {
+		return err
+	}
+
+	// A bloom has false positives but no false negatives
+	present := true
+	if c.IsSet("address") {
+		address, err := parseAddress(c.String("address"))
+		if err != nil {
+			return err
+		}
+		found := bloom.Test(address.Bytes())
+		present = present && found
+		fmt.Printf("Address %s: %s\n", address.Hex(), bloomResult(found))
+	}
+	if c.IsSet("topic") {
+		topic, err := parseTopic(c.String("topic"))
+		if err != nil {
+			return err
+		}
+		found := bloom.Test(topic.Bytes())
+		present = present && found
+		fmt.Printf("Topic %s: %s\n", topic.Hex(), bloomResult(found))
+	}
+
+	if !present {
+		fmt.Println("No log in this block can match")
+	}
+	return nil
+}
+
+// bloomResult describes the outcome of a bloom test
+func bloomResult(found bool) string {
+	if found {
+		return "possibly present"
+	}
+	return "not present"
+}
+
+func bloomCreate(c *cli.Context) error {
+	var bloom types.Bloom
+
+	for _, input := range splitList(c.String("addresses")) {
+		address, err := parseAddress(input)
+		if err != nil {
+			return err
+		}
+		bloom.Add(address.Bytes())
+	}
+	for _, input := range splitList(c.String("topics")) {
+		topic, err := parseTopic(input)
+		if err != nil {
+			return err
+		}
+		bloom.Add(topic.Bytes())
+	}
+
+	fmt.Println(hexutil.Encode(bloom.Bytes()))
+	return nil
+}
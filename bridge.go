@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// bridgeHolding is one token balance held by a bridge escrow
+type bridgeHolding struct {
+	token    common.Address
+	symbol   string
+	balance  string
+	usdValue float64
+	priced   bool
+}
+
+func bridgeTVL(c *cli.Context) error {
+	alertBelowETH := c.Float64("alert-below-eth")
+
+	bridge, err := parseAddress(c.String("bridge"))
+	if err != nil {
+		return err
+	}
+
+	// Each line is "<token>" or "<token>,<chainlink USD feed>"
+	lines, err := readLines(c.String("tokens"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	ethPrice, err := ethUSDPrice(client)
+	if err != nil {
+		return err
+	}
+
+	var holdings []bridgeHolding
+	totalUSD := 0.0
+	for _, line := range lines {
+		fields := strings.Split(line, ",")
+		tokenAddress, err := parseAddress(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return err
+		}
+
+		tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		symbol, err := tokenContract.Symbol()
+		if err != nil {
+			symbol = "?"
+		}
+		decimals, err := tokenContract.Decimals()
+		if err != nil {
+			return fmt.Errorf("failed to get decimals of %s: %w", tokenAddress.Hex(), err)
+		}
+		balance, err := tokenContract.BalanceOf(bridge.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to get balance of %s: %w", tokenAddress.Hex(), err)
+		}
+
+		holding := bridgeHolding{
+			token:   tokenAddress,
+			symbol:  symbol,
+			balance: formatBigIntToDecimal(balance, int(decimals)),
+		}
+
+		if len(fields) > 1 {
+			feed, err := parseAddress(strings.TrimSpace(fields[1]))
+			if err != nil {
+				return err
+			}
+			price, err := chainlinkPrice(client, feed)
+			if err != nil {
+				return fmt.Errorf("failed to get %s price: %w", symbol, err)
+			}
+
+			scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+			value := new(big.Float).Quo(new(big.Float).SetInt(balance), new(big.Float).SetInt(scale))
+			holding.usdValue, _ = value.Mul(value, price).Float64()
+			holding.priced = true
+			totalUSD += holding.usdValue
+		}
+
+		holdings = append(holdings, holding)
+	}
+
+	sort.SliceStable(holdings, func(i, j int) bool { return holdings[i].usdValue > holdings[j].usdValue })
+
+	ethPriceFloat, _ := ethPrice.Float64()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tSYMBOL\tBALANCE\tUSD\tETH")
+	for _, holding := range holdings {
+		if !holding.priced {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\n", holding.token.Hex(), holding.symbol, holding.balance)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%.4f\n", holding.token.Hex(), holding.symbol, holding.balance, holding.usdValue, holding.usdValue/ethPriceFloat)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("Total TVL: $%.2f (%.4f ETH)\n", totalUSD, totalUSD/ethPriceFloat)
+
+	if c.IsSet("alert-below-eth") {
+		for _, holding := range holdings {
+			if !holding.priced {
+				fmt.Printf("WARNING: no price feed for %s, liquidity not checked\n", holding.symbol)
+			} else if holding.usdValue/ethPriceFloat < alertBelowETH {
+				fmt.Printf("ALERT: %s liquidity is %.4f ETH, below %.4f ETH\n", holding.symbol, holding.usdValue/ethPriceFloat, alertBelowETH)
+			}
+		}
+	}
+	return nil
+}
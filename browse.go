@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// prompt prints label and reads one trimmed line from the reader
+func prompt(reader *bufio.Reader, label string) (string, error) {
+	fmt.Print(label)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func browseContract(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	contractABI, err := fetchVerifiedABI(chainID.Int64(), contractAddress)
+	if err != nil {
+		return err
+	}
+
+	var reads, writes []abi.Method
+	for _, method := range contractABI.Methods {
+		if method.IsConstant() {
+			reads = append(reads, method)
+		} else {
+			writes = append(writes, method)
+		}
+	}
+	sort.Slice(reads, func(i, j int) bool { return reads[i].Sig < reads[j].Sig })
+	sort.Slice(writes, func(i, j int) bool { return writes[i].Sig < writes[j].Sig })
+	methods := append(reads, writes...)
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Println("Read functions:")
+		for i, method := range reads {
+			fmt.Printf("  [%d] %s\n", i+1, method.Sig)
+		}
+		fmt.Println("Write functions:")
+		for i, method := range writes {
+			fmt.Printf("  [%d] %s\n", len(reads)+i+1, method.Sig)
+		}
+
+		choice, err := prompt(reader, "Select a function (q to quit): ")
+		if err != nil || choice == "q" || choice == "" {
+			return nil
+		}
+		index, err := strconv.Atoi(choice)
+		if err != nil || index < 1 || index > len(methods) {
+			fmt.Println("Invalid selection")
+			continue
+		}
+
+		if err := executeMethod(c, client, reader, contractAddress, contractABI, methods[index-1]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		fmt.Println()
+	}
+}
+
+// executeMethod prompts for the arguments of method and runs it, as an
+// eth_call for read functions or a signed transaction for write functions.
+func executeMethod(c *cli.Context, client *ethclient.Client, reader *bufio.Reader, contractAddress common.Address, contractABI abi.ABI, method abi.Method) error {
+	args := make([]interface{}, len(method.Inputs))
+	for i, input := range method.Inputs {
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		text, err := prompt(reader, fmt.Sprintf("%s (%s): ", name, input.Type.String()))
+		if err != nil {
+			return err
+		}
+		args[i], err = parseABIArgument(input.Type, text)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if method.IsConstant() {
+		outputs, err := callContract(client, contractAddress, contractABI, method.Name, args...)
+		if err != nil {
+			return err
+		}
+		for i, output := range outputs {
+			name := method.Outputs[i].Name
+			if name == "" {
+				name = fmt.Sprintf("output%d", i)
+			}
+			fmt.Printf("%s: %s\n", name, formatABIValue(output))
+		}
+		return nil
+	}
+
+	if !c.IsSet("from") {
+		return fmt.Errorf("--from is required to call write functions")
+	}
+
+	value := big.NewInt(0)
+	if method.IsPayable() {
+		text, err := prompt(reader, "Value in ETH: ")
+		if err != nil {
+			return err
+		}
+		if text != "" {
+			value, err = parseAmount(text, 18)
+			if err != nil {
+				return fmt.Errorf("invalid value: %w", err)
+			}
+		}
+	}
+
+	data, err := contractABI.Pack(method.Name, args...)
+	if err != nil {
+		return fmt.Errorf("failed to pack data for %s: %w", method.Name, err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("from"))
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, contractAddress, value, data)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Transaction sent: %s\n", signedTx.Hash().Hex())
+
+	receipt, err := waitForSuccess(client, signedTx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Mined in block %s, gas used: %d\n", receipt.BlockNumber.String(), receipt.GasUsed)
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// stripMetadata removes the CBOR metadata solc appends to runtime code. The
+// last two bytes hold the metadata length; code without a plausible length is
+// returned unchanged.
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:])) + 2
+	if length >= len(code) {
+		return code
+	}
+	// CBOR metadata always starts with a map header (0xa0-0xbf)
+	if marker := code[len(code)-length]; marker < 0xa0 || marker > 0xbf {
+		return code
+	}
+	return code[:len(code)-length]
+}
+
+// printHexDiff prints the 32-byte chunks that differ between a and b
+func printHexDiff(a, b []byte) {
+	const chunk = 32
+	size := len(a)
+	if len(b) > size {
+		size = len(b)
+	}
+
+	for offset := 0; offset < size; offset += chunk {
+		partA := a[min(offset, len(a)):min(offset+chunk, len(a))]
+		partB := b[min(offset, len(b)):min(offset+chunk, len(b))]
+		if bytes.Equal(partA, partB) {
+			continue
+		}
+		fmt.Printf("0x%06x\n", offset)
+		fmt.Printf("  - %s\n", hexutil.Encode(partA))
+		fmt.Printf("  + %s\n", hexutil.Encode(partB))
+	}
+}
+
+func compareBytecode(c *cli.Context) error {
+	contractA, err := parseAddress(c.String("contract-a"))
+	if err != nil {
+		return err
+	}
+	contractB, err := parseAddress(c.String("contract-b"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	codeA, err := client.CodeAt(context.Background(), contractA, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s: %w", contractA.Hex(), err)
+	}
+	codeB, err := client.CodeAt(context.Background(), contractB, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s: %w", contractB.Hex(), err)
+	}
+	if len(codeA) == 0 || len(codeB) == 0 {
+		return fmt.Errorf("both addresses must be contracts")
+	}
+
+	strippedA, strippedB := stripMetadata(codeA), stripMetadata(codeB)
+	fmt.Printf("%s: %d bytes (%d without metadata)\n", contractA.Hex(), len(codeA), len(strippedA))
+	fmt.Printf("%s: %d bytes (%d without metadata)\n", contractB.Hex(), len(codeB), len(strippedB))
+
+	switch {
+	case bytes.Equal(codeA, codeB):
+		fmt.Println("Result: IDENTICAL")
+	case bytes.Equal(strippedA, strippedB):
+		fmt.Println("Result: SIMILAR (same logic, different metadata)")
+	default:
+		fmt.Println("Result: DIFFERENT")
+	}
+
+	if c.Bool("diff") && !bytes.Equal(strippedA, strippedB) {
+		printHexDiff(strippedA, strippedB)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// EIP-2028 calldata costs
+const (
+	zeroByteGas    = 4
+	nonZeroByteGas = 16
+)
+
+// calldataGas counts the zero and non-zero bytes of calldata and their gas
+func calldataGas(data []byte) (zero, nonZero int, gas uint64) {
+	for _, b := range data {
+		if b == 0 {
+			zero++
+		} else {
+			nonZero++
+		}
+	}
+	return zero, nonZero, uint64(zero*zeroByteGas + nonZero*nonZeroByteGas)
+}
+
+// calldataSuggestions looks at the 32-byte words after the selector for
+// padding that a tighter encoding would avoid
+func calldataSuggestions(data []byte) []string {
+	if len(data) < 4 {
+		return nil
+	}
+
+	var addresses, zeroWords, smallWords, smallPadding int
+	for offset := 4; offset+32 <= len(data); offset += 32 {
+		word := data[offset : offset+32]
+		leading := len(word) - len(bytes.TrimLeft(word, "\x00"))
+		switch {
+		case leading == 32:
+			zeroWords++
+		case leading == 12:
+			// Left-padded to 32 bytes from 20, as addresses are
+			addresses++
+		case leading >= 16:
+			smallWords++
+			smallPadding += leading
+		}
+	}
+
+	var suggestions []string
+	if addresses > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("padding %d addresses to 32 bytes adds %d gas; packed encodings (abi.encodePacked) store them in 20 bytes",
+			addresses, addresses*12*zeroByteGas))
+	}
+	if smallWords > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("%d small values are padded with %d zero bytes (%d gas); narrower packed types would save most of it",
+			smallWords, smallPadding, smallPadding*zeroByteGas))
+	}
+	if zeroWords > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("%d all-zero words (zero amounts, address(0), false or empty offsets) cost %d gas; omit defaults where the function allows it",
+			zeroWords, zeroWords*32*zeroByteGas))
+	}
+	if (len(data)-4)%32 != 0 {
+		suggestions = append(suggestions, "calldata is not a whole number of ABI words after the selector, so it is already packed or malformed")
+	}
+	return suggestions
+}
+
+func analyzeCalldata(c *cli.Context) error {
+	var data []byte
+	switch {
+	case c.IsSet("data"):
+		var err error
+		data, err = hexutil.Decode(c.String("data"))
+		if err != nil {
+			return fmt.Errorf("invalid calldata: %w", err)
+		}
+	case c.IsSet("abi") && c.IsSet("method"):
+		contractABI, err := loadABIFile(c.String("abi"))
+		if err != nil {
+			return err
+		}
+		method, ok := contractABI.Methods[c.String("method")]
+		if !ok {
+			return fmt.Errorf("method %s not found in ABI", c.String("method"))
+		}
+		args, err := parseABIArguments(method.Inputs, c.String("args"))
+		if err != nil {
+			return err
+		}
+		data, err = contractABI.Pack(method.Name, args...)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s: %w", method.Sig, err)
+		}
+		fmt.Printf("Encoded %s: %s\n", method.Sig, hexutil.Encode(data))
+	default:
+		return fmt.Errorf("pass --data, or --abi and --method with --args")
+	}
+
+	zero, nonZero, gas := calldataGas(data)
+	fmt.Printf("Calldata:        %d bytes\n", len(data))
+	fmt.Printf("Zero bytes:      %d x %d = %d gas\n", zero, zeroByteGas, zero*zeroByteGas)
+	fmt.Printf("Non-zero bytes:  %d x %d = %d gas\n", nonZero, nonZeroByteGas, nonZero*nonZeroByteGas)
+	fmt.Printf("Calldata gas:    %d\n", gas)
+	fmt.Printf("Intrinsic gas:   %d (21000 base + calldata)\n", 21000+gas)
+
+	if suggestions := calldataSuggestions(data); len(suggestions) > 0 {
+		fmt.Println("\nSuggestions:")
+		for _, suggestion := range suggestions {
+			fmt.Printf("  - %s\n", suggestion)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Chainlink ETH/USD price feed on Ethereum mainnet
+const chainlinkETHUSDFeed = "0x5f4eC3Df9cbd43714FE2740F5E3616155c5b8419"
+
+const chainlinkAggregatorABI = `[
+  {
+    "inputs": [],
+    "name": "decimals",
+    "outputs": [{ "name": "", "type": "uint8" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "latestRoundData",
+    "outputs": [
+      { "name": "roundId", "type": "uint80" },
+      { "name": "answer", "type": "int256" },
+      { "name": "startedAt", "type": "uint256" },
+      { "name": "updatedAt", "type": "uint256" },
+      { "name": "answeredInRound", "type": "uint80" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var chainlinkAggregator = mustParseABI(chainlinkAggregatorABI)
+
+// chainlinkPrice reads the latest answer of a Chainlink price feed, scaled by
+// the feed's decimals.
+func chainlinkPrice(client *ethclient.Client, feed common.Address) (*big.Float, error) {
+	result, err := callContract(client, feed, chainlinkAggregator, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals := result[0].(uint8)
+
+	result, err = callContract(client, feed, chainlinkAggregator, "latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+	answer := result[1].(*big.Int)
+	if answer.Sign() <= 0 {
+		return nil, fmt.Errorf("price feed %s returned a non-positive answer", feed.Hex())
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Float).Quo(new(big.Float).SetInt(answer), new(big.Float).SetInt(scale)), nil
+}
+
+// ethUSDPrice returns the current ETH price in USD from Chainlink
+func ethUSDPrice(client *ethclient.Client) (*big.Float, error) {
+	price, err := chainlinkPrice(client, common.HexToAddress(chainlinkETHUSDFeed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ETH price: %w", err)
+	}
+	return price, nil
+}
+
+// weiToUSD converts an amount of wei to USD at the given ETH price
+func weiToUSD(amount *big.Int, ethPrice *big.Float) float64 {
+	eth := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(1e18))
+	usd, _ := eth.Mul(eth, ethPrice).Float64()
+	return usd
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const coingeckoAPI = "https://api.coingecko.com/api/v3"
+
+// coingeckoPlatforms maps network presets to CoinGecko asset platform IDs
+var coingeckoPlatforms = map[string]string{
+	"mainnet":  "ethereum",
+	"arbitrum": "arbitrum-one",
+	"optimism": "optimistic-ethereum",
+	"polygon":  "polygon-pos",
+	"base":     "base",
+	"bsc":      "binance-smart-chain",
+}
+
+// coingeckoTokenPrices returns the USD prices CoinGecko knows for the given
+// tokens on the configured network. Tokens without a price are omitted.
+func coingeckoTokenPrices(tokens []common.Address) (map[common.Address]float64, error) {
+	platform, ok := coingeckoPlatforms[network]
+	if !ok {
+		return nil, fmt.Errorf("CoinGecko has no prices for network %s", network)
+	}
+
+	addresses := make([]string, len(tokens))
+	for i, token := range tokens {
+		addresses[i] = strings.ToLower(token.Hex())
+	}
+
+	var response map[string]struct {
+		USD float64 `json:"usd"`
+	}
+	err := httpGetJSON(fmt.Sprintf("%s/simple/token_price/%s?contract_addresses=%s&vs_currencies=usd",
+		coingeckoAPI, platform, strings.Join(addresses, ",")), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := map[common.Address]float64{}
+	for address, price := range response {
+		prices[common.HexToAddress(address)] = price.USD
+	}
+	return prices, nil
+}
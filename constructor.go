@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/urfave/cli/v2"
+)
+
+// constructorArgsData locates the ABI-encoded constructor arguments at the
+// end of a deployment's calldata. The runtime code is usually not a literal
+// prefix of the calldata (immutables are filled in at deploy time), but its
+// trailing CBOR metadata is copied verbatim, so the arguments start right
+// after the last occurrence of that metadata.
+func constructorArgsData(calldata, runtimeCode []byte, inputs abi.Arguments) ([]byte, error) {
+	if len(runtimeCode) >= 2 {
+		metadataLength := int(binary.BigEndian.Uint16(runtimeCode[len(runtimeCode)-2:])) + 2
+		if metadataLength < len(runtimeCode) {
+			metadata := runtimeCode[len(runtimeCode)-metadataLength:]
+			if index := bytes.LastIndex(calldata, metadata); index >= 0 {
+				return calldata[index+metadataLength:], nil
+			}
+		}
+	}
+
+	// Without metadata, static arguments can still be taken from the end
+	size := 0
+	for _, input := range inputs {
+		inputSize, ok := staticTypeSize(input.Type)
+		if !ok {
+			return nil, fmt.Errorf("cannot locate constructor arguments: runtime code has no metadata and the constructor has dynamic parameters")
+		}
+		size += inputSize
+	}
+	if size > len(calldata) {
+		return nil, fmt.Errorf("deployment data is shorter than the constructor arguments")
+	}
+	return calldata[len(calldata)-size:], nil
+}
+
+// staticTypeSize returns the encoded size of an ABI type, or false if the
+// type is dynamic.
+func staticTypeSize(typ abi.Type) (int, bool) {
+	switch typ.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy:
+		return 0, false
+	case abi.ArrayTy:
+		elemSize, ok := staticTypeSize(*typ.Elem)
+		return typ.Size * elemSize, ok
+	case abi.TupleTy:
+		size := 0
+		for _, elem := range typ.TupleElems {
+			elemSize, ok := staticTypeSize(*elem)
+			if !ok {
+				return 0, false
+			}
+			size += elemSize
+		}
+		return size, true
+	}
+	return 32, true
+}
+
+func constructorArgs(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	contractABI, err := fetchSourcifyABI(chainID.Int64(), contractAddress)
+	if err != nil {
+		return err
+	}
+	inputs := contractABI.Constructor.Inputs
+	if len(inputs) == 0 {
+		fmt.Println("Constructor takes no arguments")
+		return nil
+	}
+
+	creation, err := findContractCreation(client, contractAddress, c.Int("max-binary-search-depth"))
+	if err != nil {
+		return err
+	}
+	if creation.tx == nil {
+		return fmt.Errorf("contract was deployed by another contract; its constructor arguments are not in a transaction's calldata")
+	}
+	fmt.Printf("Creation tx: %s\n", creation.tx.Hash().Hex())
+
+	runtimeCode, err := client.CodeAt(context.Background(), contractAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code: %w", err)
+	}
+
+	data, err := constructorArgsData(creation.tx.Data(), runtimeCode, inputs)
+	if err != nil {
+		return err
+	}
+
+	values, err := inputs.Unpack(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode constructor arguments: %w", err)
+	}
+
+	for i, value := range values {
+		name := inputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		fmt.Printf("%s (%s): %s\n", name, inputs[i].Type.String(), formatABIValue(value))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// mustParseABI parses an ABI definition bundled with the binary. Bundled ABIs
+// are constants, so a parse failure is a programming error.
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled ABI: %v", err))
+	}
+	return parsed
+}
+
+// callContract packs a call to method, executes it against the latest block
+// and unpacks the returned values.
+func callContract(client *ethclient.Client, contract common.Address, contractABI abi.ABI, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for %s: %w", method, err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+	}
+
+	result, err := client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	outputs, err := contractABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+	return outputs, nil
+}
+
+// loadABIFile reads and parses a JSON ABI file supplied by the user
+func loadABIFile(path string) (abi.ABI, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to open ABI file: %w", err)
+	}
+	defer file.Close()
+
+	parsed, err := abi.JSON(file)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	return parsed, nil
+}
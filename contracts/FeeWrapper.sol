@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+pragma solidity =0.8.26;
+
+interface IERC20Metadata {
+    function decimals() external view returns (uint8);
+}
+
+/// @title FeeWrapper
+/// @notice Wraps an ERC-20 token 1:1. deposit pulls underlying tokens (after
+/// approve) and mints wrapped ones; withdraw burns and returns them. Every
+/// transfer and transferFrom sends feeBps/10000 of the amount, rounded down,
+/// to the deployer.
+/// @dev Built by `go generate` with solc 0.8.26, optimizer enabled with 200
+/// runs, EVM version paris. The storage layout is relied on by eth-manage's
+/// tests, which seed balances in genesis.
+contract FeeWrapper {
+    address public underlying; // slot 0
+    uint256 public feeBps; // slot 1
+    address public feeRecipient; // slot 2
+    uint256 public totalSupply; // slot 3
+    mapping(address => uint256) public balanceOf; // slot 4
+    mapping(address => mapping(address => uint256)) public allowance; // slot 5
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    event Approval(address indexed owner, address indexed spender, uint256 value);
+
+    constructor(address underlying_, uint256 feeBps_) {
+        require(feeBps_ <= 10000);
+        underlying = underlying_;
+        feeBps = feeBps_;
+        feeRecipient = msg.sender;
+    }
+
+    function decimals() external view returns (uint8) {
+        return IERC20Metadata(underlying).decimals();
+    }
+
+    function transfer(address to, uint256 amount) external returns (bool) {
+        _transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 amount) external returns (bool) {
+        uint256 allowed = allowance[from][msg.sender];
+        if (allowed != type(uint256).max) {
+            require(allowed >= amount);
+            allowance[from][msg.sender] = allowed - amount;
+        }
+        _transfer(from, to, amount);
+        return true;
+    }
+
+    function approve(address spender, uint256 amount) external returns (bool) {
+        allowance[msg.sender][spender] = amount;
+        emit Approval(msg.sender, spender, amount);
+        return true;
+    }
+
+    function deposit(uint256 amount) external {
+        _call(abi.encodeWithSignature("transferFrom(address,address,uint256)", msg.sender, address(this), amount));
+        balanceOf[msg.sender] += amount;
+        totalSupply += amount;
+        emit Transfer(address(0), msg.sender, amount);
+    }
+
+    function withdraw(uint256 amount) external {
+        require(balanceOf[msg.sender] >= amount);
+        balanceOf[msg.sender] -= amount;
+        totalSupply -= amount;
+        emit Transfer(msg.sender, address(0), amount);
+        _call(abi.encodeWithSignature("transfer(address,uint256)", msg.sender, amount));
+    }
+
+    function _transfer(address from, address to, uint256 amount) private {
+        require(to != address(0));
+        require(balanceOf[from] >= amount);
+        balanceOf[from] -= amount;
+
+        // amount * feeBps / 10000 without overflowing for large amounts
+        uint256 fee = (amount / 10000) * feeBps + ((amount % 10000) * feeBps) / 10000;
+        uint256 received = amount - fee;
+        balanceOf[to] += received;
+        emit Transfer(from, to, received);
+
+        if (fee != 0) {
+            balanceOf[feeRecipient] += fee;
+            emit Transfer(from, feeRecipient, fee);
+        }
+    }
+
+    /// @dev Calls the underlying token, accepting tokens that return nothing
+    function _call(bytes memory data) private {
+        (bool success, bytes memory result) = underlying.call(data);
+        if (!success) {
+            assembly {
+                revert(add(result, 32), mload(result))
+            }
+        }
+        require(result.length == 0 || abi.decode(result, (bool)));
+    }
+}
@@ -0,0 +1,23 @@
+#!/bin/sh
+# Extracts the TransparentUpgradeableProxy and ProxyAdmin creation code from
+# the @openzeppelin/contracts npm package and records its SHA-256 in
+# openzeppelin.sha256, which eth-manage checks when it loads the code.
+# npm verifies the package against the registry's integrity hash.
+set -eu
+
+version="$1"
+dir=$(cd "$(dirname "$0")" && pwd)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+cd "$tmp"
+npm pack --silent "@openzeppelin/contracts@$version" >/dev/null
+tar -xzf "openzeppelin-contracts-$version.tgz"
+
+for name in TransparentUpgradeableProxy ProxyAdmin; do
+	node -e 'process.stdout.write(require(process.argv[1]).bytecode.replace(/^0x/, "") + "\n")' \
+		"$tmp/package/build/contracts/$name.json" >"$dir/$name.bin"
+done
+
+cd "$dir"
+sha256sum TransparentUpgradeableProxy.bin ProxyAdmin.bin >openzeppelin.sha256
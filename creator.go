@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// contractCreation describes where and by whom a contract was deployed. tx
+// and creator are only set for deployments made directly by an account.
+type contractCreation struct {
+	block     uint64
+	timestamp uint64
+	tx        *types.Transaction
+	creator   common.Address
+}
+
+// findContractCreation binary searches for the first block in which contract
+// has code and looks for its deployment transaction in that block.
+// Historical state queries need an archive node.
+func findContractCreation(client *ethclient.Client, contract common.Address, maxDepth int) (contractCreation, error) {
+	var creation contractCreation
+
+	latest, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return creation, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	code, err := client.CodeAt(context.Background(), contract, nil)
+	if err != nil {
+		return creation, fmt.Errorf("failed to get code: %w", err)
+	}
+	if len(code) == 0 {
+		return creation, fmt.Errorf("%s has no code", contract.Hex())
+	}
+
+	low, high := uint64(0), latest
+	for depth := 0; low < high; depth++ {
+		if depth >= maxDepth {
+			return creation, fmt.Errorf("binary search did not converge within %d steps (blocks %d-%d)", maxDepth, low, high)
+		}
+
+		mid := low + (high-low)/2
+		code, err := client.CodeAt(context.Background(), contract, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return creation, fmt.Errorf("failed to get code at block %d: %w", mid, err)
+		}
+		if len(code) > 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	block, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(low))
+	if err != nil {
+		return creation, fmt.Errorf("failed to get block %d: %w", low, err)
+	}
+	creation.block = low
+	creation.timestamp = block.Time()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return creation, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	for _, tx := range block.Transactions() {
+		if tx.To() != nil {
+			continue
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if crypto.CreateAddress(from, tx.Nonce()) == contract {
+			creation.tx = tx
+			creation.creator = from
+			break
+		}
+	}
+	return creation, nil
+}
+
+func contractCreator(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	creation, err := findContractCreation(client, contractAddress, c.Int("max-binary-search-depth"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Creation block: %d\n", creation.block)
+	fmt.Printf("Timestamp:      %s\n", time.Unix(int64(creation.timestamp), 0).UTC().Format(time.RFC3339))
+	if creation.tx == nil {
+		fmt.Println("No direct deployment found in this block; the contract was created by another contract (CREATE/CREATE2 from a factory).")
+		return nil
+	}
+	fmt.Printf("Creator:        %s\n", creation.creator.Hex())
+	fmt.Printf("Creation tx:    %s\n", creation.tx.Hash().Hex())
+	return nil
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// Interface of the Curve 3pool, shared by most plain and factory pools
+const curvePoolABI = `[
+  {
+    "inputs": [],
+    "name": "get_virtual_price",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "i", "type": "uint256" }],
+    "name": "balances",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "i", "type": "uint256" }],
+    "name": "coins",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "fee",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "A",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "lp_token",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "token",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+// The oldest pools index coins and balances with int128
+const curveLegacyPoolABI = `[
+  {
+    "inputs": [{ "name": "i", "type": "int128" }],
+    "name": "balances",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "i", "type": "int128" }],
+    "name": "coins",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	curvePool       = mustParseABI(curvePoolABI)
+	curveLegacyPool = mustParseABI(curveLegacyPoolABI)
+)
+
+// Curve pools use this placeholder for native ETH
+var curveETHAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Pools whose LP token is neither the pool itself nor exposed by a getter
+var curveKnownLPTokens = map[common.Address]common.Address{
+	common.HexToAddress("0xbEbc44782C7dB0a1A60Cb6fe97d0b483032FF1C7"): common.HexToAddress("0x6c3F90f043a72FA612cbac8115EE7e52BDe6E490"), // 3pool
+}
+
+// Curve fees are fractions with 10 decimals, so a percentage has 8
+const curveFeeDecimals = 10
+
+// Pools hold at most eight coins
+const curveMaxCoins = 8
+
+// curveCoin is one asset of a Curve pool with its balance held by the pool
+type curveCoin struct {
+	address  common.Address
+	symbol   string
+	decimals int
+	balance  *big.Int
+}
+
+// curvePoolCoins reads the coins of a pool and their balances, stopping at
+// the first index the pool rejects.
+func curvePoolCoins(client *ethclient.Client, pool common.Address) ([]curveCoin, error) {
+	poolABI := curvePool
+	if _, err := callContract(client, pool, poolABI, "coins", big.NewInt(0)); err != nil {
+		poolABI = curveLegacyPool
+	}
+
+	var coins []curveCoin
+	for i := int64(0); i < curveMaxCoins; i++ {
+		result, err := callContract(client, pool, poolABI, "coins", big.NewInt(i))
+		if err != nil {
+			break
+		}
+		coin := curveCoin{address: result[0].(common.Address)}
+
+		result, err = callContract(client, pool, poolABI, "balances", big.NewInt(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of coin %d: %w", i, err)
+		}
+		coin.balance = result[0].(*big.Int)
+
+		if coin.address == curveETHAddress {
+			coin.symbol, coin.decimals = "ETH", 18
+		} else {
+			coin.decimals, err = tokenDecimals(client, coin.address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get decimals of coin %d: %w", i, err)
+			}
+			coin.symbol = coin.address.Hex()
+			if tokenContract, err := Token.ERCToken(coin.address.Hex(), coin.decimals, client); err == nil {
+				if symbol, err := tokenContract.Symbol(); err == nil {
+					coin.symbol = symbol
+				}
+			}
+		}
+		coins = append(coins, coin)
+	}
+
+	if len(coins) == 0 {
+		return nil, fmt.Errorf("%s does not look like a Curve pool", pool.Hex())
+	}
+	return coins, nil
+}
+
+// curveVirtualPrice returns the value of one LP token in the pool's base
+// asset, scaled by 1e18.
+func curveVirtualPrice(client *ethclient.Client, pool common.Address) (*big.Int, error) {
+	result, err := callContract(client, pool, curvePool, "get_virtual_price")
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// curveLPToken finds the LP token of a pool. Newer pools are their own LP
+// token; older ones expose it through lp_token() or token().
+func curveLPToken(client *ethclient.Client, pool common.Address) common.Address {
+	if lpToken, ok := curveKnownLPTokens[pool]; ok {
+		return lpToken
+	}
+	for _, method := range []string{"lp_token", "token"} {
+		result, err := callContract(client, pool, curvePool, method)
+		if err == nil && result[0].(common.Address) != (common.Address{}) {
+			return result[0].(common.Address)
+		}
+	}
+	return pool
+}
+
+// normalizedAmount converts base units to a float in whole tokens
+func normalizedAmount(amount *big.Int, decimals int) float64 {
+	value := new(big.Float).SetInt(amount)
+	value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	f, _ := value.Float64()
+	return f
+}
+
+func curvePoolInfo(c *cli.Context) error {
+	pool, err := parseAddress(c.String("pool"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	coins, err := curvePoolCoins(client, pool)
+	if err != nil {
+		return err
+	}
+
+	virtualPrice, err := curveVirtualPrice(client, pool)
+	if err != nil {
+		return err
+	}
+
+	result, err := callContract(client, pool, curvePool, "fee")
+	if err != nil {
+		return err
+	}
+	fee := result[0].(*big.Int)
+
+	result, err = callContract(client, pool, curvePool, "A")
+	if err != nil {
+		return err
+	}
+	amplification := result[0].(*big.Int)
+
+	total := 0.0
+	for _, coin := range coins {
+		total += normalizedAmount(coin.balance, coin.decimals)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tCOIN\tADDRESS\tBALANCE\tSHARE")
+	for i, coin := range coins {
+		share := 0.0
+		if total > 0 {
+			share = normalizedAmount(coin.balance, coin.decimals) / total * 100
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.2f%%\n", i, coin.symbol, coin.address.Hex(), formatBigIntToDecimal(coin.balance, coin.decimals), share)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Virtual price: %s\n", formatBigIntToDecimal(virtualPrice, 18))
+	fmt.Printf("Fee: %s%%\n", formatBigIntToDecimal(fee, curveFeeDecimals-2))
+	fmt.Printf("Amplification (A): %s\n", amplification.String())
+	fmt.Printf("LP token: %s\n", curveLPToken(client, pool).Hex())
+	return nil
+}
+
+func curveLPValue(c *cli.Context) error {
+	index := c.Int("from")
+
+	pool, err := parseAddress(c.String("pool"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	holder := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	lpAddress := curveLPToken(client, pool)
+	lpToken, err := Token.ERCToken(lpAddress.Hex(), 18, client)
+	if err != nil {
+		return fmt.Errorf("failed to create LP token contract: %w", err)
+	}
+	lpBalance, err := lpToken.BalanceOf(holder.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get LP balance: %w", err)
+	}
+	lpSupply, err := lpToken.TotalSupply()
+	if err != nil {
+		return fmt.Errorf("failed to get LP supply: %w", err)
+	}
+
+	virtualPrice, err := curveVirtualPrice(client, pool)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account: %s\n", holder.Hex())
+	fmt.Printf("LP token: %s\n", lpAddress.Hex())
+	fmt.Printf("LP balance: %s\n", formatBigIntToDecimal(lpBalance, 18))
+	if lpBalance.Sign() == 0 || lpSupply.Sign() == 0 {
+		fmt.Println("Value: $0.00")
+		return nil
+	}
+
+	// Value in the pool's base asset: balance * virtual price / 1e18
+	baseValue := new(big.Int).Mul(lpBalance, virtualPrice)
+	baseValue.Quo(baseValue, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	fmt.Printf("Value in base asset: %s\n", formatBigIntToDecimal(baseValue, 18))
+
+	coins, err := curvePoolCoins(client, pool)
+	if err != nil {
+		return err
+	}
+
+	// Price the holder's share of each coin, falling back to the virtual
+	// price for a USD pool when any coin has no market price
+	tokens := make([]common.Address, 0, len(coins))
+	for _, coin := range coins {
+		if coin.address != curveETHAddress {
+			tokens = append(tokens, coin.address)
+		}
+	}
+	prices, err := coingeckoTokenPrices(tokens)
+	priced := err == nil
+	if priced && len(tokens) < len(coins) {
+		if ethPrice, err := ethUSDPrice(client); err == nil {
+			prices[curveETHAddress], _ = ethPrice.Float64()
+		}
+	}
+
+	share := normalizedAmount(lpBalance, 18) / normalizedAmount(lpSupply, 18)
+	valueUSD := 0.0
+	for _, coin := range coins {
+		price, ok := prices[coin.address]
+		if !ok {
+			priced = false
+			break
+		}
+		valueUSD += share * normalizedAmount(coin.balance, coin.decimals) * price
+	}
+
+	fmt.Printf("Pool share: %.6f%%\n", share*100)
+	if priced {
+		fmt.Printf("Value: $%.2f\n", valueUSD)
+	} else {
+		fmt.Printf("Value: $%.2f (assuming a USD pool, some coins have no market price)\n", normalizedAmount(baseValue, 18))
+	}
+	return nil
+}
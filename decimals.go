@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+const decimalsCacheFile = "decimals.json"
+
+// decimalsCacheMu guards the cache file when decimals are resolved from
+// several goroutines.
+var decimalsCacheMu sync.Mutex
+
+// decimalsCache maps a chain ID to the decimals of the tokens on that chain.
+// The same address can be a different contract, or none, on another chain.
+type decimalsCache map[string]map[common.Address]uint8
+
+// get returns the cached decimals of a token on a chain
+func (cache decimalsCache) get(chainID *big.Int, tokenAddress common.Address) (uint8, bool) {
+	decimals, ok := cache[chainID.String()][tokenAddress]
+	return decimals, ok
+}
+
+// set caches the decimals of a token on a chain
+func (cache decimalsCache) set(chainID *big.Int, tokenAddress common.Address, decimals uint8) {
+	key := chainID.String()
+	if cache[key] == nil {
+		cache[key] = map[common.Address]uint8{}
+	}
+	cache[key][tokenAddress] = decimals
+}
+
+// loadDecimalsCache reads the token decimals cache. A missing cache file is
+// treated as an empty cache, and so is one written before entries were kept
+// per chain, since its entries cannot be attributed to a chain.
+func loadDecimalsCache() (decimalsCache, error) {
+	path, err := dataFilePath(decimalsCacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := decimalsCache{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decimals cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cache); err != nil {
+		var legacy map[common.Address]uint8
+		if json.Unmarshal(data, &legacy) == nil {
+			return decimalsCache{}, nil
+		}
+		return nil, fmt.Errorf("failed to parse decimals cache: %w", err)
+	}
+	return cache, nil
+}
+
+// saveDecimalsCache writes the token decimals cache
+func saveDecimalsCache(cache decimalsCache) error {
+	path, err := dataFilePath(decimalsCacheFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode decimals cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write decimals cache: %w", err)
+	}
+	return nil
+}
+
+// cachedDecimals looks a token up in the cache under its lock
+func cachedDecimals(chainID *big.Int, tokenAddress common.Address) (uint8, bool, error) {
+	decimalsCacheMu.Lock()
+	defer decimalsCacheMu.Unlock()
+
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		return 0, false, err
+	}
+	decimals, ok := cache.get(chainID, tokenAddress)
+	return decimals, ok, nil
+}
+
+// cacheDecimals adds a token to the cache. The file is reread under the lock
+// so entries other goroutines added meanwhile are kept.
+func cacheDecimals(chainID *big.Int, tokenAddress common.Address, decimals uint8) error {
+	decimalsCacheMu.Lock()
+	defer decimalsCacheMu.Unlock()
+
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		return err
+	}
+	cache.set(chainID, tokenAddress, decimals)
+	return saveDecimalsCache(cache)
+}
+
+// tokenDecimals returns the decimals of a token from the local cache, reading
+// them from the chain and caching them on a miss. The chain is read without
+// holding the cache lock, so concurrent lookups do not wait on each other.
+func tokenDecimals(client *ethclient.Client, tokenAddress common.Address) (int, error) {
+	chainID, err := resolveChainID()
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok, err := cachedDecimals(chainID, tokenAddress)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return int(decimals), nil
+	}
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create token contract: %w", err)
+	}
+	decimals, err = tokenContract.Decimals()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get decimals of %s: %w", tokenAddress.Hex(), err)
+	}
+
+	if err := cacheDecimals(chainID, tokenAddress, decimals); err != nil {
+		return 0, err
+	}
+	return int(decimals), nil
+}
+
+// decimalsFlag returns the --decimal flag when it was given, and otherwise
+// detects the token's decimals through the cache.
+func decimalsFlag(c *cli.Context, client *ethclient.Client, tokenAddress common.Address) (int, error) {
+	if c.IsSet("decimal") {
+		return c.Int("decimal"), nil
+	}
+	return tokenDecimals(client, tokenAddress)
+}
+
+func updateDecimalsCache(c *cli.Context) error {
+	lines, err := readLines(c.String("token-addresses"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+	chainID, err := resolveChainID()
+	if err != nil {
+		return err
+	}
+
+	decimalsCacheMu.Lock()
+	defer decimalsCacheMu.Unlock()
+
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		tokenAddress, err := parseAddress(line)
+		if err != nil {
+			return err
+		}
+
+		tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		decimals, err := tokenContract.Decimals()
+		if err != nil {
+			fmt.Printf("%s: failed to get decimals: %v\n", tokenAddress.Hex(), err)
+			continue
+		}
+
+		cache.set(chainID, tokenAddress, decimals)
+		fmt.Printf("%s: %d\n", tokenAddress.Hex(), decimals)
+	}
+
+	if err := saveDecimalsCache(cache); err != nil {
+		return err
+	}
+	fmt.Printf("Decimals cache holds %d tokens on chain %s\n", len(cache[chainID.String()]), chainID)
+	return nil
+}
+
+func clearDecimalsCache(c *cli.Context) error {
+	path, err := dataFilePath(decimalsCacheFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove decimals cache: %w", err)
+	}
+	fmt.Println("Decimals cache cleared")
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDecimalsCachePerChain(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	token := common.HexToAddress("0x00000000000000000000000000000000000070CE")
+	mainnet, optimism := big.NewInt(1), big.NewInt(10)
+
+	if err := cacheDecimals(mainnet, token, 6); err != nil {
+		t.Fatal(err)
+	}
+	if decimals, ok, err := cachedDecimals(mainnet, token); err != nil || !ok || decimals != 6 {
+		t.Errorf("decimals on chain 1 = %d, %v, %v, want 6", decimals, ok, err)
+	}
+	if _, ok, err := cachedDecimals(optimism, token); err != nil || ok {
+		t.Errorf("decimals cached on chain 1 found on chain 10: %v, %v", ok, err)
+	}
+
+	// A cache hit does not touch the chain
+	saved := chainId
+	t.Cleanup(func() { chainId = saved })
+	chainId.Set(mainnet)
+	if decimals, err := tokenDecimals(nil, token); err != nil || decimals != 6 {
+		t.Errorf("tokenDecimals = %d, %v, want 6 from the cache", decimals, err)
+	}
+}
+
+func TestDecimalsCacheConcurrentWrites(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	chainID := big.NewInt(1)
+
+	const tokens = 10
+	var wg sync.WaitGroup
+	for i := 0; i < tokens; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := cacheDecimals(chainID, common.BigToAddress(big.NewInt(int64(i+1))), uint8(i)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cache["1"]) != tokens {
+		t.Errorf("cache holds %d tokens, want %d", len(cache["1"]), tokens)
+	}
+}
+
+func TestDecimalsCacheDropsLegacyFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".eth-manage", decimalsCacheFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"0x00000000000000000000000000000000000070ce": 6}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cache) != 0 {
+		t.Errorf("legacy cache loaded as %v, want it dropped", cache)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"1": "six"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDecimalsCache(); err == nil {
+		t.Error("corrupt cache accepted")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+// decodedArgument is one argument of decoded calldata
+type decodedArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// decodedCall is calldata decoded with a contract ABI
+type decodedCall struct {
+	Method    string            `json:"method"`
+	Signature string            `json:"signature"`
+	Arguments []decodedArgument `json:"arguments"`
+}
+
+// decodedTx is the --json output of decode-tx. Amounts are in wei.
+type decodedTx struct {
+	Hash                 string       `json:"hash"`
+	Type                 string       `json:"type"`
+	ChainID              string       `json:"chainId"`
+	From                 string       `json:"from,omitempty"`
+	To                   string       `json:"to,omitempty"`
+	Value                string       `json:"value"`
+	Nonce                uint64       `json:"nonce"`
+	Gas                  uint64       `json:"gas"`
+	GasPrice             string       `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string       `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string       `json:"maxPriorityFeePerGas,omitempty"`
+	Data                 string       `json:"data"`
+	Call                 *decodedCall `json:"call,omitempty"`
+	Signatures           []string     `json:"signatures,omitempty"`
+}
+
+// txTypeName names a transaction envelope type
+func txTypeName(txType uint8) string {
+	switch txType {
+	case types.LegacyTxType:
+		return "legacy"
+	case types.AccessListTxType:
+		return "EIP-2930"
+	case types.DynamicFeeTxType:
+		return "EIP-1559"
+	case types.BlobTxType:
+		return "EIP-4844"
+	}
+	return fmt.Sprintf("unknown (%d)", txType)
+}
+
+// decodeCall decodes calldata against the methods of an ABI
+func decodeCall(contractABI abi.ABI, data []byte) (*decodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata is shorter than a selector")
+	}
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("selector %s is not in the ABI", hexutil.Encode(data[:4]))
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s arguments: %w", method.Sig, err)
+	}
+
+	call := &decodedCall{Method: method.RawName, Signature: method.Sig, Arguments: []decodedArgument{}}
+	for i, value := range values {
+		call.Arguments = append(call.Arguments, decodedArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: formatABIValue(value),
+		})
+	}
+	return call, nil
+}
+
+func decodeTx(c *cli.Context) error {
+	rawTx, err := hexutil.Decode(c.String("tx"))
+	if err != nil {
+		return fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	decoded := decodedTx{
+		Hash:    tx.Hash().Hex(),
+		Type:    txTypeName(tx.Type()),
+		ChainID: tx.ChainId().String(),
+		Value:   tx.Value().String(),
+		Nonce:   tx.Nonce(),
+		Gas:     tx.Gas(),
+		Data:    hexutil.Encode(tx.Data()),
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		decoded.From = sender.Hex()
+	}
+	if tx.To() != nil {
+		decoded.To = tx.To().Hex()
+	}
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		decoded.GasPrice = tx.GasPrice().String()
+	} else {
+		decoded.MaxFeePerGas = tx.GasFeeCap().String()
+		decoded.MaxPriorityFeePerGas = tx.GasTipCap().String()
+	}
+
+	// Without an ABI the selector is looked up in 4byte.directory and the
+	// local registry
+	var callErr error
+	switch {
+	case c.IsSet("token-abi"):
+		contractABI, err := loadABIFile(c.String("token-abi"))
+		if err != nil {
+			return err
+		}
+		decoded.Call, callErr = decodeCall(contractABI, tx.Data())
+	case len(tx.Data()) >= 4 && tx.To() != nil:
+		decoded.Signatures, _ = lookupFunctionSignatures(hexutil.Encode(tx.Data()[:4]))
+	}
+
+	if c.Bool("json") {
+		encoded, err := json.MarshalIndent(decoded, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return callErr
+	}
+
+	printTransactionSummary(tx)
+	fmt.Printf("Calldata:  %s\n", decoded.Data)
+	switch {
+	case decoded.Call != nil:
+		fmt.Printf("Method:    %s\n", decoded.Call.Signature)
+		for _, arg := range decoded.Call.Arguments {
+			fmt.Printf("  %s (%s): %s\n", arg.Name, arg.Type, arg.Value)
+		}
+	case len(decoded.Signatures) > 0:
+		fmt.Println("Possible methods:")
+		for _, signature := range decoded.Signatures {
+			fmt.Printf("  %s\n", signature)
+		}
+	}
+	return callErr
+}
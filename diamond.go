@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// ERC-2535 IDiamondLoupe
+const diamondLoupeABI = `[
+  {
+    "inputs": [],
+    "name": "facets",
+    "outputs": [
+      {
+        "components": [
+          { "name": "facetAddress", "type": "address" },
+          { "name": "functionSelectors", "type": "bytes4[]" }
+        ],
+        "name": "facets_",
+        "type": "tuple[]"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "_functionSelector", "type": "bytes4" }],
+    "name": "facetAddress",
+    "outputs": [{ "name": "facetAddress_", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var diamondLoupe = mustParseABI(diamondLoupeABI)
+
+// diamondFacet mirrors the IDiamondLoupe.Facet struct
+type diamondFacet struct {
+	FacetAddress      common.Address
+	FunctionSelectors [][4]byte
+}
+
+func diamondFacets(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	result, err := callContract(client, contractAddress, diamondLoupe, "facets")
+	if err != nil {
+		return fmt.Errorf("contract does not implement IDiamondLoupe: %w", err)
+	}
+
+	facets := *abi.ConvertType(result[0], new([]diamondFacet)).(*[]diamondFacet)
+	fmt.Printf("Diamond %s (%d facets)\n", contractAddress.Hex(), len(facets))
+
+	for i, facet := range facets {
+		branch, indent := "├──", "│   "
+		if i == len(facets)-1 {
+			branch, indent = "└──", "    "
+		}
+		fmt.Printf("%s %s (%d selectors)\n", branch, facet.FacetAddress.Hex(), len(facet.FunctionSelectors))
+
+		for j, selector := range facet.FunctionSelectors {
+			leaf := "├──"
+			if j == len(facet.FunctionSelectors)-1 {
+				leaf = "└──"
+			}
+			fmt.Printf("%s%s %s %s\n", indent, leaf, hexutil.Encode(selector[:]), selectorName(selector))
+		}
+	}
+	return nil
+}
+
+func diamondFacetAddress(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	selectorBytes, err := hexutil.Decode(c.String("selector"))
+	if err != nil || len(selectorBytes) != 4 {
+		return fmt.Errorf("invalid selector, expected 4 bytes of hex")
+	}
+	var selector [4]byte
+	copy(selector[:], selectorBytes)
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	result, err := callContract(client, contractAddress, diamondLoupe, "facetAddress", selector)
+	if err != nil {
+		return err
+	}
+
+	facet := result[0].(common.Address)
+	if facet == (common.Address{}) {
+		fmt.Printf("No facet implements %s\n", hexutil.Encode(selector[:]))
+		return nil
+	}
+	fmt.Printf("%s %s -> %s\n", hexutil.Encode(selector[:]), selectorName(selector), facet.Hex())
+	return nil
+}
+
+// selectorName returns the known signatures for a selector, or a placeholder
+// when 4byte.directory has no match.
+func selectorName(selector [4]byte) string {
+	signatures, err := lookupFunctionSignatures(hexutil.Encode(selector[:]))
+	if err != nil {
+		return "(lookup failed)"
+	}
+	if len(signatures) == 0 {
+		return "(unknown)"
+	}
+	return strings.Join(signatures, " | ")
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const eip712TokenABI = `[
+  {
+    "inputs": [],
+    "name": "DOMAIN_SEPARATOR",
+    "outputs": [{ "name": "", "type": "bytes32" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "name",
+    "outputs": [{ "name": "", "type": "string" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "version",
+    "outputs": [{ "name": "", "type": "string" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var eip712Token = mustParseABI(eip712TokenABI)
+
+var (
+	domainTypeHash          = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	domainNoVersionTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)"))
+)
+
+// domainSeparator computes an EIP-712 domain separator. An empty version
+// uses the domain type without a version field.
+func domainSeparator(name, version string, chainID *big.Int, verifyingContract common.Address) common.Hash {
+	uint256Ty, _ := abi.NewType("uint256", "", nil)
+	addressTy, _ := abi.NewType("address", "", nil)
+	bytes32Ty, _ := abi.NewType("bytes32", "", nil)
+
+	nameHash := crypto.Keccak256Hash([]byte(name))
+	if version == "" {
+		encoded, _ := abi.Arguments{{Type: bytes32Ty}, {Type: bytes32Ty}, {Type: uint256Ty}, {Type: addressTy}}.
+			Pack(domainNoVersionTypeHash, nameHash, chainID, verifyingContract)
+		return crypto.Keccak256Hash(encoded)
+	}
+
+	versionHash := crypto.Keccak256Hash([]byte(version))
+	encoded, _ := abi.Arguments{{Type: bytes32Ty}, {Type: bytes32Ty}, {Type: bytes32Ty}, {Type: uint256Ty}, {Type: addressTy}}.
+		Pack(domainTypeHash, nameHash, versionHash, chainID, verifyingContract)
+	return crypto.Keccak256Hash(encoded)
+}
+
+func verifyDomains(c *cli.Context) error {
+	tokens, err := loadWatchlist()
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Println("Watchlist is empty. Add tokens with watchlist-add.")
+		return nil
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tNAME\tVERSION\tRESULT")
+
+	mismatches := 0
+	for _, token := range tokens {
+		result, err := callContract(client, token, eip712Token, "DOMAIN_SEPARATOR")
+		if err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\tno DOMAIN_SEPARATOR\n", token.Hex())
+			continue
+		}
+		onChain := common.Hash(result[0].([32]byte))
+
+		result, err = callContract(client, token, eip712Token, "name")
+		if err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\tno name()\n", token.Hex())
+			continue
+		}
+		name := result[0].(string)
+
+		// Tokens without version() usually sign with version "1"
+		versions := []string{"1", ""}
+		versionLabel := "1 (assumed)"
+		if result, err := callContract(client, token, eip712Token, "version"); err == nil {
+			versions = []string{result[0].(string)}
+			versionLabel = versions[0]
+		}
+
+		status := "MISMATCH"
+		for _, version := range versions {
+			if domainSeparator(name, version, chainID, token) == onChain {
+				status = "OK"
+				if version == "" {
+					versionLabel = "(none)"
+				}
+				break
+			}
+		}
+		if status == "MISMATCH" {
+			mismatches++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", token.Hex(), name, versionLabel, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Checked %d tokens on chain %s, %d mismatches\n", len(tokens), chainID.String(), mismatches)
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// presetByShortName returns the network preset registered under an EIP-3770
+// short name.
+func presetByShortName(shortName string) (string, networkPreset, bool) {
+	for name, preset := range networkPresets {
+		if preset.shortName == shortName {
+			return name, preset, true
+		}
+	}
+	return "", networkPreset{}, false
+}
+
+// presetByChainID returns the network preset for a chain ID
+func presetByChainID(chainID int64) (string, networkPreset, bool) {
+	for name, preset := range networkPresets {
+		if preset.chainID == chainID {
+			return name, preset, true
+		}
+	}
+	return "", networkPreset{}, false
+}
+
+// parseEIP3770 splits an EIP-3770 address (`<shortName>:<address>`) into its
+// network preset name and hex address.
+func parseEIP3770(input string) (string, common.Address, error) {
+	shortName, hexAddress, ok := strings.Cut(input, ":")
+	if !ok {
+		return "", common.Address{}, fmt.Errorf("invalid EIP-3770 address %s: missing chain short name", input)
+	}
+
+	name, _, known := presetByShortName(shortName)
+	if !known {
+		return "", common.Address{}, fmt.Errorf("unknown chain short name: %s", shortName)
+	}
+
+	if !common.IsHexAddress(hexAddress) {
+		return "", common.Address{}, fmt.Errorf("invalid address: %s", hexAddress)
+	}
+	return name, common.HexToAddress(hexAddress), nil
+}
+
+// parseAddress accepts either a plain hex address or an EIP-3770 address. A
+// chain-specific address must belong to the configured network.
+func parseAddress(input string) (common.Address, error) {
+	if !strings.Contains(input, ":") {
+		if !common.IsHexAddress(input) {
+			return common.Address{}, fmt.Errorf("invalid address: %s", input)
+		}
+		return common.HexToAddress(input), nil
+	}
+
+	name, address, err := parseEIP3770(input)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if _, known := networkPresets[network]; known && name != network {
+		return common.Address{}, fmt.Errorf("address %s belongs to %s but the configured network is %s", input, name, network)
+	}
+	return address, nil
+}
+
+func parseEIP3770Address(c *cli.Context) error {
+	name, address, err := parseEIP3770(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	preset := networkPresets[name]
+	fmt.Printf("Network:  %s (%s)\n", name, preset.shortName)
+	fmt.Printf("Chain ID: %d\n", preset.chainID)
+	fmt.Printf("Address:  %s\n", address.Hex())
+	return nil
+}
+
+func formatEIP3770Address(c *cli.Context) error {
+	address := c.String("address")
+	chainID := c.Int64("chain-id")
+
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address: %s", address)
+	}
+
+	_, preset, ok := presetByChainID(chainID)
+	if !ok {
+		return fmt.Errorf("no known short name for chain ID %d", chainID)
+	}
+
+	fmt.Printf("%s:%s\n", preset.shortName, common.HexToAddress(address).Hex())
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ENS registry, deployed at the same address on mainnet and the testnets
+var ensRegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+const ensRegistryABI = `[
+  {
+    "inputs": [{ "name": "node", "type": "bytes32" }],
+    "name": "owner",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "node", "type": "bytes32" }],
+    "name": "resolver",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+const ensResolverABI = `[
+  {
+    "inputs": [{ "name": "node", "type": "bytes32" }],
+    "name": "addr",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "node", "type": "bytes32" }],
+    "name": "name",
+    "outputs": [{ "name": "", "type": "string" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	ensRegistry = mustParseABI(ensRegistryABI)
+	ensResolver = mustParseABI(ensResolverABI)
+)
+
+// namehash computes the EIP-137 node for an ENS name. Labels are lowercased
+// but not otherwise normalised.
+func namehash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		copy(node[:], crypto.Keccak256(node[:], labelHash))
+	}
+	return node
+}
+
+// ensResolverFor returns the resolver configured for a node in the registry
+func ensResolverFor(client *ethclient.Client, node [32]byte) (common.Address, error) {
+	result, err := callContract(client, ensRegistryAddress, ensRegistry, "resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	resolver := result[0].(common.Address)
+	if resolver == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("no resolver set")
+	}
+	return resolver, nil
+}
+
+// resolveENSName looks up the address an ENS name points to
+func resolveENSName(client *ethclient.Client, name string) (common.Address, error) {
+	node := namehash(name)
+	resolver, err := ensResolverFor(client, node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+	}
+
+	result, err := callContract(client, resolver, ensResolver, "addr", node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+	}
+
+	address := result[0].(common.Address)
+	if address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%s has no address record", name)
+	}
+	return address, nil
+}
+
+// resolveAddress accepts a hex or EIP-3770 address, or an ENS name such as
+// vitalik.eth, which is resolved through the registry. Anything containing a
+// dot is treated as a name.
+func resolveAddress(ctx context.Context, client *ethclient.Client, input string) (common.Address, error) {
+	if !strings.Contains(input, ".") {
+		return parseAddress(input)
+	}
+	if err := ctx.Err(); err != nil {
+		return common.Address{}, err
+	}
+
+	address, err := resolveENSName(client, input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	fmt.Printf("Resolved %s to %s\n", input, address.Hex())
+	return address, nil
+}
+
+// lookupENSName finds the primary name of an address through its reverse
+// record. The name is only returned if it resolves back to the same address.
+func lookupENSName(client *ethclient.Client, address common.Address) (string, error) {
+	reverseName := strings.ToLower(address.Hex()[2:]) + ".addr.reverse"
+	node := namehash(reverseName)
+	resolver, err := ensResolverFor(client, node)
+	if err != nil {
+		return "", fmt.Errorf("no reverse record for %s: %w", address.Hex(), err)
+	}
+
+	result, err := callContract(client, resolver, ensResolver, "name", node)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", address.Hex(), err)
+	}
+
+	name := result[0].(string)
+	if name == "" {
+		return "", fmt.Errorf("no reverse record for %s", address.Hex())
+	}
+
+	forward, err := resolveENSName(client, name)
+	if err != nil || forward != address {
+		return "", fmt.Errorf("reverse record %s does not resolve back to %s", name, address.Hex())
+	}
+	return name, nil
+}
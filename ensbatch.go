@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// ensResolution is one line of ens-batch-resolve output
+type ensResolution struct {
+	Input  string `json:"input"`
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// readLines returns the non-empty, non-comment lines of a file
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+func ensBatchResolve(c *cli.Context) error {
+	direction := c.String("direction")
+	output := c.String("output")
+	concurrency := c.Int("concurrency")
+	rate := c.Int("rate")
+
+	if direction != "forward" && direction != "reverse" {
+		return fmt.Errorf("invalid direction %q, expected forward or reverse", direction)
+	}
+	if output != "tsv" && output != "json" {
+		return fmt.Errorf("invalid output format %q, expected tsv or json", output)
+	}
+	if concurrency < 1 || rate < 1 {
+		return fmt.Errorf("concurrency and rate must be at least 1")
+	}
+
+	inputs, err := readLines(c.String("input"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	// Each lookup makes several RPC calls, so the limiter paces lookups
+	// rather than individual requests.
+	limiter := time.NewTicker(time.Second / time.Duration(rate))
+	defer limiter.Stop()
+
+	results := make([]ensResolution, len(inputs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				<-limiter.C
+				results[i] = resolveENSInput(client, inputs[i], direction)
+			}
+		}()
+	}
+
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if output == "json" {
+		encoded, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	for _, result := range results {
+		fmt.Printf("%s\t%s\t%s\n", result.Input, result.Result, result.Error)
+	}
+	return nil
+}
+
+func resolveENSInput(client *ethclient.Client, input, direction string) ensResolution {
+	resolution := ensResolution{Input: input}
+
+	if direction == "forward" {
+		address, err := resolveENSName(client, input)
+		if err != nil {
+			resolution.Error = err.Error()
+		} else {
+			resolution.Result = address.Hex()
+		}
+		return resolution
+	}
+
+	if !common.IsHexAddress(input) {
+		resolution.Error = "invalid address"
+		return resolution
+	}
+	name, err := lookupENSName(client, common.HexToAddress(input))
+	if err != nil {
+		resolution.Error = err.Error()
+	} else {
+		resolution.Result = name
+	}
+	return resolution
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+const ensContenthashABI = `[
+  {
+    "inputs": [{ "name": "node", "type": "bytes32" }],
+    "name": "contenthash",
+    "outputs": [{ "name": "", "type": "bytes" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "node", "type": "bytes32" },
+      { "name": "hash", "type": "bytes" }
+    ],
+    "name": "setContenthash",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var ensContenthashResolver = mustParseABI(ensContenthashABI)
+
+// Multicodec codes used by EIP-1577 content hashes
+const (
+	codecIPFS   = 0xe3
+	codecSwarm  = 0xe4
+	codecIPNS   = 0xe5
+	codecDagPB  = 0x70
+	sha256Code  = 0x12
+	sha256Bytes = 32
+)
+
+// base32Lower is the multibase "b" encoding used by CIDv1 strings
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// parseCID returns the binary CIDv1 of a CID string. CIDv0 ("Qm...") is
+// converted to the equivalent dag-pb CIDv1.
+func parseCID(cid string) ([]byte, error) {
+	cid = strings.TrimPrefix(cid, "ipfs://")
+	if strings.HasPrefix(cid, "Qm") && len(cid) == 46 {
+		multihash, err := base58Decode(cid)
+		if err != nil {
+			return nil, err
+		}
+		if len(multihash) != 2+sha256Bytes || multihash[0] != sha256Code || multihash[1] != sha256Bytes {
+			return nil, fmt.Errorf("invalid CIDv0 %s", cid)
+		}
+		return append([]byte{0x01, codecDagPB}, multihash...), nil
+	}
+	if cid == "" {
+		return nil, fmt.Errorf("empty CID")
+	}
+
+	// Multibase prefixes of CIDv1 strings
+	var decoded []byte
+	var err error
+	switch cid[0] {
+	case 'b':
+		decoded, err = base32Lower.DecodeString(cid[1:])
+	case 'z':
+		decoded, err = base58Decode(cid[1:])
+	case 'f':
+		decoded, err = hexutil.Decode("0x" + cid[1:])
+	default:
+		return nil, fmt.Errorf("unsupported CID encoding %q, expected CIDv0 or base32, base58 or base16 CIDv1", cid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CID %s: %w", cid, err)
+	}
+	if len(decoded) < 2 || decoded[0] != 0x01 {
+		return nil, fmt.Errorf("invalid CID %s: not a CIDv1", cid)
+	}
+	return decoded, nil
+}
+
+// encodeIPFSContenthash encodes a CID as an EIP-1577 IPFS content hash
+func encodeIPFSContenthash(cid string) ([]byte, error) {
+	binaryCID, err := parseCID(cid)
+	if err != nil {
+		return nil, err
+	}
+	return append(binary.AppendUvarint(nil, codecIPFS), binaryCID...), nil
+}
+
+// decodeContenthash renders an EIP-1577 content hash as a URI
+func decodeContenthash(contenthash []byte) (string, error) {
+	codec, n := binary.Uvarint(contenthash)
+	if n <= 0 {
+		return "", fmt.Errorf("invalid content hash codec")
+	}
+	payload := contenthash[n:]
+
+	switch codec {
+	case codecIPFS, codecIPNS:
+		scheme := "ipfs://"
+		if codec == codecIPNS {
+			scheme = "ipns://"
+		}
+		// dag-pb sha2-256 CIDs are shown in their familiar CIDv0 form
+		if codec == codecIPFS && len(payload) == 4+sha256Bytes && bytes.Equal(payload[:4], []byte{0x01, codecDagPB, sha256Code, sha256Bytes}) {
+			return scheme + base58Encode(payload[2:]), nil
+		}
+		return scheme + "b" + base32Lower.EncodeToString(payload), nil
+	case codecSwarm:
+		// CIDv1 with the swarm-manifest codec and keccak-256 multihash
+		if len(payload) < sha256Bytes {
+			return "", fmt.Errorf("invalid Swarm content hash")
+		}
+		return "bzz://" + hexutil.Encode(payload[len(payload)-sha256Bytes:])[2:], nil
+	}
+	return "", fmt.Errorf("unsupported content hash codec 0x%x", codec)
+}
+
+func ensSetContenthash(c *cli.Context) error {
+	name := c.String("name")
+
+	contenthash, err := encodeIPFSContenthash(c.String("ipfs-cid"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	node := namehash(name)
+	resolver, err := ensResolverFor(client, node)
+	if err != nil {
+		return fmt.Errorf("failed to find resolver of %s: %w", name, err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("from"))
+	if err != nil {
+		return err
+	}
+
+	data, err := ensContenthashResolver.Pack("setContenthash", node, contenthash)
+	if err != nil {
+		return fmt.Errorf("failed to pack setContenthash data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, resolver, big.NewInt(0), data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Resolver:     %s\n", resolver.Hex())
+	fmt.Printf("Content hash: %s\n", hexutil.Encode(contenthash))
+	fmt.Printf("setContenthash transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
+
+func ensGetContenthash(c *cli.Context) error {
+	name := c.String("name")
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	node := namehash(name)
+	resolver, err := ensResolverFor(client, node)
+	if err != nil {
+		return fmt.Errorf("failed to find resolver of %s: %w", name, err)
+	}
+
+	result, err := callContract(client, resolver, ensContenthashResolver, "contenthash", node)
+	if err != nil {
+		return err
+	}
+	contenthash := result[0].([]byte)
+	if len(contenthash) == 0 {
+		fmt.Printf("%s has no content hash\n", name)
+		return nil
+	}
+
+	fmt.Printf("Content hash: %s\n", hexutil.Encode(contenthash))
+	uri, err := decodeContenthash(contenthash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Content:      %s\n", uri)
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+const reverseRegistrarABI = `[
+  {
+    "inputs": [{ "name": "owner", "type": "address" }],
+    "name": "claim",
+    "outputs": [{ "name": "", "type": "bytes32" }],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "name", "type": "string" }],
+    "name": "setName",
+    "outputs": [{ "name": "", "type": "bytes32" }],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var reverseRegistrar = mustParseABI(reverseRegistrarABI)
+
+// reverseRegistrarAddress returns the owner of addr.reverse, which is the
+// current reverse registrar on every network ENS is deployed to.
+func reverseRegistrarAddress(client *ethclient.Client) (common.Address, error) {
+	result, err := callContract(client, ensRegistryAddress, ensRegistry, "owner", namehash("addr.reverse"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to find the reverse registrar: %w", err)
+	}
+
+	registrar := result[0].(common.Address)
+	if registrar == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("addr.reverse has no owner on this network")
+	}
+	return registrar, nil
+}
+
+// sendReverseRegistrarTx packs a reverse registrar call and sends it from the
+// keystore account at index.
+func sendReverseRegistrarTx(index int, method string, args ...interface{}) error {
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	registrar, err := reverseRegistrarAddress(client)
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, index)
+	if err != nil {
+		return err
+	}
+
+	data, err := reverseRegistrar.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("failed to pack %s data: %w", method, err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, registrar, big.NewInt(0), data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reverse registrar: %s\n", registrar.Hex())
+	fmt.Printf("%s transaction sent: %s\n", method, signedTx.Hash().Hex())
+	return nil
+}
+
+func ensReverseClaim(c *cli.Context) error {
+	index := c.Int("from")
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid sender account index")
+	}
+
+	return sendReverseRegistrarTx(index, "claim", accountList[index].Address)
+}
+
+func ensSetName(c *cli.Context) error {
+	return sendReverseRegistrarTx(c.Int("from"), "setName", c.String("name"))
+}
+
+func ensReverseLookup(c *cli.Context) error {
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	name, err := lookupENSName(client, address)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s -> %s\n", address.Hex(), name)
+	return nil
+}
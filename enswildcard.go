@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// ENSIP-10 extended resolver interface ID
+var extendedResolverInterfaceID = [4]byte{0x90, 0x61, 0xb9, 0x23}
+
+const extendedResolverABI = `[
+  {
+    "inputs": [{ "name": "interfaceID", "type": "bytes4" }],
+    "name": "supportsInterface",
+    "outputs": [{ "name": "", "type": "bool" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "name", "type": "bytes" },
+      { "name": "data", "type": "bytes" }
+    ],
+    "name": "resolve",
+    "outputs": [{ "name": "", "type": "bytes" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var extendedResolver = mustParseABI(extendedResolverABI)
+
+// dnsEncode encodes an ENS name in DNS wire format, as required by ENSIP-10
+func dnsEncode(name string) ([]byte, error) {
+	var encoded []byte
+	for _, label := range strings.Split(strings.ToLower(name), ".") {
+		if len(label) == 0 || len(label) > 255 {
+			return nil, fmt.Errorf("invalid label in %s", name)
+		}
+		encoded = append(encoded, byte(len(label)))
+		encoded = append(encoded, label...)
+	}
+	return append(encoded, 0), nil
+}
+
+// findENSResolver walks up from name to the closest ancestor with a resolver,
+// as ENSIP-10 prescribes. It returns the resolver and the name it was found on.
+func findENSResolver(client *ethclient.Client, name string) (common.Address, string, error) {
+	for current := name; current != ""; {
+		resolver, err := ensResolverFor(client, namehash(current))
+		if err == nil {
+			return resolver, current, nil
+		}
+
+		dot := strings.Index(current, ".")
+		if dot < 0 {
+			break
+		}
+		current = current[dot+1:]
+	}
+	return common.Address{}, "", fmt.Errorf("no resolver found for %s or any parent", name)
+}
+
+func ensWildcardResolve(c *cli.Context) error {
+	name := strings.ToLower(c.String("name"))
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	resolver, owner, err := findENSResolver(client, name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Resolver:  %s (set on %s)\n", resolver.Hex(), owner)
+
+	supported := false
+	if result, err := callContract(client, resolver, extendedResolver, "supportsInterface", extendedResolverInterfaceID); err == nil {
+		supported = result[0].(bool)
+	}
+	fmt.Printf("Wildcard:  %t\n", supported)
+
+	if !supported {
+		if owner != name {
+			return fmt.Errorf("%s has no resolver and %s's resolver does not support wildcards", name, owner)
+		}
+		address, err := resolveENSName(client, name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Address:   %s\n", address.Hex())
+		return nil
+	}
+
+	encodedName, err := dnsEncode(name)
+	if err != nil {
+		return err
+	}
+	addrCall, err := ensResolver.Pack("addr", namehash(name))
+	if err != nil {
+		return fmt.Errorf("failed to pack addr data: %w", err)
+	}
+
+	result, err := callContract(client, resolver, extendedResolver, "resolve", encodedName, addrCall)
+	if err != nil {
+		// Offchain resolvers revert with OffchainLookup (EIP-3668)
+		return fmt.Errorf("wildcard resolution failed (CCIP-read gateways are not supported): %w", err)
+	}
+
+	outputs, err := ensResolver.Unpack("addr", result[0].([]byte))
+	if err != nil {
+		return fmt.Errorf("failed to decode resolved address: %w", err)
+	}
+	fmt.Printf("Address:   %s\n", outputs[0].(common.Address).Hex())
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	ERC1155 "eth-manage/erc1155"
+)
+
+// parseUint256List parses a comma-separated list of uint256 values
+func parseUint256List(value string) ([]*big.Int, error) {
+	var values []*big.Int
+	for _, item := range splitList(value) {
+		parsed, err := parseUint256(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
+func check1155Balance(c *cli.Context) error {
+	index := c.Int("index")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseUint256List(c.String("token-id"))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no token IDs given")
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	owner := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	token, err := ERC1155.NewToken1155(contractAddress, client)
+	if err != nil {
+		return err
+	}
+
+	// Several IDs are read in one balanceOfBatch call
+	owners := make([]common.Address, len(ids))
+	for i := range owners {
+		owners[i] = owner
+	}
+	balances, err := token.BalanceOfBatch(owners, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	for i, id := range ids {
+		fmt.Printf("Balance of %s for token %s: %s\n", owner.Hex(), id.String(), balances[i].String())
+	}
+	return nil
+}
+
+func batchTransfer1155(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	toAddress, err := parseAddress(c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseUint256List(c.String("ids"))
+	if err != nil {
+		return err
+	}
+	amounts, err := parseUint256List(c.String("amounts"))
+	if err != nil {
+		return err
+	}
+	if len(ids) != len(amounts) {
+		return fmt.Errorf("--ids has %d entries but --amounts has %d", len(ids), len(amounts))
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no token IDs given")
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	token, err := ERC1155.NewToken1155(contractAddress, client)
+	if err != nil {
+		return err
+	}
+
+	txData, err := token.PackSafeBatchTransferFrom(account.Address, toAddress, ids, amounts)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, contractAddress, big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Batch transfer transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
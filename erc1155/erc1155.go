@@ -0,0 +1,96 @@
+package erc1155
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+//go:embed abi.json
+var erc1155ABI string
+
+// Token1155 is an ERC-1155 multi-token contract accessed through an ethclient
+type Token1155 struct {
+	address common.Address
+	ABI     abi.ABI
+	client  *ethclient.Client
+}
+
+// NewToken1155 creates a Token1155 for the contract at address
+func NewToken1155(address common.Address, client *ethclient.Client) (*Token1155, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(erc1155ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-1155 ABI: %w", err)
+	}
+
+	return &Token1155{
+		address: address,
+		ABI:     parsedABI,
+		client:  client,
+	}, nil
+}
+
+// BalanceOf returns how many tokens of type id owner holds
+func (t *Token1155) BalanceOf(owner common.Address, id *big.Int) (*big.Int, error) {
+	result, err := t.call("balanceOf", owner, id)
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// BalanceOfBatch returns the balance of owners[i] for ids[i] in one call
+func (t *Token1155) BalanceOfBatch(owners []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	if len(owners) != len(ids) {
+		return nil, fmt.Errorf("got %d owners but %d ids", len(owners), len(ids))
+	}
+	result, err := t.call("balanceOfBatch", owners, ids)
+	if err != nil {
+		return nil, err
+	}
+	return result[0].([]*big.Int), nil
+}
+
+// PackSafeBatchTransferFrom encodes a safeBatchTransferFrom call with empty
+// data. ids and amounts must have the same length.
+func (t *Token1155) PackSafeBatchTransferFrom(from, to common.Address, ids, amounts []*big.Int) ([]byte, error) {
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("got %d ids but %d amounts", len(ids), len(amounts))
+	}
+	data, err := t.ABI.Pack("safeBatchTransferFrom", from, to, ids, amounts, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack safeBatchTransferFrom data: %w", err)
+	}
+	return data, nil
+}
+
+// call packs a read-only contract call, executes it and unpacks the outputs
+func (t *Token1155) call(method string, args ...interface{}) ([]interface{}, error) {
+	data, err := t.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for %s: %w", method, err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &t.address,
+		Data: data,
+	}
+
+	result, err := t.client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	outputs, err := t.ABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+	return outputs, nil
+}
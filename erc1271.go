@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// ERC-1271 magic value, bytes4(keccak256("isValidSignature(bytes32,bytes)"))
+var erc1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+const erc1271ABI = `[
+  {
+    "inputs": [
+      { "name": "hash", "type": "bytes32" },
+      { "name": "signature", "type": "bytes" }
+    ],
+    "name": "isValidSignature",
+    "outputs": [{ "name": "magicValue", "type": "bytes4" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var erc1271 = mustParseABI(erc1271ABI)
+
+// isValidContractSignature asks a contract account whether signature is
+// valid for hash. A revert is reported as an invalid signature.
+func isValidContractSignature(client *ethclient.Client, contract common.Address, hash common.Hash, signature []byte) bool {
+	result, err := callContract(client, contract, erc1271, "isValidSignature", [32]byte(hash), signature)
+	if err != nil {
+		return false
+	}
+	magic := result[0].([4]byte)
+	return bytes.Equal(magic[:], erc1271MagicValue)
+}
+
+func verifyContractSignature(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	hashBytes, err := hexutil.Decode(c.String("message-hash"))
+	if err != nil || len(hashBytes) != 32 {
+		return fmt.Errorf("invalid message hash, expected 32 bytes of hex")
+	}
+	signature, err := hexutil.Decode(c.String("signature"))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	if isValidContractSignature(client, contractAddress, common.BytesToHash(hashBytes), signature) {
+		fmt.Printf("Signature is VALID for contract account %s\n", contractAddress.Hex())
+		return nil
+	}
+	fmt.Printf("Signature is NOT valid for contract account %s\n", contractAddress.Hex())
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRentalExpiry(t *testing.T) {
+	tests := []struct {
+		expires string
+		want    string
+	}{
+		{"0", "0 (1970-01-01T00:00:00Z)"},
+		{"1700000000", "1700000000 (2023-11-14T22:13:20Z)"},
+		{"9223372036854775808", "9223372036854775808"},
+		{"18446744073709551615", "18446744073709551615"},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+	}
+
+	for _, tt := range tests {
+		if got := rentalExpiry(bigInt(t, tt.expires)); got != tt.want {
+			t.Errorf("rentalExpiry(%s) = %q, want %q", tt.expires, got, tt.want)
+		}
+	}
+
+	// Past the year 9999 the value is still an int64 but not a date
+	if got := rentalExpiry(big.NewInt(1 << 40)); got != "1099511627776" {
+		t.Errorf("rentalExpiry(1<<40) = %q, want the bare number", got)
+	}
+}
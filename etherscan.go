@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const etherscanAPI = "https://api.etherscan.io/v2/api"
+
+// etherscanResponse is the envelope returned by every Etherscan API call
+type etherscanResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// etherscanGet calls an Etherscan API module/action for the given chain and
+// decodes the result field into out.
+func etherscanGet(chainID int64, params url.Values, out interface{}) error {
+	if etherscanKey == "" {
+		return fmt.Errorf("ETHERSCAN_API_KEY is not set")
+	}
+
+	params.Set("chainid", fmt.Sprint(chainID))
+	params.Set("apikey", etherscanKey)
+
+	var response etherscanResponse
+	if err := httpGetJSON(etherscanAPI+"?"+params.Encode(), &response); err != nil {
+		return err
+	}
+	if response.Status != "1" {
+		var detail string
+		if json.Unmarshal(response.Result, &detail) != nil {
+			detail = response.Message
+		}
+		return fmt.Errorf("etherscan error: %s", detail)
+	}
+
+	if err := json.Unmarshal(response.Result, out); err != nil {
+		return fmt.Errorf("failed to decode Etherscan result: %w", err)
+	}
+	return nil
+}
+
+// fetchEtherscanABI downloads the ABI of a contract verified on Etherscan
+func fetchEtherscanABI(chainID int64, address common.Address) (abi.ABI, error) {
+	var abiJSON string
+	err := etherscanGet(chainID, url.Values{
+		"module":  {"contract"},
+		"action":  {"getabi"},
+		"address": {address.Hex()},
+	}, &abiJSON)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("contract is not verified on Etherscan: %w", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse Etherscan ABI: %w", err)
+	}
+	return parsed, nil
+}
+
+// fetchVerifiedABI looks up a contract ABI on Sourcify, falling back to
+// Etherscan when an API key is configured.
+func fetchVerifiedABI(chainID int64, address common.Address) (abi.ABI, error) {
+	parsed, err := fetchSourcifyABI(chainID, address)
+	if err == nil || etherscanKey == "" {
+		return parsed, err
+	}
+	return fetchEtherscanABI(chainID, address)
+}
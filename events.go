@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+func detectEvents(c *cli.Context) error {
+	fromBlock := c.Uint64("from-block")
+	toBlock := c.Uint64("to-block")
+	verifySourcify := c.Bool("verify-sourcify")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	logs, err := fetchLogs(client, []common.Address{contractAddress}, nil, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	counts := map[common.Hash]int{}
+	for _, log := range logs {
+		// Anonymous events have no signature topic
+		if len(log.Topics) > 0 {
+			counts[log.Topics[0]]++
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Println("No events found.")
+		return nil
+	}
+
+	// Events declared in the verified ABI, keyed by topic
+	verified := map[common.Hash]string{}
+	if verifySourcify {
+		chainID, err := client.ChainID(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get chain ID: %w", err)
+		}
+
+		contractABI, err := fetchSourcifyABI(chainID.Int64(), contractAddress)
+		if err != nil {
+			fmt.Printf("Sourcify verification unavailable: %v\n", err)
+			verifySourcify = false
+		} else {
+			for _, event := range contractABI.Events {
+				verified[event.ID] = event.Sig
+			}
+		}
+	}
+
+	topics := make([]common.Hash, 0, len(counts))
+	for topic := range counts {
+		topics = append(topics, topic)
+	}
+	sort.Slice(topics, func(i, j int) bool { return counts[topics[i]] > counts[topics[j]] })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "TOPIC\tCOUNT\tSIGNATURE"
+	if verifySourcify {
+		header += "\tSOURCIFY"
+	}
+	fmt.Fprintln(w, header)
+
+	for _, topic := range topics {
+		signature := "unknown"
+		candidates, err := lookupEventSignatures(topic.Hex())
+		if err != nil {
+			signature = "lookup failed"
+		} else if len(candidates) > 0 {
+			signature = strings.Join(candidates, " | ")
+		}
+
+		row := fmt.Sprintf("%s\t%d\t%s", topic.Hex(), counts[topic], signature)
+		if verifySourcify {
+			if sig, ok := verified[topic]; ok {
+				row += "\tconfirmed " + sig
+			} else {
+				row += "\tnot in ABI"
+			}
+		}
+		fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,57 @@
+package exchange
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed exchanges.json
+var embeddedDatabase []byte
+
+// Wallet describes a known exchange hot wallet
+type Wallet struct {
+	Address  common.Address `json:"address"`
+	Exchange string         `json:"exchange"`
+	Label    string         `json:"label"`
+}
+
+// Database indexes known exchange wallets by address
+type Database map[common.Address]Wallet
+
+// Load reads the exchange database from path, falling back to the copy
+// embedded in the binary when the file does not exist.
+func Load(path string) (Database, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Parse(embeddedDatabase)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange database: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a JSON list of exchange wallets
+func Parse(data []byte) (Database, error) {
+	var wallets []Wallet
+	if err := json.Unmarshal(data, &wallets); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange database: %w", err)
+	}
+
+	db := make(Database, len(wallets))
+	for _, wallet := range wallets {
+		db[wallet.Address] = wallet
+	}
+	return db, nil
+}
+
+// Lookup returns the exchange wallet registered for address, if any
+func (db Database) Lookup(address common.Address) (Wallet, bool) {
+	wallet, ok := db[address]
+	return wallet, ok
+}
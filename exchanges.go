@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Exchange "eth-manage/exchange"
+)
+
+const exchangeDatabaseFile = "exchanges.json"
+
+// loadExchangeDatabase loads the locally updated exchange database, or the
+// embedded one if update-exchange-db has never been run.
+func loadExchangeDatabase() (Exchange.Database, error) {
+	path, err := dataFilePath(exchangeDatabaseFile)
+	if err != nil {
+		return nil, err
+	}
+	return Exchange.Load(path)
+}
+
+// exchangeNote returns a short annotation for addresses owned by a known
+// exchange, or an empty string otherwise.
+func exchangeNote(db Exchange.Database, address common.Address) string {
+	if wallet, ok := db.Lookup(address); ok {
+		return fmt.Sprintf(" (Exchange: %s)", wallet.Exchange)
+	}
+	return ""
+}
+
+func detectExchange(c *cli.Context) error {
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	db, err := loadExchangeDatabase()
+	if err != nil {
+		return err
+	}
+
+	wallet, ok := db.Lookup(address)
+	if !ok {
+		fmt.Printf("%s is not a known exchange wallet\n", address.Hex())
+		return nil
+	}
+
+	fmt.Printf("%s belongs to %s (%s)\n", wallet.Address.Hex(), wallet.Exchange, wallet.Label)
+	return nil
+}
+
+func updateExchangeDatabase(c *cli.Context) error {
+	url := c.String("url")
+
+	data, err := httpGet(url)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to overwrite the local copy with something we cannot read back
+	db, err := Exchange.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	path, err := dataFilePath(exchangeDatabaseFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write exchange database: %w", err)
+	}
+
+	fmt.Printf("Exchange database updated with %d wallets: %s\n", len(db), path)
+	return nil
+}
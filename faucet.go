@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/urfave/cli/v2"
+)
+
+// Function signatures commonly exposed by testnet token faucets, tried in
+// order.
+const faucetABI = `[
+  {
+    "inputs": [
+      { "name": "to", "type": "address" },
+      { "name": "amount", "type": "uint256" }
+    ],
+    "name": "mint",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "to", "type": "address" }],
+    "name": "drip",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "faucet",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var faucet = mustParseABI(faucetABI)
+
+func faucetRequest(c *cli.Context) error {
+	toIndex := c.Int("to-index")
+	faucetURL := c.String("faucet-url")
+
+	accountList := openKeyStore().Accounts()
+	if toIndex < 0 || toIndex >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	recipient := accountList[toIndex].Address
+
+	if faucetURL != "" {
+		var response map[string]interface{}
+		endpoint := strings.TrimRight(faucetURL, "/") + "/api/faucet"
+		err := httpPostJSON(endpoint, map[string]string{"address": recipient.Hex()}, &response)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Faucet request accepted for %s: %v\n", recipient.Hex(), response)
+		return nil
+	}
+
+	if !c.IsSet("token-address") {
+		return fmt.Errorf("either --token-address or --faucet-url is required")
+	}
+	faucetAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	candidates := map[string][]interface{}{
+		"drip":   {recipient},
+		"faucet": {},
+	}
+	order := []string{"drip", "faucet"}
+	if c.IsSet("amount") {
+		decimals, err := tokenDecimals(client, faucetAddress)
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount(c.String("amount"), decimals)
+		if err != nil {
+			return err
+		}
+		candidates["mint"] = []interface{}{recipient, amount}
+		order = append([]string{"mint"}, order...)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, toIndex)
+	if err != nil {
+		return err
+	}
+
+	for _, method := range order {
+		txData, err := faucet.Pack(method, candidates[method]...)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s data: %w", method, err)
+		}
+
+		// Skip functions the faucet does not have or that would revert
+		_, err = client.EstimateGas(context.Background(), ethereum.CallMsg{
+			From: account.Address,
+			To:   &faucetAddress,
+			Data: txData,
+		})
+		if err != nil {
+			continue
+		}
+
+		signedTx, err := sendTransaction(client, keyStore, account, faucetAddress, big.NewInt(0), txData)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Faucet %s transaction sent: %s\n", method, signedTx.Hash().Hex())
+		return nil
+	}
+
+	return fmt.Errorf("faucet %s does not accept mint(address,uint256), drip(address) or faucet() from %s", faucetAddress.Hex(), account.Address.Hex())
+}
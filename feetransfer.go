@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// transferProbeCode is runtime code that is placed at the sender's address
+// with an eth_call state override. Called with
+// token (32 bytes) ++ balanceOf(to) calldata ++ transfer(to, amount) calldata
+// it returns the recipient's balance before and after the transfer, which
+// executes with the sender as msg.sender. Reverts are bubbled up.
+//
+//	calldatacopy(0x100, 0x20, 0x24)             balanceOf(to)
+//	calldatacopy(0x200, 0x44, 0x44)             transfer(to, amount)
+//	staticcall(gas, token, 0x100, 0x24, 0x00, 0x20)
+//	call(gas, token, 0, 0x200, 0x44, 0x300, 0x20)
+//	staticcall(gas, token, 0x100, 0x24, 0x20, 0x20)
+//	return(0x00, 0x40)
+var transferProbeCode = hexutil.MustDecode("0x602460206101003760446044610200376020600060246101006000355afa15604e576020610300604461020060006000355af115604e576020602060246101006000355afa15604e5760406000f35b3d600060003e3d6000fd")
+
+// simulateTransferReceived simulates token.transfer(to, amount) sent by from
+// and returns how much the recipient's balance actually increases. It needs a
+// node that supports eth_call state overrides.
+func simulateTransferReceived(client *ethclient.Client, tokenContract *Token.Token, token, from, to common.Address, amount *big.Int) (*big.Int, error) {
+	if from == to {
+		return nil, fmt.Errorf("sender and recipient must differ")
+	}
+
+	balanceCall, err := tokenContract.ABI.Pack("balanceOf", to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack balanceOf data: %w", err)
+	}
+	transferCall, err := tokenContract.ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transfer data: %w", err)
+	}
+
+	data := append(common.LeftPadBytes(token.Bytes(), 32), balanceCall...)
+	data = append(data, transferCall...)
+
+	overrides := map[common.Address]gethclient.OverrideAccount{
+		from: {Code: transferProbeCode},
+	}
+	result, err := gethclient.New(client.Client()).CallContract(context.Background(), ethereum.CallMsg{
+		To:   &from,
+		Data: data,
+	}, nil, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("transfer simulation failed: %w", err)
+	}
+	if len(result) != 64 {
+		return nil, fmt.Errorf("unexpected simulation result")
+	}
+
+	before := new(big.Int).SetBytes(result[:32])
+	after := new(big.Int).SetBytes(result[32:])
+	return after.Sub(after, before), nil
+}
+
+func simulateFeeOnTransfer(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+	from, err := parseAddress(c.String("from"))
+	if err != nil {
+		return err
+	}
+	to, err := parseAddress(c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	decimals, err := tokenDecimals(client, tokenAddress)
+	if err != nil {
+		return err
+	}
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimals, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+
+	sent, err := parseAmount(c.String("amount"), decimals)
+	if err != nil {
+		return err
+	}
+	if sent.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	received, err := simulateTransferReceived(client, tokenContract, tokenAddress, from, to, sent)
+	if err != nil {
+		return err
+	}
+
+	fee := new(big.Int).Sub(sent, received)
+	feePercent, _ := new(big.Float).Quo(new(big.Float).SetInt(fee), new(big.Float).SetInt(sent)).Float64()
+
+	fmt.Printf("Sent:     %s\n", formatBigIntToDecimal(sent, decimals))
+	fmt.Printf("Received: %s\n", formatBigIntToDecimal(received, decimals))
+	fmt.Printf("Fee:      %s (%.4f%%)\n", formatBigIntToDecimal(fee, decimals), feePercent*100)
+	if fee.Sign() == 0 {
+		fmt.Println("Token does not take a fee on this transfer")
+	}
+	return nil
+}
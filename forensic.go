@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// storageRangeResult is a page of debug_storageRangeAt output
+type storageRangeResult struct {
+	Storage map[common.Hash]struct {
+		Key   *common.Hash `json:"key"`
+		Value common.Hash  `json:"value"`
+	} `json:"storage"`
+	NextKey *common.Hash `json:"nextKey"`
+}
+
+// dumpStorage enumerates every storage slot of address as of the end of the
+// given block using debug_storageRangeAt, which geth archive nodes provide.
+func dumpStorage(client *ethclient.Client, address common.Address, blockNumber *big.Int) (map[string]string, error) {
+	block, err := client.BlockByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	slots := map[string]string{}
+	start := common.Hash{}
+	for {
+		var page storageRangeResult
+		err := client.Client().CallContext(context.Background(), &page, "debug_storageRangeAt",
+			block.Hash(), len(block.Transactions()), address, start, 1024)
+		if err != nil {
+			return nil, fmt.Errorf("debug_storageRangeAt failed: %w", err)
+		}
+
+		for hashedKey, entry := range page.Storage {
+			// Nodes without preimages only return the hashed key
+			key := hashedKey.Hex()
+			if entry.Key != nil {
+				key = entry.Key.Hex()
+			}
+			slots[key] = entry.Value.Hex()
+		}
+
+		if page.NextKey == nil {
+			return slots, nil
+		}
+		start = *page.NextKey
+	}
+}
+
+// writeJSONFile writes value as indented JSON to dir/name
+func writeJSONFile(dir, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	fmt.Printf("Wrote %s\n", filepath.Join(dir, name))
+	return nil
+}
+
+func forensicDump(c *cli.Context) error {
+	outDir := c.String("out")
+
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	block := c.Uint64("block")
+	if block == 0 {
+		block, err = client.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %w", err)
+		}
+	}
+	blockNumber := new(big.Int).SetUint64(block)
+
+	if err := os.MkdirAll(outDir, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Account state with its Merkle proof against the block's state root
+	proof, err := gethclient.New(client.Client()).GetProof(context.Background(), address, nil, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get account proof (historical state needs an archive node): %w", err)
+	}
+	err = writeJSONFile(outDir, "account.json", map[string]interface{}{
+		"address":      address.Hex(),
+		"block":        block,
+		"balance":      proof.Balance.String(),
+		"balanceEth":   formatBigIntToDecimal(proof.Balance, 18),
+		"nonce":        proof.Nonce,
+		"codeHash":     proof.CodeHash.Hex(),
+		"storageHash":  proof.StorageHash.Hex(),
+		"accountProof": proof.AccountProof,
+	})
+	if err != nil {
+		return err
+	}
+
+	code, err := client.CodeAt(context.Background(), address, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get code: %w", err)
+	}
+	if len(code) > 0 {
+		path := filepath.Join(outDir, "code.hex")
+		if err := os.WriteFile(path, []byte(hexutil.Encode(code)), 0600); err != nil {
+			return fmt.Errorf("failed to write code: %w", err)
+		}
+		fmt.Printf("Wrote %s\n", path)
+
+		slots, err := dumpStorage(client, address, blockNumber)
+		if err != nil {
+			fmt.Printf("Storage not dumped: %v\n", err)
+		} else if err := writeJSONFile(outDir, "storage.json", slots); err != nil {
+			return err
+		}
+	}
+
+	// ERC-20 balances of every watchlist token at the block
+	tokens, err := loadWatchlist()
+	if err != nil {
+		return err
+	}
+	balances := map[string]string{}
+	for _, token := range tokens {
+		tokenContract, err := Token.ERCToken(token.Hex(), 0, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		data, err := tokenContract.ABI.Pack("balanceOf", address)
+		if err != nil {
+			return fmt.Errorf("failed to pack balanceOf data: %w", err)
+		}
+		result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, blockNumber)
+		if err != nil || len(result) < 32 {
+			balances[token.Hex()] = "error"
+			continue
+		}
+		balances[token.Hex()] = new(big.Int).SetBytes(result[:32]).String()
+	}
+	if err := writeJSONFile(outDir, "tokens.json", balances); err != nil {
+		return err
+	}
+
+	fromBlock := block
+	if c.IsSet("from-block") {
+		fromBlock = c.Uint64("from-block")
+	}
+	logs, err := fetchLogs(client, []common.Address{address}, nil, fromBlock, block)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(outDir, "events.json", logs)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/url"
+)
+
+const fourByteAPI = "https://www.4byte.directory/api/v1"
+
+// fourByteResponse is a page of signatures returned by 4byte.directory
+type fourByteResponse struct {
+	Results []struct {
+		TextSignature string `json:"text_signature"`
+	} `json:"results"`
+}
+
+// lookupFourByte returns the known text signatures for a hex selector or
+// event topic from the given 4byte.directory endpoint.
+func lookupFourByte(endpoint, hexSignature string) ([]string, error) {
+	var response fourByteResponse
+	err := httpGetJSON(fourByteAPI+"/"+endpoint+"/?hex_signature="+url.QueryEscape(hexSignature), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		signatures = append(signatures, result.TextSignature)
+	}
+	return signatures, nil
+}
+
+// lookupEventSignatures returns candidate event signatures for a topic hash
+func lookupEventSignatures(topic string) ([]string, error) {
+	return lookupFourByte("event-signatures", topic)
+}
+
+// lookupFunctionSignatures returns candidate function signatures for a
+// 4-byte selector. Selectors unknown to 4byte.directory, or found while it
+// is unreachable, fall back to the functions registered locally.
+func lookupFunctionSignatures(selector string) ([]string, error) {
+	signatures, err := lookupFourByte("signatures", selector)
+	if len(signatures) > 0 {
+		return signatures, nil
+	}
+	if signature, ok := registeredFunction(selector); ok {
+		return []string{signature}, nil
+	}
+	return signatures, err
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// gasAlert is sent to the webhook as JSON when the gas price crosses a
+// threshold.
+type gasAlert struct {
+	Direction     string  `json:"direction"`
+	ThresholdGwei float64 `json:"thresholdGwei"`
+	GasPriceGwei  string  `json:"gasPriceGwei"`
+	Timestamp     int64   `json:"timestamp"`
+}
+
+// sendAlertEmail sends a plain-text email through the SMTP server in
+// SMTP_HOST (host:port), authenticating as SMTP_USER.
+func sendAlertEmail(to, subject, body string) error {
+	if smtpHost == "" || smtpUser == "" {
+		return fmt.Errorf("SMTP_HOST and SMTP_USER must be set to send email")
+	}
+
+	host, _, err := net.SplitHostPort(smtpHost)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_HOST %q, expected host:port: %w", smtpHost, err)
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", smtpUser, to, subject, body)
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, host)
+	if err := smtp.SendMail(smtpHost, auth, smtpUser, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// sendGasAlert prints an alert and forwards it to the webhook and email
+// address when configured. Delivery failures are printed, not returned, so
+// monitoring continues.
+func sendGasAlert(alert gasAlert, webhook, email string) {
+	text := fmt.Sprintf("Gas price %s gwei is %s %g gwei", alert.GasPriceGwei, alert.Direction, alert.ThresholdGwei)
+	fmt.Printf("[%s] %s\n", time.Unix(alert.Timestamp, 0).UTC().Format(time.RFC3339), text)
+
+	if webhook != "" {
+		if err := httpPostJSON(webhook, alert, nil); err != nil {
+			fmt.Printf("Failed to send webhook: %v\n", err)
+		}
+	}
+	if email != "" {
+		if err := sendAlertEmail(email, "Gas price alert", text); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func gasAlertWatch(c *cli.Context) error {
+	interval := time.Duration(c.Int("poll-interval")) * time.Second
+	cooldown := time.Duration(c.Int("alert-cooldown")) * time.Minute
+	webhook := c.String("webhook")
+	email := c.String("email")
+
+	if interval <= 0 {
+		return fmt.Errorf("poll-interval must be positive")
+	}
+	if !c.IsSet("below") && !c.IsSet("above") {
+		return fmt.Errorf("at least one of --below and --above is required")
+	}
+	if webhook == "" && email == "" {
+		fmt.Println("No --webhook or --email given, alerts are only printed")
+	}
+
+	thresholds := map[string]*big.Int{}
+	for _, direction := range []string{"below", "above"} {
+		if !c.IsSet(direction) {
+			continue
+		}
+		threshold, err := parseAmount(strings.TrimSpace(c.String(direction)), 9)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", direction, err)
+		}
+		thresholds[direction] = threshold
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	// An alert fires when the price moves beyond a threshold, or is already
+	// beyond it at the first poll, and re-arms once the price moves back
+	beyond := map[string]bool{}
+	lastAlert := map[string]time.Time{}
+
+	fmt.Printf("Watching gas price every %s\n", interval)
+	for {
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			fmt.Printf("Failed to get gas price: %v\n", err)
+			time.Sleep(interval)
+			continue
+		}
+
+		for direction, threshold := range thresholds {
+			isBeyond := gasPrice.Cmp(threshold) < 0
+			if direction == "above" {
+				isBeyond = gasPrice.Cmp(threshold) > 0
+			}
+
+			crossed := isBeyond && !beyond[direction]
+			beyond[direction] = isBeyond
+			if !crossed || time.Since(lastAlert[direction]) < cooldown {
+				continue
+			}
+
+			lastAlert[direction] = time.Now()
+			thresholdGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(threshold), big.NewFloat(1e9)).Float64()
+			sendGasAlert(gasAlert{
+				Direction:     direction,
+				ThresholdGwei: thresholdGwei,
+				GasPriceGwei:  formatBigIntToDecimal(gasPrice, 9),
+				Timestamp:     time.Now().Unix(),
+			}, webhook, email)
+		}
+
+		time.Sleep(interval)
+	}
+}
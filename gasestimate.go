@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+func gasEstimate(c *cli.Context) error {
+	// Simulated from the account that would sign the transfer
+	from, err := senderAddress(openKeyStore(), c.Int("from"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	toAddress, err := resolveAddress(context.Background(), client, c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	// An ETH transfer unless --token-address is given
+	msg := ethereum.CallMsg{From: from, To: &toAddress}
+	if c.IsSet("token-address") {
+		tokenAddress, err := parseAddress(c.String("token-address"))
+		if err != nil {
+			return err
+		}
+
+		decimal, err := decimalsFlag(c, client, tokenAddress)
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount(c.String("amount"), decimal)
+		if err != nil {
+			return err
+		}
+
+		tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		msg.Data, err = tokenContract.ABI.Pack("transfer", toAddress, amount)
+		if err != nil {
+			return fmt.Errorf("failed to pack transfer data: %w", err)
+		}
+		msg.To = &tokenAddress
+	} else {
+		msg.Value, err = parseAmount(c.String("amount"), 18)
+		if err != nil {
+			return err
+		}
+	}
+
+	gas, err := client.EstimateGas(context.Background(), msg)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	zero, nonZero, dataGas := calldataGas(msg.Data)
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block header: %w", err)
+	}
+
+	var gasPrice *big.Int
+	fmt.Printf("Estimated gas: %d\n", gas)
+	if len(msg.Data) > 0 {
+		fmt.Printf("Calldata gas: %d (%d zero bytes, %d non-zero bytes)\n", dataGas, zero, nonZero)
+	}
+	if header.BaseFee != nil {
+		tip, err := client.SuggestGasTipCap(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get priority fee: %w", err)
+		}
+		fmt.Printf("Base fee: %s gwei\n", formatBigIntToDecimal(header.BaseFee, 9))
+		fmt.Printf("Suggested tip: %s gwei\n", formatBigIntToDecimal(tip, 9))
+		gasPrice = new(big.Int).Add(header.BaseFee, tip)
+	} else {
+		gasPrice, err = client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get gas price: %w", err)
+		}
+	}
+
+	if c.IsSet("gas-price-gwei") {
+		gasPrice, err = parseAmount(c.String("gas-price-gwei"), 9)
+		if err != nil {
+			return fmt.Errorf("invalid gas price: %w", err)
+		}
+	}
+
+	cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	fmt.Printf("Gas price: %s gwei\n", formatBigIntToDecimal(gasPrice, 9))
+	fmt.Printf("Total cost: %s ETH\n", formatBigIntToDecimal(cost, 18))
+	if c.IsSet("token-address") && gas > fallbackTokenTransferGas {
+		fmt.Printf("Warning: this exceeds the fixed gas limit of %d used by transfer-token\n", fallbackTokenTransferGas)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if isArbitrumChain(chainID) {
+		l1Gas, err := arbitrumL1Gas(client, *msg.To, msg.Data)
+		if err != nil {
+			return fmt.Errorf("failed to estimate the L1 component: %w", err)
+		}
+		fmt.Printf("L1 component: %d of the %d gas, for posting calldata to L1\n", l1Gas, gas)
+		fmt.Println()
+		if err := printArbitrumInfo(client); err != nil {
+			return fmt.Errorf("failed to query Arbitrum precompiles: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+func effectiveGasPrice(c *cli.Context) error {
+	txHash := common.HexToHash(c.String("tx-hash"))
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if isPending {
+		return fmt.Errorf("transaction %s is still pending", txHash.Hex())
+	}
+
+	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	header, err := client.HeaderByHash(context.Background(), receipt.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	price := tx.GasPrice()
+	if tx.Type() != types.LegacyTxType && tx.Type() != types.AccessListTxType && header.BaseFee != nil {
+		// effectiveGasPrice = baseFee + min(maxPriorityFee, maxFee - baseFee)
+		tip := new(big.Int).Sub(tx.GasFeeCap(), header.BaseFee)
+		if tx.GasTipCap().Cmp(tip) < 0 {
+			tip = tx.GasTipCap()
+		}
+		price = new(big.Int).Add(header.BaseFee, tip)
+
+		fmt.Printf("Max fee:           %s gwei\n", formatBigIntToDecimal(tx.GasFeeCap(), 9))
+		fmt.Printf("Max priority fee:  %s gwei\n", formatBigIntToDecimal(tx.GasTipCap(), 9))
+		fmt.Printf("Priority fee paid: %s gwei\n", formatBigIntToDecimal(tip, 9))
+	} else {
+		fmt.Printf("Gas price:         %s gwei\n", formatBigIntToDecimal(tx.GasPrice(), 9))
+	}
+
+	if header.BaseFee != nil {
+		fmt.Printf("Block base fee:    %s gwei\n", formatBigIntToDecimal(header.BaseFee, 9))
+	}
+	fmt.Printf("Effective price:   %s gwei\n", formatBigIntToDecimal(price, 9))
+	if receipt.EffectiveGasPrice != nil && receipt.EffectiveGasPrice.Cmp(price) != 0 {
+		fmt.Printf("Node reports:      %s gwei\n", formatBigIntToDecimal(receipt.EffectiveGasPrice, 9))
+	}
+
+	fee := new(big.Int).Mul(price, new(big.Int).SetUint64(receipt.GasUsed))
+	fmt.Printf("Gas used:          %d of %d\n", receipt.GasUsed, tx.Gas())
+	fmt.Printf("Total fee:         %s ETH\n", formatBigIntToDecimal(fee, 18))
+	return nil
+}
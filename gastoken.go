@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// gasToken is a legacy gas token contract on Ethereum mainnet
+type gasToken struct {
+	symbol   string
+	address  string
+	decimals int
+}
+
+var gasTokens = []gasToken{
+	{symbol: "CHI", address: "0x0000000000004946c0e9F43F4Dee607b0eF1fA1c", decimals: 0},
+	{symbol: "GST2", address: "0x0000000000b3F879cb30FE243b4Dfee438691c04", decimals: 2},
+	{symbol: "GST1", address: "0x88d60255F917e3eb94eaE199d827DAd837fac4cB", decimals: 2},
+}
+
+func gasTokenStatus(c *cli.Context) error {
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	held := false
+	for _, gasToken := range gasTokens {
+		tokenContract, err := Token.ERCToken(gasToken.address, gasToken.decimals, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+
+		balance, err := tokenContract.BalanceOf(address.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to get %s balance: %w", gasToken.symbol, err)
+		}
+		if balance.Sign() > 0 {
+			held = true
+		}
+		fmt.Printf("%s Balance of %s: %s\n", gasToken.symbol, address.Hex(), formatBigIntToDecimal(balance, gasToken.decimals))
+	}
+
+	fmt.Println()
+	fmt.Println("Gas tokens stopped working with the London fork: EIP-3529 removed the")
+	fmt.Println("SELFDESTRUCT refund and capped storage refunds at 20% of the gas used, so")
+	fmt.Println("burning CHI or GST tokens no longer lowers transaction costs.")
+	if held {
+		fmt.Println()
+		fmt.Println("This address still holds gas tokens. They have no remaining use as a gas")
+		fmt.Println("discount and can be sold or transferred like any other token.")
+	}
+	fmt.Println()
+	fmt.Println("To reduce fees instead:")
+	fmt.Println("  - Send EIP-1559 transactions with a modest priority fee and let the max fee absorb base fee swings")
+	fmt.Println("  - Wait for the base fee to drop; it falls by up to 12.5% per block while blocks are under half full")
+	fmt.Println("  - Batch operations into a single transaction where the contract supports it")
+	return nil
+}
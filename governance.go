@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Functions shared by Compound GovernorBravo and OpenZeppelin Governor (with
+// the GovernorStorage extension, which provides proposalCount).
+const governorABI = `[
+  {
+    "inputs": [],
+    "name": "proposalCount",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proposalId", "type": "uint256" }],
+    "name": "state",
+    "outputs": [{ "name": "", "type": "uint8" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "proposalId", "type": "uint256" },
+      { "name": "support", "type": "uint8" }
+    ],
+    "name": "castVote",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+// Compound GovernorBravo proposal storage
+const governorBravoABI = `[
+  {
+    "inputs": [{ "name": "", "type": "uint256" }],
+    "name": "proposals",
+    "outputs": [
+      { "name": "id", "type": "uint256" },
+      { "name": "proposer", "type": "address" },
+      { "name": "eta", "type": "uint256" },
+      { "name": "startBlock", "type": "uint256" },
+      { "name": "endBlock", "type": "uint256" },
+      { "name": "forVotes", "type": "uint256" },
+      { "name": "againstVotes", "type": "uint256" },
+      { "name": "abstainVotes", "type": "uint256" },
+      { "name": "canceled", "type": "bool" },
+      { "name": "executed", "type": "bool" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+// OpenZeppelin Governor with GovernorStorage and GovernorCountingSimple
+const governorOZABI = `[
+  {
+    "inputs": [{ "name": "index", "type": "uint256" }],
+    "name": "proposalDetailsAt",
+    "outputs": [
+      { "name": "proposalId", "type": "uint256" },
+      { "name": "targets", "type": "address[]" },
+      { "name": "values", "type": "uint256[]" },
+      { "name": "calldatas", "type": "bytes[]" },
+      { "name": "descriptionHash", "type": "bytes32" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proposalId", "type": "uint256" }],
+    "name": "proposalSnapshot",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proposalId", "type": "uint256" }],
+    "name": "proposalDeadline",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proposalId", "type": "uint256" }],
+    "name": "proposalVotes",
+    "outputs": [
+      { "name": "againstVotes", "type": "uint256" },
+      { "name": "forVotes", "type": "uint256" },
+      { "name": "abstainVotes", "type": "uint256" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	governor      = mustParseABI(governorABI)
+	governorBravo = mustParseABI(governorBravoABI)
+	governorOZ    = mustParseABI(governorOZABI)
+)
+
+// Both governor families use the same ProposalState enum
+var proposalStates = []string{"Pending", "Active", "Canceled", "Defeated", "Succeeded", "Queued", "Expired", "Executed"}
+
+// governanceProposal is the subset of proposal data shown in the table
+type governanceProposal struct {
+	id              *big.Int
+	descriptionHash string
+	start, end      *big.Int
+	forVotes        *big.Int
+	againstVotes    *big.Int
+	state           string
+}
+
+// readProposal loads proposal number n (1-based), trying the Bravo layout
+// first and the OpenZeppelin GovernorStorage layout second.
+func readProposal(client *ethclient.Client, governorAddress common.Address, n int64) (governanceProposal, error) {
+	var proposal governanceProposal
+
+	if result, err := callContract(client, governorAddress, governorBravo, "proposals", big.NewInt(n)); err == nil {
+		proposal = governanceProposal{
+			id:              result[0].(*big.Int),
+			descriptionHash: "-",
+			start:           result[3].(*big.Int),
+			end:             result[4].(*big.Int),
+			forVotes:        result[5].(*big.Int),
+			againstVotes:    result[6].(*big.Int),
+		}
+	} else {
+		result, err := callContract(client, governorAddress, governorOZ, "proposalDetailsAt", big.NewInt(n-1))
+		if err != nil {
+			return proposal, fmt.Errorf("governor is neither GovernorBravo nor an OpenZeppelin Governor with GovernorStorage")
+		}
+		proposal.id = result[0].(*big.Int)
+		proposal.descriptionHash = common.Hash(result[4].([32]byte)).Hex()
+
+		if result, err = callContract(client, governorAddress, governorOZ, "proposalSnapshot", proposal.id); err != nil {
+			return proposal, err
+		}
+		proposal.start = result[0].(*big.Int)
+		if result, err = callContract(client, governorAddress, governorOZ, "proposalDeadline", proposal.id); err != nil {
+			return proposal, err
+		}
+		proposal.end = result[0].(*big.Int)
+		if result, err = callContract(client, governorAddress, governorOZ, "proposalVotes", proposal.id); err != nil {
+			return proposal, err
+		}
+		proposal.againstVotes = result[0].(*big.Int)
+		proposal.forVotes = result[1].(*big.Int)
+	}
+
+	result, err := callContract(client, governorAddress, governor, "state", proposal.id)
+	if err != nil {
+		return proposal, err
+	}
+	state := int(result[0].(uint8))
+	proposal.state = fmt.Sprintf("Unknown(%d)", state)
+	if state < len(proposalStates) {
+		proposal.state = proposalStates[state]
+	}
+	return proposal, nil
+}
+
+func governanceProposals(c *cli.Context) error {
+	stateFilter := c.String("state")
+	limit := c.Int("limit")
+
+	if stateFilter != "active" && stateFilter != "pending" && stateFilter != "all" {
+		return fmt.Errorf("invalid state %q, expected active, pending or all", stateFilter)
+	}
+
+	governorAddress, err := parseAddress(c.String("governor"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	result, err := callContract(client, governorAddress, governor, "proposalCount")
+	if err != nil {
+		return err
+	}
+	count := result[0].(*big.Int).Int64()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDESCRIPTION HASH\tSTART\tEND\tFOR\tAGAINST\tSTATE")
+
+	// Walk from the newest proposal back until limit matches are shown
+	shown := 0
+	for n := count; n >= 1 && shown < limit; n-- {
+		proposal, err := readProposal(client, governorAddress, n)
+		if err != nil {
+			return err
+		}
+		if stateFilter == "active" && proposal.state != "Active" || stateFilter == "pending" && proposal.state != "Pending" {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			proposal.id.String(), proposal.descriptionHash, proposal.start.String(), proposal.end.String(),
+			formatBigIntToDecimal(proposal.forVotes, 18), formatBigIntToDecimal(proposal.againstVotes, 18), proposal.state)
+		shown++
+	}
+	return w.Flush()
+}
+
+func governanceVote(c *cli.Context) error {
+	fromIndex := c.Int("from")
+	support := c.Uint("support")
+
+	if support > 2 {
+		return fmt.Errorf("invalid support %d, expected 0 (against), 1 (for) or 2 (abstain)", support)
+	}
+
+	governorAddress, err := parseAddress(c.String("governor"))
+	if err != nil {
+		return err
+	}
+	proposalID, err := parseUint256(c.String("proposal-id"))
+	if err != nil {
+		return fmt.Errorf("invalid proposal ID: %w", err)
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	txData, err := governor.Pack("castVote", proposalID, uint8(support))
+	if err != nil {
+		return fmt.Errorf("failed to pack castVote data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, governorAddress, big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Vote transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
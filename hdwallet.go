@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	defaultDerivationPath = "m/44'/60'/0'/0/0"
+	hardenedKeyOffset     = 0x80000000
+)
+
+// bip39English is the BIP-39 English wordlist, bip-0039/english.txt of
+// github.com/bitcoin/bips (sha256 2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda)
+//
+//go:embed wordlists/english.txt
+var bip39English string
+
+// bip39Index maps each wordlist word to its 11-bit index
+var bip39Index = func() map[string]int {
+	index := make(map[string]int, 2048)
+	for i, word := range strings.Fields(bip39English) {
+		index[word] = i
+	}
+	return index
+}()
+
+// mnemonicSeed derives the BIP-39 seed of a mnemonic. The mnemonic must be
+// made of English wordlist words with a valid checksum. Mnemonic and
+// passphrase are NFKD normalised as the spec requires.
+func mnemonicSeed(mnemonic, passphrase string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(norm.NFKD.String(mnemonic)))
+	if err := checkMnemonic(words); err != nil {
+		return nil, err
+	}
+
+	normalised := strings.Join(words, " ")
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(normalised), []byte(salt), 2048, 64, sha512.New), nil
+}
+
+// checkMnemonic verifies that every word is in the wordlist and that the
+// checksum bits at the end match the sha256 of the entropy they follow
+func checkMnemonic(words []string) error {
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return fmt.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+
+	// Each word carries 11 bits: the entropy followed by len(words)/3
+	// checksum bits
+	bits := new(big.Int)
+	for i, word := range words {
+		index, ok := bip39Index[word]
+		if !ok {
+			return fmt.Errorf("word %d of the mnemonic (%q) is not in the BIP-39 English wordlist", i+1, word)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(len(words) / 3)
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1))
+	entropy := new(big.Int).Rsh(bits, checksumBits).FillBytes(make([]byte, len(words)*4/3))
+
+	hash := sha256.Sum256(entropy)
+	if checksum.Uint64() != uint64(hash[0]>>(8-checksumBits)) {
+		return fmt.Errorf("invalid mnemonic checksum")
+	}
+	return nil
+}
+
+// parseDerivationPath parses a BIP-32 path such as m/44'/60'/0'/0/0
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path must start with m/")
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		index, err := strconv.ParseUint(strings.TrimRight(part, "'h"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path component %q", part)
+		}
+		if hardened {
+			index += hardenedKeyOffset
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// checkDerivationCount verifies that count consecutive values of the last
+// component of path stay within its range, normal or hardened, of 2^31
+// indexes
+func checkDerivationCount(path []uint32, count int) error {
+	last := uint64(path[len(path)-1] % hardenedKeyOffset)
+	if last+uint64(count)-1 >= hardenedKeyOffset {
+		return fmt.Errorf("deriving %d accounts from %s goes past index 2^31-1", count, formatDerivationPath(path))
+	}
+	return nil
+}
+
+// formatDerivationPath renders a parsed path back to m/44'/60'/... form
+func formatDerivationPath(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range path {
+		if index >= hardenedKeyOffset {
+			fmt.Fprintf(&b, "/%d'", index-hardenedKeyOffset)
+		} else {
+			fmt.Fprintf(&b, "/%d", index)
+		}
+	}
+	return b.String()
+}
+
+// deriveHDKey walks a BIP-32 path from a seed and returns the private key
+func deriveHDKey(seed []byte, path []uint32) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+
+	for _, index := range path {
+		data := make([]byte, 0, 37)
+		if index >= hardenedKeyOffset {
+			data = append(data, 0)
+			data = append(data, key.FillBytes(make([]byte, 32))...)
+		} else {
+			privateKey, err := crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, crypto.CompressPubkey(&privateKey.PublicKey)...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		// An invalid child (probability ~2^-127) means the index must be skipped
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key.Add(key, tweak).Mod(key, curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+
+	return crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestMnemonicSeed(t *testing.T) {
+	// Vectors from the reference implementation (trezor/python-mnemonic),
+	// all with the passphrase TREZOR
+	tests := []struct {
+		mnemonic string
+		seed     string
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Fields(tt.mnemonic)[0], func(t *testing.T) {
+			seed, err := mnemonicSeed(tt.mnemonic, "TREZOR")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(seed); got != tt.seed {
+				t.Errorf("seed = %s, want %s", got, tt.seed)
+			}
+		})
+	}
+}
+
+func TestMnemonicSeedNormalisation(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	want, err := mnemonicSeed(mnemonic, "caf\u00e9")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decomposed é, upper case and extra whitespace give the same seed
+	got, err := mnemonicSeed("  ABANDON abandon abandon abandon abandon abandon\n abandon abandon abandon abandon abandon About ", "cafe\u0301")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(got) != hex.EncodeToString(want) {
+		t.Error("seed depends on the Unicode form of the input")
+	}
+}
+
+func TestMnemonicSeedRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+	}{
+		{"bad checksum", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"},
+		{"bad checksum, 24 words", strings.Repeat("zoo ", 24)},
+		{"unknown word", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot"},
+		{"word count", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mnemonicSeed(tt.mnemonic, ""); err == nil {
+				t.Errorf("mnemonicSeed(%q) succeeded, want an error", tt.mnemonic)
+			}
+		})
+	}
+}
+
+func TestDeriveHDKey(t *testing.T) {
+	seed, err := mnemonicSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := parseDerivationPath(defaultDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := deriveHDKey(seed, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := crypto.PubkeyToAddress(key.PublicKey).Hex(), "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"; got != want {
+		t.Errorf("address at %s = %s, want %s", defaultDerivationPath, got, want)
+	}
+}
+
+func TestCheckDerivationCount(t *testing.T) {
+	tests := []struct {
+		path  string
+		count int
+		ok    bool
+	}{
+		{"m/44'/60'/0'/0/0", 5, true},
+		{"m/44'/60'/0'/0/2147483647", 1, true},
+		{"m/44'/60'/0'/0/2147483647", 2, false},
+		{"m/44'/60'/0'/0/2147483640", 8, true},
+		{"m/44'/60'/0'/0/2147483640", 9, false},
+		{"m/44'/60'/2147483647'", 1, true},
+		{"m/44'/60'/2147483647'", 2, false},
+	}
+
+	for _, tt := range tests {
+		path, err := parseDerivationPath(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkDerivationCount(path, tt.count); (err == nil) != tt.ok {
+			t.Errorf("checkDerivationCount(%s, %d) = %v, want ok %t", tt.path, tt.count, err, tt.ok)
+		}
+	}
+}
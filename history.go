@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+const historyFile = "history.db"
+
+// historyPath is the --db flag; empty means historyFile in the data directory
+var historyPath string
+
+// ERC-20 transfer(address,uint256)
+var transferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// History buckets. transactions maps a hash to its record and order maps a
+// broadcast sequence number to the hash. addresses indexes the sender,
+// recipient and token of every transaction as address ‖ sequence number,
+// mapping to the hash, so lookups by address come out in broadcast order.
+var (
+	transactionsBucket = []byte("transactions")
+	orderBucket        = []byte("order")
+	addressesBucket    = []byte("addresses")
+)
+
+// txRecord is a transaction in the history. A transaction is recorded when
+// broadcast and updated once its receipt is known.
+type txRecord struct {
+	Hash      string `json:"hash"`
+	From      string `json:"from"`
+	To        string `json:"to,omitempty"`
+	Value     string `json:"value"`
+	Token     string `json:"token,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+	Block     uint64 `json:"block,omitempty"`
+	Status    string `json:"status"`
+	Sequence  uint64 `json:"sequence"`
+}
+
+// historyFilePath returns the history database selected with --db
+func historyFilePath() (string, error) {
+	if historyPath != "" {
+		return historyPath, nil
+	}
+	return dataFilePath(historyFile)
+}
+
+// history is the process's handle on the history database. bbolt locks the
+// file while it is open, so it is opened once, on first use, and shared by
+// every writer, such as the receipts a batch waits for concurrently.
+// closeHistory releases it when the command ends.
+var history struct {
+	sync.Mutex
+	db   *bolt.DB
+	path string
+}
+
+// openHistory opens the history database, creating it and its buckets if
+// needed
+func openHistory(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{transactionsBucket, orderBucket, addressesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialise history: %w", err)
+	}
+	return db, nil
+}
+
+// historyDB returns the open history database, opening it on first use. A
+// missing database is only created when create is set; otherwise nil is
+// returned for it.
+func historyDB(create bool) (*bolt.DB, error) {
+	path, err := historyFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	history.Lock()
+	defer history.Unlock()
+	if history.db != nil && history.path == path {
+		return history.db, nil
+	}
+	if history.db != nil {
+		history.db.Close()
+		history.db = nil
+	}
+	if !create {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+
+	db, err := openHistory(path)
+	if err != nil {
+		return nil, err
+	}
+	history.db, history.path = db, path
+	return db, nil
+}
+
+// closeHistory closes the history database if it was opened
+func closeHistory() error {
+	history.Lock()
+	defer history.Unlock()
+	if history.db == nil {
+		return nil
+	}
+	err := history.db.Close()
+	history.db = nil
+	return err
+}
+
+// viewHistory runs fn in a read transaction on the history database. A
+// missing database is an empty history and fn is not called.
+func viewHistory(fn func(tx *bolt.Tx) error) error {
+	db, err := historyDB(false)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.View(fn)
+}
+
+// sequenceKey encodes a sequence number so that keys sort in broadcast order
+func sequenceKey(sequence uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, sequence)
+}
+
+// putHistory stores a record. A transaction already in the history keeps
+// its broadcast time and position; its other fields are replaced. Concurrent
+// writes are committed together.
+func putHistory(update txRecord) error {
+	db, err := historyDB(true)
+	if err != nil {
+		return err
+	}
+
+	err = db.Batch(func(tx *bolt.Tx) error {
+		// Batch may run this more than once
+		record := update
+		transactions := tx.Bucket(transactionsBucket)
+		hash := []byte(record.Hash)
+
+		if existing := transactions.Get(hash); existing != nil {
+			var previous txRecord
+			if err := json.Unmarshal(existing, &previous); err != nil {
+				return fmt.Errorf("failed to parse history record %s: %w", record.Hash, err)
+			}
+			record.Timestamp, record.Sequence = previous.Timestamp, previous.Sequence
+		} else {
+			order := tx.Bucket(orderBucket)
+			sequence, err := order.NextSequence()
+			if err != nil {
+				return err
+			}
+			record.Sequence = sequence
+			if err := order.Put(sequenceKey(sequence), hash); err != nil {
+				return err
+			}
+
+			addresses := tx.Bucket(addressesBucket)
+			for _, address := range []string{record.From, record.To, record.Token} {
+				if address == "" {
+					continue
+				}
+				key := append(common.HexToAddress(address).Bytes(), sequenceKey(sequence)...)
+				if err := addresses.Put(key, hash); err != nil {
+					return err
+				}
+			}
+		}
+
+		value, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode history record: %w", err)
+		}
+		return transactions.Put(hash, value)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// getRecord reads the record of a hash within a history transaction
+func getRecord(tx *bolt.Tx, hash []byte) (txRecord, bool, error) {
+	var record txRecord
+	value := tx.Bucket(transactionsBucket).Get(hash)
+	if value == nil {
+		return record, false, nil
+	}
+	if err := json.Unmarshal(value, &record); err != nil {
+		return record, false, fmt.Errorf("failed to parse history record %s: %w", hash, err)
+	}
+	return record, true, nil
+}
+
+// loadHistory reads the whole history in broadcast order
+func loadHistory() ([]txRecord, error) {
+	var records []txRecord
+	err := viewHistory(func(tx *bolt.Tx) error {
+		return tx.Bucket(orderBucket).ForEach(func(_, hash []byte) error {
+			record, ok, err := getRecord(tx, hash)
+			if ok {
+				records = append(records, record)
+			}
+			return err
+		})
+	})
+	return records, err
+}
+
+// historyByHash looks up a single transaction
+func historyByHash(hash common.Hash) (txRecord, bool, error) {
+	var record txRecord
+	var found bool
+	err := viewHistory(func(tx *bolt.Tx) error {
+		var err error
+		record, found, err = getRecord(tx, []byte(hash.Hex()))
+		return err
+	})
+	return record, found, err
+}
+
+// historyByAddress returns the transactions an address sent, received or
+// whose token it is, in broadcast order
+func historyByAddress(address common.Address) ([]txRecord, error) {
+	var records []txRecord
+	err := viewHistory(func(tx *bolt.Tx) error {
+		prefix := address.Bytes()
+		cursor := tx.Bucket(addressesBucket).Cursor()
+		for key, hash := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, hash = cursor.Next() {
+			record, ok, err := getRecord(tx, hash)
+			if err != nil {
+				return err
+			}
+			if ok {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
+// newTxRecord describes a signed transaction. ERC-20 transfers are recorded
+// with the token, recipient and token amount rather than the raw call.
+func newTxRecord(tx *types.Transaction) txRecord {
+	record := txRecord{
+		Hash:      tx.Hash().Hex(),
+		Value:     tx.Value().String(),
+		Timestamp: time.Now().Unix(),
+		Status:    "pending",
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		record.From = from.Hex()
+	}
+	if tx.To() != nil {
+		record.To = tx.To().Hex()
+	}
+
+	data := tx.Data()
+	if tx.To() != nil && len(data) == 68 && bytes.Equal(data[:4], transferSelector) {
+		record.Token = tx.To().Hex()
+		record.To = common.BytesToAddress(data[4:36]).Hex()
+		record.Value = new(big.Int).SetBytes(data[36:68]).String()
+	}
+	return record
+}
+
+// broadcastTransaction sends a signed transaction and records it in the
+// history. Failing to record is reported but does not fail the send.
+func broadcastTransaction(client *ethclient.Client, signedTx *types.Transaction) error {
+	if err := client.SendTransaction(context.Background(), signedTx); err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	if err := putHistory(newTxRecord(signedTx)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: transaction not recorded in history: %v\n", err)
+	}
+	return nil
+}
+
+// recordReceipt updates the history with the block and status of a mined
+// transaction.
+func recordReceipt(tx *types.Transaction, receipt *types.Receipt) {
+	record := newTxRecord(tx)
+	record.Block = receipt.BlockNumber.Uint64()
+	record.Status = "success"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		record.Status = "reverted"
+	}
+	if err := putHistory(record); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: receipt not recorded in history: %v\n", err)
+	}
+}
+
+// parseSince accepts a date, an RFC 3339 time or a duration back from now
+func parseSince(value string) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q, expected a date, RFC 3339 time or duration such as 24h", value)
+}
+
+// selectHistory reads the transactions list-transactions starts from, using
+// the hash and address indexes rather than the whole history when it can
+func selectHistory(c *cli.Context, from string) ([]txRecord, error) {
+	switch {
+	case c.IsSet("hash"):
+		record, found, err := historyByHash(common.HexToHash(c.String("hash")))
+		if err != nil || !found {
+			return nil, err
+		}
+		return []txRecord{record}, nil
+	case c.IsSet("address"):
+		address, err := parseAddress(c.String("address"))
+		if err != nil {
+			return nil, err
+		}
+		return historyByAddress(address)
+	case from != "":
+		return historyByAddress(common.HexToAddress(from))
+	}
+	return loadHistory()
+}
+
+func listTransactions(c *cli.Context) error {
+	limit := c.Int("limit")
+
+	var from string
+	if c.IsSet("from-index") {
+		index := c.Int("from-index")
+		accountList := openKeyStore().Accounts()
+		if index < 0 || index >= len(accountList) {
+			return fmt.Errorf("invalid account index")
+		}
+		from = accountList[index].Address.Hex()
+	}
+
+	records, err := selectHistory(c, from)
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if c.IsSet("since") {
+		since, err = parseSince(c.String("since"))
+		if err != nil {
+			return err
+		}
+	}
+
+	var selected []txRecord
+	for _, record := range records {
+		if from != "" && record.From != from {
+			continue
+		}
+		if record.Timestamp < since.Unix() {
+			continue
+		}
+		selected = append(selected, record)
+	}
+
+	// Most recent transactions
+	if limit > 0 && len(selected) > limit {
+		selected = selected[len(selected)-limit:]
+	}
+
+	if c.Bool("csv") {
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"hash", "from", "to", "value", "token", "timestamp", "block", "status"})
+		for _, record := range selected {
+			w.Write([]string{
+				record.Hash, record.From, record.To, record.Value, record.Token,
+				time.Unix(record.Timestamp, 0).UTC().Format(time.RFC3339),
+				strconv.FormatUint(record.Block, 10), record.Status,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No transactions recorded")
+		return nil
+	}
+
+	// Token amounts are formatted when the token's decimals are cached for
+	// the selected chain. Without a known chain ID they stay in base units.
+	cache, err := loadDecimalsCache()
+	if err != nil {
+		return err
+	}
+	var chainID *big.Int
+	for _, record := range selected {
+		if record.Token != "" {
+			chainID, _ = resolveChainID()
+			break
+		}
+	}
+	for _, record := range selected {
+		when := time.Unix(record.Timestamp, 0).UTC().Format(time.RFC3339)
+		value := formatBigIntToDecimal(parseRecordValue(record.Value), 18) + " ETH"
+		if record.Token != "" {
+			value = record.Value + " base units of " + record.Token
+			if chainID != nil {
+				if decimals, ok := cache.get(chainID, common.HexToAddress(record.Token)); ok {
+					value = formatBigIntToDecimal(parseRecordValue(record.Value), int(decimals)) + " of " + record.Token
+				}
+			}
+		}
+		fmt.Printf("[%s] %s %s -> %s %s (%s", when, record.Hash, record.From, record.To, value, record.Status)
+		if record.Block != 0 {
+			fmt.Printf(", block %d", record.Block)
+		}
+		fmt.Println(")")
+	}
+	return nil
+}
+
+// parseRecordValue parses a value written to the history, treating a
+// malformed value as zero.
+func parseRecordValue(value string) *big.Int {
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return parsed
+}
+
+func clearHistory(c *cli.Context) error {
+	path, err := historyFilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := closeHistory(); err != nil {
+		return fmt.Errorf("failed to close history: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to clear history: %w", err)
+	}
+	fmt.Printf("Cleared transaction history at %s\n", path)
+	return nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// useTempHistory points --db at a fresh path for the duration of the test
+func useTempHistory(t *testing.T) string {
+	t.Helper()
+	saved := historyPath
+	t.Cleanup(func() {
+		closeHistory()
+		historyPath = saved
+	})
+	historyPath = filepath.Join(t.TempDir(), "history.db")
+	return historyPath
+}
+
+func hashes(records []txRecord) []string {
+	var list []string
+	for _, record := range records {
+		list = append(list, record.Hash)
+	}
+	return list
+}
+
+func equalHashes(t *testing.T, what string, records []txRecord, want ...string) {
+	t.Helper()
+	got := hashes(records)
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", what, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s = %v, want %v", what, got, want)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := useTempHistory(t)
+
+	alice := common.HexToAddress("0x00000000000000000000000000000000000A11CE")
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000B0B")
+	token := common.HexToAddress("0x00000000000000000000000000000000000070CE")
+	first := common.HexToHash("0x01").Hex()
+	second := common.HexToHash("0x02").Hex()
+	third := common.HexToHash("0x03").Hex()
+
+	// Nothing is created until a transaction is recorded
+	if records, err := loadHistory(); err != nil || len(records) != 0 {
+		t.Fatalf("empty history = %v, %v", records, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("reading the history created the database")
+	}
+
+	for _, record := range []txRecord{
+		{Hash: first, From: alice.Hex(), To: bob.Hex(), Value: "1", Timestamp: 100, Status: "pending"},
+		{Hash: second, From: bob.Hex(), To: alice.Hex(), Value: "5", Token: token.Hex(), Timestamp: 200, Status: "pending"},
+		{Hash: third, From: alice.Hex(), Value: "0", Timestamp: 300, Status: "pending"},
+	} {
+		if err := putHistory(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A receipt updates the record in place, keeping its broadcast time
+	if err := putHistory(txRecord{Hash: first, From: alice.Hex(), To: bob.Hex(), Value: "1", Timestamp: 999, Block: 42, Status: "success"}); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalHashes(t, "history", records, first, second, third)
+	if records[0].Status != "success" || records[0].Block != 42 || records[0].Timestamp != 100 {
+		t.Errorf("updated record = %+v, want status success, block 42, timestamp 100", records[0])
+	}
+
+	record, found, err := historyByHash(common.HexToHash(second))
+	if err != nil || !found {
+		t.Fatalf("historyByHash(%s) = %v, %v", second, found, err)
+	}
+	if record.Token != token.Hex() || record.Value != "5" {
+		t.Errorf("historyByHash(%s) = %+v", second, record)
+	}
+	if _, found, err := historyByHash(common.HexToHash("0x04")); err != nil || found {
+		t.Errorf("historyByHash of an unknown hash = %v, %v", found, err)
+	}
+
+	byAlice, err := historyByAddress(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalHashes(t, "transactions of alice", byAlice, first, second, third)
+
+	byBob, err := historyByAddress(bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalHashes(t, "transactions of bob", byBob, first, second)
+
+	byToken, err := historyByAddress(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalHashes(t, "transactions of the token", byToken, second)
+
+	none, err := historyByAddress(common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalHashes(t, "transactions of an unknown address", none)
+}
+
+func TestHistoryConcurrentWrites(t *testing.T) {
+	useTempHistory(t)
+
+	// As the receipts of a batch are recorded
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hash := common.BigToHash(big.NewInt(int64(i + 1))).Hex()
+			errs <- putHistory(txRecord{Hash: hash, From: fmt.Sprintf("0x%040x", i), Value: "1", Status: "success"})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != writers {
+		t.Fatalf("recorded %d transactions, want %d", len(records), writers)
+	}
+	sequences := map[uint64]bool{}
+	for _, record := range records {
+		sequences[record.Sequence] = true
+	}
+	if len(sequences) != writers {
+		t.Errorf("records share sequence numbers: %v", sequences)
+	}
+}
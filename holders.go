@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// tokenHolder is an address and its reconstructed balance
+type tokenHolder struct {
+	address common.Address
+	balance *big.Int
+}
+
+// reconstructHolders replays the Transfer events of a token from fromBlock
+// and returns the positive balances, largest first. Balances are only exact
+// when fromBlock is at or before the token's deployment.
+func reconstructHolders(client *ethclient.Client, token common.Address, fromBlock uint64) ([]tokenHolder, error) {
+	logs, err := fetchLogs(client, []common.Address{token}, [][]common.Hash{{transferTopic}}, fromBlock, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[common.Address]*big.Int{}
+	adjust := func(address common.Address, amount *big.Int) {
+		if address == (common.Address{}) {
+			return
+		}
+		if balances[address] == nil {
+			balances[address] = new(big.Int)
+		}
+		balances[address].Add(balances[address], amount)
+	}
+
+	for _, log := range logs {
+		// ERC-721 transfers index the token ID and carry no data
+		if len(log.Topics) != 3 || len(log.Data) != 32 {
+			continue
+		}
+		amount := new(big.Int).SetBytes(log.Data)
+		adjust(common.BytesToAddress(log.Topics[1].Bytes()), new(big.Int).Neg(amount))
+		adjust(common.BytesToAddress(log.Topics[2].Bytes()), amount)
+	}
+
+	holders := make([]tokenHolder, 0, len(balances))
+	for address, balance := range balances {
+		if balance.Sign() > 0 {
+			holders = append(holders, tokenHolder{address: address, balance: balance})
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].balance.Cmp(holders[j].balance) > 0 })
+	return holders, nil
+}
+
+// giniCoefficient computes the Gini coefficient of holder balances, which
+// must be sorted largest first.
+func giniCoefficient(holders []tokenHolder) float64 {
+	n := len(holders)
+	if n == 0 {
+		return 0
+	}
+
+	// G = 2*sum(i*x_i) / (n*sum(x)) - (n+1)/n, with x sorted ascending and i from 1
+	weighted := new(big.Int)
+	total := new(big.Int)
+	for i := range holders {
+		balance := holders[n-1-i].balance
+		weighted.Add(weighted, new(big.Int).Mul(big.NewInt(int64(i+1)), balance))
+		total.Add(total, balance)
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	numerator := new(big.Float).SetInt(new(big.Int).Mul(weighted, big.NewInt(2)))
+	denominator := new(big.Float).SetInt(new(big.Int).Mul(total, big.NewInt(int64(n))))
+	gini, _ := numerator.Quo(numerator, denominator).Float64()
+	return gini - float64(n+1)/float64(n)
+}
+
+func holderConcentration(c *cli.Context) error {
+	fromBlock := c.Uint64("from-block")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	holders, err := reconstructHolders(client, tokenAddress, fromBlock)
+	if err != nil {
+		return err
+	}
+	if len(holders) == 0 {
+		fmt.Println("No holders found.")
+		return nil
+	}
+
+	decimals, err := tokenDecimals(client, tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	// Prefer the on-chain supply, which is correct even for a partial replay
+	supply := new(big.Int)
+	for _, holder := range holders {
+		supply.Add(supply, holder.balance)
+	}
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimals, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	if totalSupply, err := tokenContract.TotalSupply(); err == nil && totalSupply.Sign() > 0 {
+		supply = totalSupply
+	}
+
+	top := holders
+	if len(top) > 10 {
+		top = top[:10]
+	}
+	topBalance := new(big.Int)
+	for i, holder := range top {
+		topBalance.Add(topBalance, holder.balance)
+		fmt.Printf("%2d. %s %s\n", i+1, holder.address.Hex(), formatBigIntToDecimal(holder.balance, decimals))
+	}
+
+	topShare, _ := new(big.Float).Quo(new(big.Float).SetInt(topBalance), new(big.Float).SetInt(supply)).Float64()
+	topShare *= 100
+	gini := giniCoefficient(holders)
+
+	fmt.Printf("Holders:           %d\n", len(holders))
+	fmt.Printf("Gini coefficient:  %.4f\n", gini)
+	fmt.Printf("Top 10 share:      %.2f%%\n", topShare)
+
+	switch {
+	case topShare >= 80 || gini >= 0.9:
+		fmt.Printf("Highly concentrated: top 10 holders own %.0f%% of supply\n", topShare)
+	case topShare >= 50 || gini >= 0.7:
+		fmt.Printf("Moderately concentrated: top 10 holders own %.0f%% of supply\n", topShare)
+	default:
+		fmt.Printf("Well distributed: top 10 holders own %.0f%% of supply\n", topShare)
+	}
+	return nil
+}
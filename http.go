@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// redactURL drops the query string and user info of a URL for error
+// messages, since they can carry API keys such as Etherscan's apikey
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "the requested URL"
+	}
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.ForceQuery = false
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// redactError redacts the URL net/http includes in request errors
+func redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	return err
+}
+
+// httpGet fetches url and returns the response body, treating any non-2xx
+// status as an error.
+func httpGet(rawURL string) ([]byte, error) {
+	return httpGetWithHeaders(rawURL, nil)
+}
+
+// httpGetWithHeaders is httpGet with extra request headers, such as API keys
+func httpGetWithHeaders(rawURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", redactURL(rawURL), redactError(err))
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", redactURL(rawURL), redactError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", redactURL(rawURL), err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status from %s: %s", redactURL(rawURL), resp.Status)
+	}
+
+	return body, nil
+}
+
+// httpGetJSON fetches url and decodes the JSON response into out
+func httpGetJSON(rawURL string, out interface{}) error {
+	body, err := httpGet(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", redactURL(rawURL), err)
+	}
+	return nil
+}
+
+// httpPostJSON encodes payload as JSON and posts it to url, treating any
+// non-2xx status as an error. If out is not nil the JSON response is decoded
+// into it.
+func httpPostJSON(rawURL string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := httpClient.Post(rawURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post to %s: %w", redactURL(rawURL), redactError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status from %s: %s", redactURL(rawURL), resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", redactURL(rawURL), err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// immutableReference is a location of an immutable value in deployed bytecode
+type immutableReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// solcOutput is the subset of solc's standard JSON output needed to locate
+// immutables.
+type solcOutput struct {
+	Sources map[string]struct {
+		AST json.RawMessage `json:"ast"`
+	} `json:"sources"`
+	Contracts map[string]map[string]struct {
+		EVM struct {
+			DeployedBytecode struct {
+				ImmutableReferences map[string][]immutableReference `json:"immutableReferences"`
+			} `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+}
+
+// findImmutableID walks a solc AST looking for the declaration of an
+// immutable state variable and returns its AST node ID.
+func findImmutableID(node interface{}, name string) (int, bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if n["nodeType"] == "VariableDeclaration" && n["name"] == name && n["mutability"] == "immutable" {
+			if id, ok := n["id"].(float64); ok {
+				return int(id), true
+			}
+		}
+		for _, child := range n {
+			if id, ok := findImmutableID(child, name); ok {
+				return id, true
+			}
+		}
+	case []interface{}:
+		for _, child := range n {
+			if id, ok := findImmutableID(child, name); ok {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// immutableOffsets resolves the bytecode offsets of an immutable variable from
+// compiler output.
+func immutableOffsets(path, name string) ([]immutableReference, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compiler output: %w", err)
+	}
+
+	var output solcOutput
+	if err := json.Unmarshal(content, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse compiler output: %w", err)
+	}
+
+	for _, source := range output.Sources {
+		var ast interface{}
+		if err := json.Unmarshal(source.AST, &ast); err != nil {
+			continue
+		}
+		id, ok := findImmutableID(ast, name)
+		if !ok {
+			continue
+		}
+
+		for _, contracts := range output.Contracts {
+			for _, contract := range contracts {
+				if refs, ok := contract.EVM.DeployedBytecode.ImmutableReferences[fmt.Sprint(id)]; ok {
+					return refs, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("immutable %s is declared but never referenced in the bytecode", name)
+	}
+	return nil, fmt.Errorf("immutable %s not found in compiler output", name)
+}
+
+// push32Values returns every PUSH32 operand in code with its offset. Solidity
+// embeds immutables as PUSH32 operands.
+func push32Values(code []byte) map[int][]byte {
+	values := map[int][]byte{}
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op < 0x60 || op > 0x7f {
+			continue
+		}
+
+		size := int(op-0x60) + 1
+		if op == 0x7f && pc+1+size <= len(code) {
+			values[pc+1] = code[pc+1 : pc+1+size]
+		}
+		pc += size
+	}
+	return values
+}
+
+// matchesType reports whether a 32-byte word has the shape of an ABI-encoded
+// value of the given type.
+func matchesType(word []byte, typ abi.Type) bool {
+	switch typ.T {
+	case abi.AddressTy:
+		return bytes.Equal(word[:12], make([]byte, 12)) && !bytes.Equal(word[12:], make([]byte, 20))
+	case abi.BoolTy:
+		return bytes.Equal(word[:31], make([]byte, 31)) && word[31] <= 1
+	default:
+		return true
+	}
+}
+
+func readImmutable(c *cli.Context) error {
+	name := c.String("name")
+	compilerOutput := c.String("compiler-output")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	typ, err := abi.NewType(c.String("type"), "", nil)
+	if err != nil {
+		return fmt.Errorf("invalid type: %w", err)
+	}
+	arguments := abi.Arguments{{Type: typ}}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	code, err := client.CodeAt(context.Background(), contractAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get contract code: %w", err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no contract code at %s", contractAddress.Hex())
+	}
+
+	if compilerOutput != "" {
+		refs, err := immutableOffsets(compilerOutput, name)
+		if err != nil {
+			return err
+		}
+
+		ref := refs[0]
+		if ref.Start+ref.Length > len(code) {
+			return fmt.Errorf("immutable offset %d is outside the deployed bytecode", ref.Start)
+		}
+
+		values, err := arguments.Unpack(code[ref.Start : ref.Start+ref.Length])
+		if err != nil {
+			return fmt.Errorf("failed to decode %s as %s: %w", name, typ.String(), err)
+		}
+		fmt.Printf("%s (%s) = %v\n", name, typ.String(), formatABIValue(values[0]))
+		return nil
+	}
+
+	// Without compiler output the variable name cannot be resolved, so list
+	// every embedded word that could hold a value of the requested type
+	fmt.Printf("No compiler output given; candidate %s values embedded in the bytecode:\n", typ.String())
+	words := push32Values(code)
+	offsets := make([]int, 0, len(words))
+	for offset := range words {
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+
+	seen := map[string]bool{}
+	found := false
+	for _, offset := range offsets {
+		word := words[offset]
+		if !matchesType(word, typ) || seen[string(word)] {
+			continue
+		}
+		seen[string(word)] = true
+
+		values, err := arguments.Unpack(word)
+		if err != nil {
+			continue
+		}
+		found = true
+		fmt.Printf("  offset %d: %v (%s)\n", offset, formatABIValue(values[0]), hexutil.Encode(word))
+	}
+	if !found {
+		fmt.Println("  none")
+	}
+	return nil
+}
+
+// formatABIValue renders a decoded ABI value for display
+func formatABIValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
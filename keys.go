@@ -0,0 +1,237 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Check encodes payload with a 4-byte double-SHA256 checksum
+func base58Check(payload []byte) string {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return base58Encode(append(append([]byte{}, payload...), second[:4]...))
+}
+
+// base58Encode encodes data as base58btc
+func base58Encode(data []byte) string {
+	value := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var encoded []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	// Leading zero bytes are kept as '1's
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58Decode decodes a base58btc string
+func base58Decode(text string) ([]byte, error) {
+	value := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range text {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+
+	// Leading '1's encode leading zero bytes
+	zeros := len(text) - len(strings.TrimLeft(text, base58Alphabet[:1]))
+	return append(make([]byte, zeros), value.Bytes()...), nil
+}
+
+// zeroBytes overwrites key material that is no longer needed
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// zeroPrivateKey overwrites the scalar of an ECDSA private key
+func zeroPrivateKey(key *ecdsa.PrivateKey) {
+	words := key.D.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+}
+
+func exportPrivateKey(c *cli.Context) error {
+	index := c.Int("index")
+	format := c.String("output-format")
+	out := c.String("out")
+
+	fmt.Fprintln(os.Stderr, "WARNING: anyone who sees this private key has full control of the account's funds.")
+	fmt.Fprintln(os.Stderr, "WARNING: never share it, and make sure your terminal and shell history are not recorded.")
+	if !c.Bool("confirm-dangerous") {
+		return fmt.Errorf("refusing to export a private key without --confirm-dangerous")
+	}
+	if format != "hex" && format != "wif" && format != "raw" {
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	if format == "raw" && out == "" {
+		return fmt.Errorf("raw output must be written to a file with --out")
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	account := accountList[index]
+
+	keyJSON, err := os.ReadFile(account.URL.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, keystorePassword)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt account: %w", err)
+	}
+	defer zeroPrivateKey(key.PrivateKey)
+
+	keyBytes := crypto.FromECDSA(key.PrivateKey)
+	defer zeroBytes(keyBytes)
+
+	var output []byte
+	switch format {
+	case "hex":
+		output = []byte(hexutil.Encode(keyBytes) + "\n")
+	case "wif":
+		// Bitcoin-style WIF: mainnet prefix 0x80, compressed-key flag 0x01
+		payload := append(append([]byte{0x80}, keyBytes...), 0x01)
+		output = []byte(base58Check(payload) + "\n")
+		zeroBytes(payload)
+	case "raw":
+		output = append([]byte{}, keyBytes...)
+	}
+	defer zeroBytes(output)
+
+	if out == "" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+
+	if err := os.WriteFile(out, output, 0600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Private key of %s written to %s\n", account.Address.Hex(), out)
+	return nil
+}
+
+func importPrivateKey(c *cli.Context) error {
+	password := keystorePassword
+	if c.IsSet("password-override") {
+		password = c.String("password-override")
+	}
+
+	keyBytes, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(c.String("key")), "0x"))
+	if err != nil || len(keyBytes) != 32 {
+		return fmt.Errorf("private key must be 32 bytes of hex")
+	}
+	defer zeroBytes(keyBytes)
+
+	// ToECDSA rejects zero and scalars not below the secp256k1 order
+	privateKey, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	defer zeroPrivateKey(privateKey)
+
+	account, err := importKey(openKeyStore(), privateKey, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account imported: %s\n", account.Address.Hex())
+	return nil
+}
+
+// importKey encrypts privateKey into the keystore, rejecting accounts that
+// are already present.
+func importKey(keyStore *keystore.KeyStore, privateKey *ecdsa.PrivateKey, password string) (accounts.Account, error) {
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if keyStore.HasAddress(address) {
+		return accounts.Account{}, fmt.Errorf("account %s is already in the keystore", address.Hex())
+	}
+
+	account, err := keyStore.ImportECDSA(privateKey, password)
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("failed to import private key: %w", err)
+	}
+	return account, nil
+}
+
+func importMnemonic(c *cli.Context) error {
+	count := c.Int("count")
+	password := keystorePassword
+	if c.IsSet("password-override") {
+		password = c.String("password-override")
+	}
+	if count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	path, err := parseDerivationPath(c.String("derivation-path"))
+	if err != nil {
+		return err
+	}
+	if len(path) == 0 {
+		return fmt.Errorf("derivation path must have at least one component")
+	}
+	if err := checkDerivationCount(path, count); err != nil {
+		return err
+	}
+
+	seed, err := mnemonicSeed(c.String("mnemonic"), c.String("passphrase"))
+	if err != nil {
+		return err
+	}
+	defer zeroBytes(seed)
+
+	keyStore := openKeyStore()
+	last := path[len(path)-1]
+	for i := 0; i < count; i++ {
+		// --count walks the last component of the path
+		path[len(path)-1] = last + uint32(i)
+
+		privateKey, err := deriveHDKey(seed, path)
+		if err != nil {
+			return err
+		}
+
+		account, err := importKey(keyStore, privateKey, password)
+		zeroPrivateKey(privateKey)
+		if err != nil {
+			fmt.Printf("%s: %v\n", formatDerivationPath(path), err)
+			continue
+		}
+		fmt.Printf("%s: imported %s\n", formatDerivationPath(path), account.Address.Hex())
+	}
+	return nil
+}
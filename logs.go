@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// logBlockRange is the number of blocks requested per eth_getLogs call. Most
+// hosted providers reject larger ranges.
+const logBlockRange = 10000
+
+// fetchLogs collects the logs matching addresses and topics between fromBlock
+// and toBlock (inclusive), splitting the range into provider-friendly chunks.
+// A toBlock of 0 means the latest block.
+func fetchLogs(client *ethclient.Client, addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock uint64) ([]types.Log, error) {
+	if toBlock == 0 {
+		latest, err := client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %w", err)
+		}
+		toBlock = latest
+	}
+
+	var logs []types.Log
+	for start := fromBlock; start <= toBlock; start += logBlockRange {
+		end := start + logBlockRange - 1
+		if end > toBlock {
+			end = toBlock
+		}
+
+		chunk, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: addresses,
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs for blocks %d-%d: %w", start, end, err)
+		}
+		logs = append(logs, chunk...)
+	}
+	return logs, nil
+}
+
+// blockTimes caches block timestamps while walking through logs
+type blockTimes struct {
+	client *ethclient.Client
+	cache  map[uint64]uint64
+}
+
+func newBlockTimes(client *ethclient.Client) *blockTimes {
+	return &blockTimes{client: client, cache: map[uint64]uint64{}}
+}
+
+// get returns the timestamp of block number
+func (b *blockTimes) get(number uint64) (uint64, error) {
+	if timestamp, ok := b.cache[number]; ok {
+		return timestamp, nil
+	}
+
+	header, err := b.client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+	b.cache[number] = header.Time
+	return header.Time, nil
+}
@@ -4,17 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math"
 	"math/big"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
 	"github.com/urfave/cli/v2"
 
+	Signer "eth-manage/signer"
 	Token "eth-manage/token"
 )
 
@@ -23,8 +24,14 @@ var (
 	infuraKey        string
 	network          string
 	keystorePassword string
-	ethNodeURL       string
-	chainId          big.Int
+	beaconNodeURL    string
+	etherscanKey     string
+	safeTxServiceURL string
+	lifiKey          string
+	smtpHost         string
+	smtpUser         string
+	smtpPass         string
+	chainId          big.Int // set by --chain-id, otherwise by resolveChainID
 )
 
 func main() {
@@ -37,101 +44,3032 @@ func main() {
 	// Read values from environment variables
 	keystoreDir = os.Getenv("KESTORE_DIR")
 	infuraKey = os.Getenv("INFURA_KEY")
-	network = os.Getenv("NETWORK")
+	alchemyKey = os.Getenv("ALCHEMY_KEY")
+	quicknodeURL = os.Getenv("QUICKNODE_URL")
 	keystorePassword = os.Getenv("KEYSTORE_PASSWORD")
-
-	chainId = *big.NewInt(1)
-	ethNodeURL = fmt.Sprintf("https://%s.infura.io/v3/%s", network, infuraKey)
+	beaconNodeURL = os.Getenv("BEACON_NODE_URL")
+	etherscanKey = os.Getenv("ETHERSCAN_API_KEY")
+	safeTxServiceURL = os.Getenv("SAFE_TX_SERVICE_URL")
+	lifiKey = os.Getenv("LIFI_API_KEY")
+	smtpHost = os.Getenv("SMTP_HOST")
+	smtpUser = os.Getenv("SMTP_USER")
+	smtpPass = os.Getenv("SMTP_PASS")
 
 	app := &cli.App{
 		Name:  "eth_project",
 		Usage: "Ethereum CLI project",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "network",
+				Usage:   "Network to connect to (mainnet, sepolia, arbitrum, optimism, ...)",
+				EnvVars: []string{"NETWORK"},
+			},
+			&cli.StringFlag{
+				Name:    "provider",
+				Usage:   "RPC provider (infura, alchemy, quicknode, local, custom)",
+				Value:   "infura",
+				EnvVars: []string{"PROVIDER"},
+			},
+			&cli.StringFlag{
+				Name:    "rpc-url",
+				Usage:   "RPC endpoint to use instead of the provider's",
+				EnvVars: []string{"RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:    "rpc-url-fallback",
+				Usage:   "RPC endpoint to switch to after repeated connection errors",
+				EnvVars: []string{"RPC_URL_FALLBACK"},
+			},
+			&cli.StringFlag{
+				Name:    "ws-url",
+				Usage:   "Websocket endpoint for subscriptions (default: the provider's)",
+				EnvVars: []string{"WS_URL"},
+			},
+			&cli.Int64Flag{
+				Name:    "chain-id",
+				Usage:   "Chain ID to sign with (default: queried from the node)",
+				EnvVars: []string{"CHAIN_ID"},
+			},
+			&cli.StringFlag{
+				Name:    "hsm-lib",
+				Usage:   "PKCS#11 library of a hardware security module to sign with",
+				EnvVars: []string{"HSM_LIB"},
+			},
+			&cli.UintFlag{
+				Name:    "hsm-slot",
+				Usage:   "HSM slot holding the signing key",
+				EnvVars: []string{"HSM_SLOT"},
+			},
+			&cli.StringFlag{
+				Name:    "hsm-pin",
+				Usage:   "HSM user PIN",
+				EnvVars: []string{"HSM_PIN"},
+			},
+			&cli.StringFlag{
+				Name:  "db",
+				Usage: "Transaction history database (default $HOME/.eth-manage/history.db)",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			network = c.String("network")
+			provider = c.String("provider")
+			rpcURL = c.String("rpc-url")
+			rpcURLFallback = c.String("rpc-url-fallback")
+			wsURL = c.String("ws-url")
+			if err := checkProvider(); err != nil {
+				return err
+			}
+			if c.IsSet("chain-id") {
+				chainId.SetInt64(c.Int64("chain-id"))
+			}
+			historyPath = c.String("db")
+
+			if lib := c.String("hsm-lib"); lib != "" {
+				hsmSigner, err = Signer.NewPKCS11Signer(lib, c.Uint("hsm-slot"), c.String("hsm-pin"))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		After: func(c *cli.Context) error {
+			if hsmSigner != nil {
+				hsmSigner.Close()
+			}
+			if err := closeHistory(); err != nil {
+				return fmt.Errorf("failed to close history: %w", err)
+			}
+			return nil
+		},
 		Commands: []*cli.Command{
 			{
-				Name:   "create-account",
-				Usage:  "Create a new Ethereum account",
-				Action: createAccount,
+				Name:   "create-account",
+				Usage:  "Create a new Ethereum account",
+				Action: createAccount,
+			},
+			{
+				Name:   "list-accounts",
+				Usage:  "List all Ethereum accounts",
+				Action: listAccounts,
+			},
+			{
+				Name:   "check-balance",
+				Usage:  "Check ETH and token balances",
+				Action: checkBalance,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index to check balance",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "all-accounts",
+						Usage:    "Check every account in the keystore",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "token-addresses",
+						Usage:    "Comma-separated token addresses to check",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "concurrency",
+						Usage:    "Maximum number of balance calls in flight",
+						Required: false,
+						Value:    10,
+					},
+				},
+			},
+			{
+				Name:   "transfer-eth",
+				Usage:  "Transfer ETH to another address",
+				Action: transferEth,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of ETH to transfer",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for the transaction to be mined",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for the transaction",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+					&cli.BoolFlag{
+						Name:     "eip1559",
+						Usage:    "Send an EIP-1559 transaction (default: when the network has a base fee)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-fee-per-gas",
+						Usage:    "EIP-1559 max fee per gas in gwei (default: 2x base fee plus tip)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-priority-fee",
+						Usage:    "EIP-1559 priority fee in gwei (default: node suggestion)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "transfer-token",
+				Usage:  "Transfer tokens to another address",
+				Action: transferToken,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of tokens to transfer",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "bypass-scam-check",
+						Usage:    "Transfer even if the token is on a scam blocklist",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for the transaction to be mined",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for the transaction",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+					&cli.BoolFlag{
+						Name:     "eip1559",
+						Usage:    "Send an EIP-1559 transaction (default: when the network has a base fee)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-fee-per-gas",
+						Usage:    "EIP-1559 max fee per gas in gwei (default: 2x base fee plus tip)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-priority-fee",
+						Usage:    "EIP-1559 priority fee in gwei (default: node suggestion)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "verify-tx-signature",
+				Usage:  "Recover the signer of a transaction and check it against the sender",
+				Action: verifyTxSignature,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hash",
+						Usage:    "Transaction hash",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "assert-signer",
+						Usage:    "Fail if the recovered signer is not this address",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "detect-exchange",
+				Usage:  "Check whether an address is a known exchange hot wallet",
+				Action: detectExchange,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to check",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "update-exchange-db",
+				Usage:  "Download the latest exchange hot wallet database",
+				Action: updateExchangeDatabase,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "url",
+						Usage:    "URL of the exchange wallet JSON list",
+						EnvVars:  []string{"EXCHANGE_DB_URL"},
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "simulate-swap",
+				Usage:  "Quote a multi-hop Uniswap V3 swap without executing it",
+				Action: simulateSwap,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "path",
+						Usage:    "Swap route as <token>-<fee>-<token>[-<fee>-<token>...]",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount-in",
+						Usage:    "Amount of the first token to swap",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "quoter",
+						Usage:    "Uniswap V3 QuoterV2 address",
+						Required: false,
+						Value:    uniswapV3QuoterAddress,
+					},
+				},
+			},
+			{
+				Name:   "polygon-deposit-eth",
+				Usage:  "Deposit ETH to Polygon through the PoS bridge",
+				Action: polygonDepositEth,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of ETH to deposit",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "polygon-deposit-erc20",
+				Usage:  "Deposit ERC20 tokens to Polygon through the PoS bridge",
+				Action: polygonDepositErc20,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token",
+						Usage:    "Token address on Ethereum",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of tokens to deposit",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "nft-rental-status",
+				Usage:  "Show the current user and expiry of an ERC-4907 rental NFT",
+				Action: nftRentalStatus,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "NFT contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-id",
+						Usage:    "Token ID",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "set-nft-user",
+				Usage:  "Rent out an ERC-4907 NFT by setting its user and expiry",
+				Action: setNftUser,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the owning account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "NFT contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-id",
+						Usage:    "Token ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "user",
+						Usage:    "Address of the renter",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "expires",
+						Usage:    "Unix timestamp when the rental ends",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "beacon-status",
+				Usage:  "Show a validator's status and balance from the beacon chain",
+				Action: beaconStatus,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "validator-index",
+						Usage:    "Validator index or public key",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "beacon-block",
+				Usage:  "Show a beacon chain block",
+				Action: beaconBlock,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "slot",
+						Usage:    "Slot number, block root, or head/finalized",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "network-info",
+				Usage:  "Show chain ID, latest block and gas prices of the connected network",
+				Action: networkInfo,
+			},
+			{
+				Name:   "op-l2-to-l1-message",
+				Usage:  "Show an OP Mainnet L2 to L1 message and its withdrawal status",
+				Action: opL2ToL1Message,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hash",
+						Usage:    "Hash of the L2 transaction that sent the message",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "mev-relay-stats",
+				Usage:  "Show recent payloads delivered by an MEV-Boost relay",
+				Action: mevRelayStats,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "relay-url",
+						Usage:    "MEV-Boost relay URL",
+						Required: false,
+						Value:    "https://boost-relay.flashbots.net",
+					},
+					&cli.StringFlag{
+						Name:     "builder-pubkey",
+						Usage:    "Only show payloads from this builder",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "limit",
+						Usage:    "Maximum number of payloads to show",
+						Required: false,
+						Value:    10,
+					},
+				},
+			},
+			{
+				Name:   "check-bundle",
+				Usage:  "Check the inclusion status of a Flashbots bundle",
+				Action: checkBundle,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "bundle-hash",
+						Usage:    "Bundle hash returned by eth_sendBundle",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "block-number",
+						Usage:    "Block the bundle targeted",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the account that signed the bundle",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "relay-url",
+						Usage:    "Flashbots relay RPC URL",
+						Required: false,
+						Value:    "https://relay.flashbots.net",
+					},
+				},
+			},
+			{
+				Name:   "batch-mint-estimate",
+				Usage:  "Compare the gas cost of individual and batched ERC-1155 mints",
+				Action: batchMintEstimate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-1155 contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "abi",
+						Usage:    "Path to the contract ABI JSON file",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "from-index",
+						Usage:    "Index of the minting account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "recipients",
+						Usage:    "CSV file with address,token_id,amount columns",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "mint-method",
+						Usage:    "Name of the single mint function",
+						Required: false,
+						Value:    "mint",
+					},
+					&cli.StringFlag{
+						Name:     "batch-method",
+						Usage:    "Name of the batch mint function",
+						Required: false,
+						Value:    "mintBatch",
+					},
+				},
+			},
+			{
+				Name:   "token-migrate",
+				Usage:  "Migrate the full balance of a token to its successor contract",
+				Action: tokenMigrate,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from-index",
+						Usage:    "Index of the migrating account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "old-token",
+						Usage:    "Address of the token being migrated",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "new-token",
+						Usage:    "Address of the successor token",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "migration-contract",
+						Usage:    "Address of the migration contract",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "check-migration-status",
+				Usage:  "Show the overall progress of a token migration",
+				Action: checkMigrationStatus,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "old-token",
+						Usage:    "Address of the token being migrated",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "new-token",
+						Usage:    "Address of the successor token",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "migration-contract",
+						Usage:    "Address of the migration contract",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "parse-eip3770",
+				Usage:  "Split an EIP-3770 chain-specific address into its network and address",
+				Action: parseEIP3770Address,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "EIP-3770 address, e.g. eth:0xabc...",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "format-eip3770",
+				Usage:  "Format an address as an EIP-3770 chain-specific address",
+				Action: formatEIP3770Address,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Hex address",
+						Required: true,
+					},
+					&cli.Int64Flag{
+						Name:     "chain-id",
+						Usage:    "Chain ID of the network",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "gas-token-status",
+				Usage:  "Show legacy gas token (CHI, GST) balances and post-London alternatives",
+				Action: gasTokenStatus,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to check",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "token-pause-history",
+				Usage:  "Show the pause and freeze history of a token",
+				Action: tokenPauseHistory,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "First block to search",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "permit2-batch-transfer",
+				Usage:  "Sign a Permit2 batch permit and transfer several tokens in one transaction",
+				Action: permit2BatchTransfer,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the token owner's account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "permit-batch-json",
+						Usage:    "JSON file with an array of {token, spender, amount, nonce, deadline} objects",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "read-immutable",
+				Usage:  "Read a Solidity immutable variable from deployed bytecode",
+				Action: readImmutable,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "Name of the immutable variable",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "type",
+						Usage:    "Solidity type of the variable, e.g. address or uint256",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "compiler-output",
+						Usage:    "solc standard JSON output containing immutableReferences",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "detect-events",
+				Usage:  "List the events a contract emits without needing its ABI",
+				Action: detectEvents,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "First block to search",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "to-block",
+						Usage:    "Last block to search (defaults to the latest block)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "verify-sourcify",
+						Usage:    "Confirm signatures against the Sourcify-verified ABI",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "flash-loan-test",
+				Usage:  "Exercise a flash loan receiver contract through Aave V3 flashLoanSimple",
+				Action: flashLoanTest,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "asset",
+						Usage:    "Address of the token to borrow",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of tokens to borrow",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "receiver",
+						Usage:    "Address of the deployed flash loan receiver contract",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "params",
+						Usage:    "Hex-encoded params passed to the receiver",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "pool",
+						Usage:    "Aave V3 Pool address",
+						Required: false,
+						Value:    aaveV3PoolAddress,
+					},
+				},
+			},
+			{
+				Name:   "ens-batch-resolve",
+				Usage:  "Resolve a file of ENS names or addresses",
+				Action: ensBatchResolve,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "input",
+						Usage:    "File with one name or address per line",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "direction",
+						Usage:    "forward (name to address) or reverse (address to name)",
+						Required: false,
+						Value:    "forward",
+					},
+					&cli.StringFlag{
+						Name:     "output",
+						Usage:    "Output format: tsv or json",
+						Required: false,
+						Value:    "tsv",
+					},
+					&cli.IntFlag{
+						Name:     "concurrency",
+						Usage:    "Number of parallel lookups",
+						Required: false,
+						Value:    4,
+					},
+					&cli.IntFlag{
+						Name:     "rate",
+						Usage:    "Maximum lookups started per second",
+						Required: false,
+						Value:    10,
+					},
+				},
+			},
+			{
+				Name:   "verify-tx-sender",
+				Usage:  "Check that a transaction was sent by a keystore account",
+				Action: verifyTxSender,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hash",
+						Usage:    "Transaction hash",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the expected keystore account",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "diamond-facets",
+				Usage:  "List the facets and function selectors of an ERC-2535 diamond",
+				Action: diamondFacets,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Address of the diamond proxy",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "diamond-facet-address",
+				Usage:  "Find the facet that implements a function selector",
+				Action: diamondFacetAddress,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Address of the diamond proxy",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "selector",
+						Usage:    "4-byte function selector (e.g. 0xa9059cbb)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "bridge-tvl",
+				Usage:  "Show the token balances held by a bridge escrow",
+				Action: bridgeTVL,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "bridge",
+						Usage:    "Address of the bridge escrow contract",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "tokens",
+						Usage:    "File with one token address per line, optionally followed by a Chainlink USD feed (token,feed)",
+						Required: true,
+					},
+					&cli.Float64Flag{
+						Name:     "alert-below-eth",
+						Usage:    "Warn when a token's liquidity is worth less than this many ETH",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "browse-contract",
+				Usage:  "Interactively call the functions of a verified contract",
+				Action: browseContract,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Address of the verified contract",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account used for write functions",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "verify-contract-signature",
+				Usage:  "Validate a signature against a contract account with ERC-1271",
+				Action: verifyContractSignature,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Address of the contract account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "message-hash",
+						Usage:    "32-byte hash that was signed",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "signature",
+						Usage:    "Hex-encoded signature",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "effective-gas-price",
+				Usage:  "Show the gas price actually paid by a transaction",
+				Action: effectiveGasPrice,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hash",
+						Usage:    "Transaction hash",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "check-rebase-balance",
+				Usage:  "Compare the rebased balance and underlying shares of a rebasing token",
+				Action: checkRebaseBalance,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Address of the rebasing token",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the keystore account",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "governance-proposals",
+				Usage:  "List proposals of a Compound or OpenZeppelin governor",
+				Action: governanceProposals,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "governor",
+						Usage:    "Address of the governor contract",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "state",
+						Usage:    "Filter by state: active, pending or all",
+						Required: false,
+						Value:    "all",
+					},
+					&cli.IntFlag{
+						Name:     "limit",
+						Usage:    "Maximum number of proposals to show, newest first",
+						Required: false,
+						Value:    20,
+					},
+				},
+			},
+			{
+				Name:   "governance-vote",
+				Usage:  "Cast a vote on a governance proposal",
+				Action: governanceVote,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the voting account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "governor",
+						Usage:    "Address of the governor contract",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "proposal-id",
+						Usage:    "Proposal ID",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:     "support",
+						Usage:    "0 = against, 1 = for, 2 = abstain",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "sandwich-risk",
+				Usage:  "Assess the sandwich attack risk of a signed swap transaction",
+				Action: sandwichRisk,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hex",
+						Usage:    "Signed, unbroadcast transaction as hex",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "update-decimals-cache",
+				Usage:  "Fetch and cache the decimals of a list of tokens",
+				Action: updateDecimalsCache,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-addresses",
+						Usage:    "File with one token address per line",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "clear-decimals-cache",
+				Usage:  "Remove the local token decimals cache",
+				Action: clearDecimalsCache,
+			},
+			{
+				Name:   "watch-ownership",
+				Usage:  "Watch a contract for owner and role changes",
+				Action: watchOwnership,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Address of the contract to watch",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "interval",
+						Usage:    "Polling interval in seconds",
+						Required: false,
+						Value:    60,
+					},
+					&cli.StringFlag{
+						Name:     "webhook",
+						Usage:    "URL that receives each change as a JSON POST",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "log-file",
+						Usage:    "File changes are appended to (default $HOME/.eth-manage/ownership.log)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "watchlist-add",
+				Usage:  "Add a token to the watchlist",
+				Action: watchlistAdd,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "watchlist-remove",
+				Usage:  "Remove a token from the watchlist",
+				Action: watchlistRemove,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "watchlist",
+				Usage:  "List the tokens in the watchlist",
+				Action: watchlistList,
+			},
+			{
+				Name:   "verify-domains",
+				Usage:  "Check the EIP-712 domain separator of every watchlist token",
+				Action: verifyDomains,
+			},
+			{
+				Name:   "safe-list-pending",
+				Usage:  "List pending multisig transactions of a Safe",
+				Action: safeListPending,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "safe",
+						Usage:    "Safe address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "safe-sign-pending",
+				Usage:  "Confirm a pending Safe transaction",
+				Action: safeSignPending,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the Safe owner account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "safe",
+						Usage:    "Safe address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "safe-tx-hash",
+						Usage:    "Safe transaction hash to confirm",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "next-base-fee",
+				Usage:  "Predict the base fee of the next blocks",
+				Action: predictNextBaseFee,
+			},
+			{
+				Name:   "snapshot-proposals",
+				Usage:  "List open Snapshot proposals of a space",
+				Action: snapshotProposals,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "space",
+						Usage:    "Snapshot space (e.g. uniswap.eth)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "snapshot-vote-power",
+				Usage:  "Show the Snapshot voting power of an address for a proposal",
+				Action: snapshotVotePower,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "space",
+						Usage:    "Snapshot space (e.g. uniswap.eth)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Voter address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "proposal-id",
+						Usage:    "Snapshot proposal ID",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "total-balance",
+				Usage:  "Aggregate an address's balances across chains",
+				Action: totalBalance,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to check",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "chains",
+						Usage:    "Comma-separated networks",
+						Required: false,
+						Value:    "mainnet,polygon,arbitrum,optimism,bsc",
+					},
+					&cli.StringFlag{
+						Name:     "tokens",
+						Usage:    "File of tokens to include, one <network>,<token> per line",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "contract-creator",
+				Usage:  "Find who deployed a contract and when",
+				Action: contractCreator,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract address",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "max-binary-search-depth",
+						Usage:    "Maximum number of binary search steps over block numbers",
+						Required: false,
+						Value:    64,
+					},
+				},
+			},
+			{
+				Name:   "watch-supply-changes",
+				Usage:  "List the mints and burns of a token",
+				Action: watchSupplyChanges,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "First block to scan",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:     "live",
+						Usage:    "Keep watching for new mints and burns",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "Write the events to a CSV file",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "constructor-args",
+				Usage:  "Decode the constructor arguments a contract was deployed with",
+				Action: constructorArgs,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract address (verified on Sourcify)",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "max-binary-search-depth",
+						Usage:    "Maximum number of binary search steps over block numbers",
+						Required: false,
+						Value:    64,
+					},
+				},
+			},
+			{
+				Name:   "faucet-request",
+				Usage:  "Request testnet tokens or ETH from a faucet",
+				Action: faucetRequest,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Address of the faucet token contract",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "to-index",
+						Usage:    "Index of the receiving account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount to mint, for faucets with mint(address,uint256)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "faucet-url",
+						Usage:    "Base URL of a web faucet; posts to <url>/api/faucet",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "portfolio-chart",
+				Usage:  "Show an account's portfolio allocation as an ASCII pie chart",
+				Action: portfolioChart,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the keystore account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-addresses",
+						Usage:    "File with one token address per line",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "output",
+						Usage:    "Output format: chart or json",
+						Required: false,
+						Value:    "chart",
+					},
+				},
+			},
+			{
+				Name:   "compound-apy",
+				Usage:  "Project compound interest for a staking or lending position",
+				Action: compoundAPY,
+				Flags: []cli.Flag{
+					&cli.Float64Flag{
+						Name:     "principal",
+						Usage:    "Starting balance",
+						Required: true,
+					},
+					&cli.Float64Flag{
+						Name:     "apy",
+						Usage:    "Nominal annual rate in percent",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "days",
+						Usage:    "Number of days to project",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "compound-frequency",
+						Usage:    "daily, weekly, monthly or continuously",
+						Required: false,
+						Value:    "daily",
+					},
+					&cli.StringFlag{
+						Name:     "protocol",
+						Usage:    "Fetch the current supply rate from aave or compound instead of --apy",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "asset",
+						Usage:    "Reserve asset for --protocol aave",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "market",
+						Usage:    "Comet market for --protocol compound",
+						Required: false,
+						Value:    compoundV3USDCAddress,
+					},
+				},
+			},
+			{
+				Name:   "compare-bytecode",
+				Usage:  "Compare the runtime bytecode of two contracts",
+				Action: compareBytecode,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract-a",
+						Usage:    "First contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract-b",
+						Usage:    "Second contract address",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:     "diff",
+						Usage:    "Show the differing bytes",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "holder-concentration",
+				Usage:  "Measure how concentrated a token's holders are",
+				Action: holderConcentration,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "Block to start replaying transfers from (ideally the deployment block)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "storage-slot",
+				Usage:  "Compute the storage slot of a common variable layout",
+				Action: storageSlot,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "pattern",
+						Usage:    "erc20-balance, erc20-allowance, ownable-owner, eip1967-implementation, eip1967-admin or eip1967-beacon",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "key",
+						Usage:    "Mapping key (holder or owner address)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "key2",
+						Usage:    "Second mapping key (spender address for erc20-allowance)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "custom-slot",
+						Usage:    "Override the pattern's base slot",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Read the slot from this contract",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "simulate-fee-on-transfer",
+				Usage:  "Simulate a token transfer and measure any transfer fee",
+				Action: simulateFeeOnTransfer,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    "Sender address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of tokens to transfer",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "forensic-dump",
+				Usage:  "Export the full state of an address at a block",
+				Action: forensicDump,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to export",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "block",
+						Usage:    "Block to export the state at (default latest)",
+						Required: false,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "First block of the event range (default --block)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "Output directory",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "ens-wildcard-resolve",
+				Usage:  "Resolve an ENS name through an ENSIP-10 wildcard resolver",
+				Action: ensWildcardResolve,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "ENS name (e.g. sub.parent.eth)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "token-velocity",
+				Usage:  "Measure ERC-20 transfer volume relative to total supply",
+				Action: tokenVelocity,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token contract address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "from-block",
+						Usage:    "First block to scan",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "to-block",
+						Usage:    "Last block to scan (default: latest)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "output",
+						Usage:    "Output format: text or json",
+						Required: false,
+						Value:    "text",
+					},
+				},
+			},
+			{
+				Name:   "check-access-control",
+				Usage:  "Check whether a keystore account passes a function's access modifiers",
+				Action: checkAccessControl,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Sourcify-verified contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "function",
+						Usage:    "Function selector, name or signature",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the keystore account to check",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "ens-reverse-claim",
+				Usage:  "Claim the ENS reverse record of a keystore account",
+				Action: ensReverseClaim,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sender account",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "ens-set-name",
+				Usage:  "Set the primary ENS name of a keystore account",
+				Action: ensSetName,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sender account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "ENS name the account's address resolves to",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "ens-reverse-lookup",
+				Usage:  "Look up the primary ENS name of an address",
+				Action: ensReverseLookup,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to look up",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "update-token-list",
+				Usage:  "Download and cache a Uniswap-format token list",
+				Action: updateTokenList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "url",
+						Usage:    "Token list URL (https:// or ipfs://)",
+						Required: false,
+						Value:    defaultTokenListURL,
+					},
+				},
+			},
+			{
+				Name:   "token-list-search",
+				Usage:  "Find a token's address by symbol in the cached token list",
+				Action: tokenListSearch,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "symbol",
+						Usage:    "Token symbol (case-insensitive)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "create-wrapper",
+				Usage:  "Deploy an ERC-20 wrapper that charges a fee on transfers",
+				Action: createWrapper,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the deploying account, which receives the fees",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "underlying",
+						Usage:    "Token to wrap",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "fee-bps",
+						Usage:    "Transfer fee in basis points (at most 10000)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "wrap-tokens",
+				Usage:  "Deposit underlying tokens into a fee wrapper",
+				Action: wrapTokens,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "wrapper",
+						Usage:    "Wrapper contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of underlying tokens to wrap",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "unwrap-tokens",
+				Usage:  "Redeem wrapped tokens for the underlying token",
+				Action: unwrapTokens,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "wrapper",
+						Usage:    "Wrapper contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of wrapped tokens to unwrap",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "siwe-create",
+				Usage:  "Create and sign an EIP-4361 Sign-In with Ethereum message",
+				Action: siweCreate,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the signing account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "domain",
+						Usage:    "Domain requesting the sign-in (e.g. example.com)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "uri",
+						Usage:    "URI of the resource being signed in to",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "statement",
+						Usage:    "Human-readable statement shown to the user",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "expiry",
+						Usage:    "How long the message stays valid (e.g. 15m); no expiry when omitted",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "siwe-verify",
+				Usage:  "Verify a signed EIP-4361 Sign-In with Ethereum message",
+				Action: siweVerify,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "message-file",
+						Usage:    "File containing the plaintext message",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "signature",
+						Usage:    "Hex signature of the message",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "expected-address",
+						Usage:    "Address that must have signed the message",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "predict-contract-address",
+				Usage:  "Compute the address of a contract deployed with CREATE",
+				Action: predictContractAddress,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    "Deploying address",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "nonce",
+						Usage:    "Nonce of the deployment transaction",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "until-nonce",
+						Usage:    "Print addresses for every nonce up to this one",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "predict-create2-address",
+				Usage:  "Compute the address of a contract deployed with CREATE2",
+				Action: predictCreate2Address,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "factory",
+						Usage:    "Address of the deploying contract",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "salt",
+						Usage:    "CREATE2 salt as hex (left-padded to 32 bytes)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "init-code-hash",
+						Usage:    "keccak256 of the contract's init code",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "export-private-key",
+				Usage:  "Decrypt a keystore account and export its private key",
+				Action: exportPrivateKey,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the account to export",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "output-format",
+						Usage:    "hex, wif or raw (32 bytes, requires --out)",
+						Required: false,
+						Value:    "hex",
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "File to write the key to (created with mode 0600) instead of stdout",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "confirm-dangerous",
+						Usage:    "Confirm that you understand the key grants full control of the account",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "check-scam",
+				Usage:  "Check a token address against scam and phishing blocklists",
+				Action: checkScam,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address to check",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "update-blocklist",
+				Usage:  "Download the latest scam token blocklist",
+				Action: updateBlocklist,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "url",
+						Usage:    "URL of the blocklist JSON",
+						Value:    defaultBlocklistURL,
+						EnvVars:  []string{"SCAM_BLOCKLIST_URL"},
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "import-private-key",
+				Usage:  "Import a hex private key into the keystore",
+				Action: importPrivateKey,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "key",
+						Usage:    "Private key as 32 bytes of hex",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "password-override",
+						Usage:    "Encrypt the key with this password instead of KEYSTORE_PASSWORD",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "compare-transfer-cost",
+				Usage:  "Compare the gas cost of sending a USD value as ETH or as a stablecoin",
+				Action: compareTransferCost,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address",
+						Required: true,
+					},
+					&cli.Float64Flag{
+						Name:     "amount-usd",
+						Usage:    "Value to send in USD",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "stablecoin",
+						Usage:    "Reference stablecoin: usdc or usdt",
+						Required: false,
+						Value:    "usdc",
+					},
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account to estimate gas from",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "import-mnemonic",
+				Usage:  "Derive accounts from a BIP-39 mnemonic and import them into the keystore",
+				Action: importMnemonic,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "mnemonic",
+						Usage:    "12 to 24 word BIP-39 mnemonic",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "passphrase",
+						Usage:    "Optional BIP-39 passphrase (\"25th word\")",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "derivation-path",
+						Usage:    "BIP-44 derivation path of the first account",
+						Required: false,
+						Value:    defaultDerivationPath,
+					},
+					&cli.IntFlag{
+						Name:     "count",
+						Usage:    "Number of consecutive accounts to import",
+						Required: false,
+						Value:    1,
+					},
+					&cli.StringFlag{
+						Name:     "password-override",
+						Usage:    "Encrypt the keys with this password instead of KEYSTORE_PASSWORD",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "curve-pool-info",
+				Usage:  "Show the composition, virtual price, fee and amplification of a Curve pool",
+				Action: curvePoolInfo,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "pool",
+						Usage:    "Curve pool address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "curve-lp-value",
+				Usage:  "Compute the USD value of an account's Curve LP tokens",
+				Action: curveLPValue,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account holding the LP tokens",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "pool",
+						Usage:    "Curve pool address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "check-selfdestruct",
+				Usage:  "Check a contract for SELFDESTRUCT and what EIP-6780 means for it",
+				Action: checkSelfdestruct,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "data",
+						Usage:    "Hex call data for the traced synthetic call",
+						Required: false,
+						Value:    "0x",
+					},
+					&cli.IntFlag{
+						Name:     "max-binary-search-depth",
+						Usage:    "Maximum number of binary search steps over block numbers",
+						Required: false,
+						Value:    64,
+					},
+				},
+			},
+			{
+				Name:   "decode-rlp",
+				Usage:  "Decode hex RLP data and print it as a tree",
+				Action: decodeRLP,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "data",
+						Usage:    "Hex-encoded RLP data, or - to read it from stdin",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "check-nft-balance",
+				Usage:  "Check how many tokens of an ERC-721 contract an account owns",
+				Action: checkNftBalance,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index to check",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-721 contract address",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "transfer-nft",
+				Usage:  "Transfer an ERC-721 token with safeTransferFrom",
+				Action: transferNft,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account owning the token",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-721 contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-id",
+						Usage:    "Token ID",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "list-nfts",
+				Usage:  "List the ERC-721 token IDs owned by an account",
+				Action: listNfts,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index to list tokens for",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-721 contract address",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "count",
+						Usage:    "Maximum number of tokens to list (all when 0)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "bloom-check",
+				Usage:  "Test whether an address or topic may be in a logs bloom filter",
+				Action: bloomCheck,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Contract address to test",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "topic",
+						Usage:    "32-byte log topic to test",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "bloom",
+						Usage:    "2048-bit logs bloom as 512 hex characters",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "bloom-create",
+				Usage:  "Build a logs bloom filter from addresses and topics",
+				Action: bloomCreate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "addresses",
+						Usage:    "Comma-separated contract addresses",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "topics",
+						Usage:    "Comma-separated 32-byte log topics",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "check-1155-balance",
+				Usage:  "Check an account's balance of ERC-1155 token IDs",
+				Action: check1155Balance,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index to check",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-1155 contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-id",
+						Usage:    "Token ID, or comma-separated token IDs",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "batch-transfer-1155",
+				Usage:  "Transfer several ERC-1155 token IDs with safeBatchTransferFrom",
+				Action: batchTransfer1155,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "ERC-1155 contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "ids",
+						Usage:    "Comma-separated token IDs",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amounts",
+						Usage:    "Comma-separated amounts, one per token ID",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "gas-alert",
+				Usage:  "Alert through a webhook or email when the gas price crosses a threshold",
+				Action: gasAlertWatch,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "below",
+						Usage:    "Alert when the gas price drops below this many gwei",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "above",
+						Usage:    "Alert when the gas price rises above this many gwei",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "webhook",
+						Usage:    "URL that receives each alert as a JSON POST",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "email",
+						Usage:    "Address that receives each alert by email (needs SMTP_HOST, SMTP_USER, SMTP_PASS)",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "poll-interval",
+						Usage:    "Polling interval in seconds",
+						Required: false,
+						Value:    15,
+					},
+					&cli.IntFlag{
+						Name:     "alert-cooldown",
+						Usage:    "Minimum minutes between two alerts for the same threshold",
+						Required: false,
+						Value:    30,
+					},
+				},
+			},
+			{
+				Name:   "watch-slashing",
+				Usage:  "Watch the beacon chain for slashings of the given validators",
+				Action: watchSlashing,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "validator-pubkeys",
+						Usage:    "File with one validator public key per line",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "gas-estimate",
+				Usage:  "Estimate the gas and cost of an ETH or token transfer without sending it",
+				Action: gasEstimate,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the sending account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of ETH, or of the token when --token-address is set",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Token address, to estimate a token transfer",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "gas-price-gwei",
+						Usage:    "Gas price in gwei to compute the cost with, instead of base fee plus tip",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "optimize-bytecode-cost",
+				Usage:  "Compare deployment and call gas across solc optimizer run counts",
+				Action: optimizeBytecodeCost,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "source",
+						Usage:    "Solidity source file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "contract",
+						Usage:    "Contract to compile when the source has several",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "optimizer-runs",
+						Usage:    "Comma-separated optimizer run counts; ∞ for the maximum",
+						Required: false,
+						Value:    "1,200,1000,∞",
+					},
+					&cli.StringFlag{
+						Name:     "function",
+						Usage:    "Benchmark function name whose call gas is estimated",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "args",
+						Usage:    "Comma-separated arguments for the benchmark function",
+						Required: false,
+					},
+					&cli.Float64Flag{
+						Name:     "expected-calls",
+						Usage:    "Expected number of benchmark calls per deployment",
+						Required: false,
+						Value:    1000,
+					},
+				},
+			},
+			{
+				Name:   "abi-diff",
+				Usage:  "Compare the functions of two versions of a contract ABI",
+				Action: abiDiff,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "old-abi",
+						Usage:    "Path to the old ABI JSON file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "new-abi",
+						Usage:    "Path to the new ABI JSON file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "output",
+						Usage:    "Output format: text or json",
+						Required: false,
+						Value:    "text",
+					},
+				},
+			},
+			{
+				Name:   "list-transactions",
+				Usage:  "List transactions sent by this tool",
+				Action: listTransactions,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from-index",
+						Usage:    "Only list transactions sent by this account index",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Only list transactions sent to or from this address, or of this token",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "hash",
+						Usage:    "Only show the transaction with this hash",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "limit",
+						Usage:    "Number of most recent transactions to list (all when 0)",
+						Required: false,
+						Value:    20,
+					},
+					&cli.StringFlag{
+						Name:     "since",
+						Usage:    "Only list transactions since a date (2024-01-31), RFC 3339 time or duration (24h)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "csv",
+						Usage:    "Print CSV instead of text",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "clear-history",
+				Usage:  "Delete the transaction history",
+				Action: clearHistory,
+			},
+			{
+				Name:   "watch-address",
+				Usage:  "Stream transactions to and from an address as blocks arrive",
+				Action: watchAddress,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index to watch",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to watch instead of a keystore account",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "Also report ERC-20 transfers of this token",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "node-peers",
+				Usage:  "Show the peer count of the node and, with the admin namespace, its peers",
+				Action: nodePeers,
+			},
+			{
+				Name:   "register-function",
+				Usage:  "Register a function signature for a selector in the local database",
+				Action: registerFunction,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "selector",
+						Usage:    "4-byte function selector",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "signature",
+						Usage:    "Function signature, e.g. transfer(address,uint256)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "list-registered-functions",
+				Usage:  "List the function signatures registered in the local database",
+				Action: listRegisteredFunctions,
+			},
+			{
+				Name:   "unregister-function",
+				Usage:  "Remove a function signature from the local database",
+				Action: unregisterFunction,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "selector",
+						Usage:    "4-byte function selector",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "approve-token",
+				Usage:  "Allow a spender to transfer tokens from an account",
+				Action: approveToken,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account granting the allowance",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "ERC-20 token contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "spender",
+						Usage:    "Spender address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Allowance in tokens",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "unlimited",
+						Usage:    "Approve the maximum amount (2^256-1)",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for the transaction to be mined",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for the transaction",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+				},
+			},
+			{
+				Name:   "check-allowance",
+				Usage:  "Show how many tokens a spender may transfer from an account",
+				Action: checkAllowance,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Account index of the token owner",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "ERC-20 token contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "spender",
+						Usage:    "Spender address or ENS name",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "tx-urgency",
+				Usage:  "Score how urgently a signed transaction needs a higher gas price",
+				Action: txUrgency,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx-hex",
+						Usage:    "Signed raw transaction in hex",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "gas-price",
+						Usage:    "Gas price to evaluate in gwei (default: the transaction's max fee)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "transfer-from",
+				Usage:  "Transfer tokens from an owner who approved the account",
+				Action: transferFrom,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from-index",
+						Usage:    "Index of the approved spender account that signs",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "owner",
+						Usage:    "Token owner address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Recipient address or ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "ERC-20 token contract address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "Amount of tokens to transfer",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for the transaction to be mined",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for the transaction",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+				},
+			},
+			{
+				Name:  "portfolio",
+				Usage: "Track tokens with labels and show their value per account",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "add",
+						Usage:  "Track a token in the portfolio",
+						Action: portfolioAdd,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "token-address",
+								Usage:    "Token address",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "label",
+								Usage:    "Name to show for the token",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:     "index",
+								Usage:    "Track the token only for this account (default: every account)",
+								Required: false,
+							},
+						},
+					},
+					{
+						Name:   "remove",
+						Usage:  "Stop tracking a token",
+						Action: portfolioRemove,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "token-address",
+								Usage:    "Token address",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:     "index",
+								Usage:    "Remove the entry of this account (default: the entry for every account)",
+								Required: false,
+							},
+						},
+					},
+					{
+						Name:   "show",
+						Usage:  "Show the balances and USD value of the tracked tokens",
+						Action: portfolioShow,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:     "index",
+								Usage:    "Account index",
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:   "batch-transfer-eth",
+				Usage:  "Send ETH to every recipient of a CSV file",
+				Action: batchTransferEth,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account to send from",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "recipients-file",
+						Usage:    "CSV file of address,amount_eth lines",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "concurrency",
+						Usage:    "Maximum number of transactions sent at once",
+						Required: false,
+						Value:    5,
+					},
+					&cli.StringFlag{
+						Name:     "results-file",
+						Usage:    "CSV file to write the hash or error of every transfer to",
+						Required: false,
+						Value:    "batch-transfer-results.csv",
+					},
+					&cli.BoolFlag{
+						Name:     "eip1559",
+						Usage:    "Send EIP-1559 transactions (default: when the network has a base fee)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-fee-per-gas",
+						Usage:    "EIP-1559 max fee per gas in gwei (default: 2x base fee plus tip)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-priority-fee",
+						Usage:    "EIP-1559 priority fee in gwei (default: node suggestion)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "validator-apr",
+				Usage:  "Estimate a validator's APR from its recent attestation rewards",
+				Action: validatorAPR,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "validator-index",
+						Usage:    "Validator index or public key",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "epochs",
+						Usage:    "Number of recent epochs to measure (225 is about a day)",
+						Required: false,
+						Value:    225,
+					},
+				},
+			},
+			{
+				Name:   "batch-transfer-token",
+				Usage:  "Send an ERC-20 token to every recipient of a CSV file",
+				Action: batchTransferToken,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the account to send from",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-address",
+						Usage:    "ERC-20 token contract address",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "decimal",
+						Usage:    "Token decimal (detected from the token when omitted)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "recipients-file",
+						Usage:    "CSV file of address,amount lines",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "concurrency",
+						Usage:    "Maximum number of transactions sent at once",
+						Required: false,
+						Value:    5,
+					},
+					&cli.StringFlag{
+						Name:     "results-file",
+						Usage:    "CSV file to write the hash or error of every transfer to",
+						Required: false,
+						Value:    "batch-transfer-results.csv",
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for every transfer to be mined and report the gas spent",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for each transfer",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+					&cli.BoolFlag{
+						Name:     "continue-on-insufficient",
+						Usage:    "Skip the transfers the token balance cannot cover instead of sending none",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "eip1559",
+						Usage:    "Send EIP-1559 transactions (default: when the network has a base fee)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-fee-per-gas",
+						Usage:    "EIP-1559 max fee per gas in gwei (default: 2x base fee plus tip)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "max-priority-fee",
+						Usage:    "EIP-1559 priority fee in gwei (default: node suggestion)",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "analyze-calldata",
+				Usage:  "Break down the gas cost of calldata by zero and non-zero bytes",
+				Action: analyzeCalldata,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "data",
+						Usage:    "Calldata in hex",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "abi",
+						Usage:    "ABI file to encode the call with instead of --data",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "method",
+						Usage:    "Method to encode",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "args",
+						Usage:    "Method arguments as a JSON array",
+						Required: false,
+						Value:    "[]",
+					},
+				},
 			},
 			{
-				Name:   "list-accounts",
-				Usage:  "List all Ethereum accounts",
-				Action: listAccounts,
+				Name:   "send-raw-tx",
+				Usage:  "Broadcast a signed raw transaction",
+				Action: sendRawTx,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx",
+						Usage:    "Signed transaction in hex",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:     "decode",
+						Usage:    "Print the transaction fields before broadcasting",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "skip-chain-id-check",
+						Usage:    "Send even if the transaction is signed for another chain",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "wait",
+						Usage:    "Wait for the transaction to be mined",
+						Required: false,
+					},
+					&cli.DurationFlag{
+						Name:     "timeout",
+						Usage:    "How long --wait waits for the transaction",
+						Required: false,
+						Value:    5 * time.Minute,
+					},
+				},
 			},
 			{
-				Name:   "check-balance",
-				Usage:  "Check ETH and token balances",
-				Action: checkBalance,
+				Name:   "ens-set-contenthash",
+				Usage:  "Point an ENS name's content hash at an IPFS CID",
+				Action: ensSetContenthash,
 				Flags: []cli.Flag{
 					&cli.IntFlag{
-						Name:     "index",
-						Usage:    "Account index to check balance",
+						Name:     "from",
+						Usage:    "Index of the account managing the name",
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "token-address",
-						Usage:    "Token address",
+						Name:     "name",
+						Usage:    "ENS name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "ipfs-cid",
+						Usage:    "IPFS CID (CIDv0 Qm... or CIDv1)",
 						Required: true,
 					},
+				},
+			},
+			{
+				Name:   "ens-get-contenthash",
+				Usage:  "Show the decoded content hash of an ENS name",
+				Action: ensGetContenthash,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "ENS name",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "sign-message",
+				Usage:  "Sign a message with the personal_sign (EIP-191) prefix",
+				Action: signMessage,
+				Flags: []cli.Flag{
 					&cli.IntFlag{
-						Name:     "decimal",
-						Usage:    "Token decimal",
+						Name:     "index",
+						Usage:    "Index of the signing account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "message",
+						Usage:    "Message text to sign",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "message-file",
+						Usage:    "File whose raw bytes are signed instead of --message",
 						Required: false,
-						Value:    6,
 					},
 				},
 			},
 			{
-				Name:   "transfer-eth",
-				Usage:  "Transfer ETH to another address",
-				Action: transferEth,
+				Name:   "verify-message",
+				Usage:  "Check that a personal_sign signature was made by an address",
+				Action: verifyMessage,
 				Flags: []cli.Flag{
-					&cli.IntFlag{
-						Name:     "from",
-						Usage:    "Index of the sending account",
+					&cli.StringFlag{
+						Name:     "message",
+						Usage:    "Signed message text",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "message-file",
+						Usage:    "File whose raw bytes were signed instead of --message",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "signature",
+						Usage:    "65-byte signature in hex",
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "to",
-						Usage:    "Recipient address",
+						Name:     "expected-address",
+						Usage:    "Address expected to have signed",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "balancer-pool-info",
+				Usage:  "Show the tokens, balances, weights and prices of a Balancer V2 pool",
+				Action: balancerPoolInfo,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "pool-id",
+						Usage:    "Balancer pool ID (bytes32)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "balancer-price-impact",
+				Usage:  "Calculate the price impact of a swap in a Balancer V2 weighted pool",
+				Action: balancerPriceImpact,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "pool-id",
+						Usage:    "Balancer pool ID (bytes32)",
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:     "token-in",
+						Usage:    "Address of the token sold",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token-out",
+						Usage:    "Address of the token bought (default: the first other token of the pool)",
+						Required: false,
+					},
 					&cli.Float64Flag{
 						Name:     "amount",
-						Usage:    "Amount of ETH to transfer",
+						Usage:    "Amount of token in, in whole tokens",
 						Required: true,
 					},
 				},
 			},
 			{
-				Name:   "transfer-token",
-				Usage:  "Transfer tokens to another address",
-				Action: transferToken,
+				Name:   "sign-typed-data",
+				Usage:  "Sign EIP-712 typed data and print the r, s and v components",
+				Action: signTypedData,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "index",
+						Usage:    "Index of the signing account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "domain-file",
+						Usage:    "JSON file with the EIP-712 domain (name, version, chainId, verifyingContract, salt)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "message-file",
+						Usage:    "JSON file with the types, primaryType and message to sign",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "deploy-upgradeable",
+				Usage:  "Deploy an implementation behind an ERC-1967 transparent upgradeable proxy",
+				Action: deployUpgradeable,
 				Flags: []cli.Flag{
 					&cli.IntFlag{
 						Name:     "from",
-						Usage:    "Index of the sending account",
+						Usage:    "Index of the deploying account",
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "to",
-						Usage:    "Recipient address",
+						Name:     "implementation",
+						Usage:    "Implementation init code as hex, or a file containing it",
 						Required: true,
 					},
-					&cli.Float64Flag{
-						Name:     "amount",
-						Usage:    "Amount of tokens to transfer",
+					&cli.StringFlag{
+						Name:     "admin",
+						Usage:    "Proxy admin: a ProxyAdmin contract or an account (default: a new ProxyAdmin owned by --from)",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "init-calldata",
+						Usage:    "Initializer calldata run against the implementation in the proxy's context",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "upgrade-proxy",
+				Usage:  "Upgrade a transparent proxy through its admin",
+				Action: upgradeProxy,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "from",
+						Usage:    "Index of the admin account or the ProxyAdmin owner",
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "token-address",
-						Usage:    "Token address",
+						Name:     "proxy",
+						Usage:    "Proxy address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "new-implementation",
+						Usage:    "Address of the deployed new implementation",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "migrate-calldata",
+						Usage:    "Calldata run against the new implementation after the upgrade",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "decode-tx",
+				Usage:  "Decode a signed raw transaction into readable fields",
+				Action: decodeTx,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "tx",
+						Usage:    "Signed transaction as hex",
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:     "token-abi",
+						Usage:    "ABI file used to decode the calldata",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "json",
+						Usage:    "Print the transaction as JSON, with amounts in wei",
+						Required: false,
+					},
+				},
+			},
+			{
+				Name:   "delegate-votes",
+				Usage:  "Delegate the voting power of an ERC20Votes token",
+				Action: delegateVotes,
+				Flags: []cli.Flag{
 					&cli.IntFlag{
-						Name:     "decimal",
-						Usage:    "Token decimal",
+						Name:     "from",
+						Usage:    "Index of the token holder's account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "token",
+						Usage:    "ERC20Votes token address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "delegatee",
+						Usage:    "Address or ENS name receiving the votes; use your own address to self-delegate",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "check-voting-power",
+				Usage:  "Show the current and historical voting power of an address",
+				Action: checkVotingPower,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token",
+						Usage:    "ERC20Votes token address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address to check",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:     "block",
+						Usage:    "Past block (or timestamp, for timestamp-clock tokens) to check",
 						Required: false,
-						Value:    6,
+					},
+				},
+			},
+			{
+				Name:   "delegates",
+				Usage:  "Show the current delegatee of an address",
+				Action: showDelegates,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token",
+						Usage:    "ERC20Votes token address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Delegating address",
+						Required: true,
 					},
 				},
 			},
@@ -174,31 +3112,68 @@ func listAccounts(c *cli.Context) error {
 // FormatBigIntToDecimal converts a big.Int amount (in Wei) to a human-readable format
 // based on the provided number of decimals (e.g., 18 for Ether).
 func formatBigIntToDecimal(amount *big.Int, decimals int) string {
-	// Create a big float from the big.Int amount
-	amountFloat := new(big.Float).SetInt(amount)
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	// Split into whole and fractional parts with integer arithmetic so that
+	// large balances keep every digit
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, fraction := new(big.Int).QuoRem(new(big.Int).Abs(amount), divisor, new(big.Int))
 
-	// Create a divisor based on the token's decimals (e.g., 10^18 for Ether)
-	divisor := new(big.Float).SetFloat64(float64(1))
-	divisor.Mul(divisor, new(big.Float).SetFloat64(float64(1e18))) // For Ether or token with 18 decimals
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%s.%0*s", sign, whole.String(), decimals, fraction.String())
+}
 
-	// Adjust the divisor for custom token decimals
-	if decimals != 18 {
-		// For token decimals other than 18
-		divisor.SetFloat64(1)
-		divisor = divisor.Mul(divisor, new(big.Float).SetFloat64(float64(10^decimals)))
+// parseAmount parses a human-readable decimal amount such as "100.5" into
+// base units exactly, rejecting more fractional digits than decimals allows.
+func parseAmount(amount string, decimals int) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %s has more than %d decimal places", amount, decimals)
+	}
+	if whole == "" {
+		whole = "0"
 	}
 
-	// Divide amount by the divisor to get the human-readable amount
-	humanReadable := new(big.Float).Quo(amountFloat, divisor)
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	if strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	parsed, ok := new(big.Int).SetString(digits, 10)
+	if !ok || parsed.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	return parsed, nil
+}
 
-	// Convert the result to a string and return it
-	return humanReadable.Text('f', decimals)
+// parseUint256 parses a decimal or 0x-prefixed hex string into an unsigned
+// 256-bit integer, such as a token ID.
+func parseUint256(value string) (*big.Int, error) {
+	parsed, ok := new(big.Int).SetString(value, 0)
+	if !ok || parsed.Sign() < 0 || parsed.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid uint256 value: %s", value)
+	}
+	return parsed, nil
 }
 
 func checkBalance(c *cli.Context) error {
+	if c.Bool("all-accounts") || c.IsSet("token-addresses") {
+		return checkBalances(c)
+	}
+	if !c.IsSet("index") || !c.IsSet("token-address") {
+		return fmt.Errorf("--index and --token-address are required unless --all-accounts or --token-addresses is given")
+	}
+
 	index := c.Int("index")
-	tokenAddress := c.String("token-address")
-	decimal := c.Int("decimal")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
 
 	keyStore := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
 	accounts := keyStore.Accounts()
@@ -207,26 +3182,44 @@ func checkBalance(c *cli.Context) error {
 		return fmt.Errorf("invalid account index")
 	}
 
-	client, err := ethclient.Dial(ethNodeURL)
+	client, err := dialClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
 	}
 
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	symbol, decimal, err := tokenDisplayInfo(c, client, chainID.Int64(), tokenAddress)
+	if err != nil {
+		return err
+	}
+	if symbol != "" {
+		symbol = " " + symbol
+	}
+
 	ethAddress := accounts[index].Address
 
+	exchanges, err := loadExchangeDatabase()
+	if err != nil {
+		return err
+	}
+	note := exchangeNote(exchanges, ethAddress)
+
 	// Check ETH balance
 	ethBalance, err := client.BalanceAt(context.Background(), ethAddress, nil)
 	if err != nil {
 		return fmt.Errorf("failed to get ETH balance: %w", err)
 	}
-	fmt.Printf("ETH Balance of %s: %s\n", ethAddress.Hex(), formatBigIntToDecimal(ethBalance, 18))
+	fmt.Printf("ETH Balance of %s%s: %s\n", ethAddress.Hex(), note, formatBigIntToDecimal(ethBalance, 18))
 
 	// Check token balance
-	tokenBalance, err := getTokenBalance(client, tokenAddress, decimal, ethAddress)
+	tokenBalance, err := getTokenBalance(client, tokenAddress.Hex(), decimal, ethAddress)
 	if err != nil {
 		return fmt.Errorf("failed to get token balance: %w", err)
 	}
-	fmt.Printf("Token Balance of %s: %s\n", ethAddress.Hex(), formatBigIntToDecimal(tokenBalance, decimal))
+	fmt.Printf("Token Balance of %s%s: %s%s\n", ethAddress.Hex(), note, formatBigIntToDecimal(tokenBalance, decimal), symbol)
 
 	return nil
 }
@@ -246,98 +3239,120 @@ func getTokenBalance(client *ethclient.Client, tokenAddress string, decimal int,
 
 func transferEth(c *cli.Context) error {
 	fromIndex := c.Int("from")
-	toAddress := c.String("to")
-	amount := c.Float64("amount")
 
-	client, err := ethclient.Dial(ethNodeURL)
+	value, err := parseAmount(c.String("amount"), 18)
 	if err != nil {
-		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+		return err
 	}
 
-	keyStore := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
-	accounts := keyStore.Accounts()
-
-	if fromIndex < 0 || fromIndex >= len(accounts) {
-		return fmt.Errorf("invalid sender account index")
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
 	}
 
-	// Load the account from keystore
-	account := accounts[fromIndex]
+	toAddress, err := resolveAddress(context.Background(), client, c.String("to"))
+	if err != nil {
+		return err
+	}
 
-	// Unlock the account
-	err = keyStore.Unlock(account, keystorePassword)
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
 	if err != nil {
-		return fmt.Errorf("failed to unlock account: %w", err)
+		return err
 	}
 
 	// Create transaction
-	value := big.NewInt(int64(amount * 1e18)) // Convert ETH to Wei
 	nonce, err := client.PendingNonceAt(context.Background(), account.Address)
 	if err != nil {
 		return fmt.Errorf("failed to get nonce: %w", err)
 	}
 
 	gasLimit := uint64(21000) // Gas limit for ETH transfer
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	tx, err := buildTransaction(c, client, nonce, toAddress, value, gasLimit, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
+		return err
 	}
 
-	tx := types.NewTransaction(nonce, common.HexToAddress(toAddress), value, gasLimit, gasPrice, nil)
-
 	// Sign transaction
-	signedTx, err := keyStore.SignTx(account, tx, &chainId)
+	signedTx, err := signTransaction(keyStore, account, tx)
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+		return err
 	}
 
 	// Send transaction
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		return fmt.Errorf("failed to send transaction: %w", err)
+	if err := broadcastTransaction(client, signedTx); err != nil {
+		return err
 	}
 
 	fmt.Printf("Transaction sent: %s\n", signedTx.Hash().Hex())
+	if c.Bool("wait") {
+		if _, err := waitMined(client, signedTx, c.Duration("timeout")); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func transferToken(c *cli.Context) error {
 	fromIndex := c.Int("from")
-	toAddress := c.String("to")
-	amount := c.Float64("amount")
-	tokenAddress := c.String("token-address")
-	decimal := c.Int("decimal")
+	amount := c.String("amount")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
 
-	client, err := ethclient.Dial(ethNodeURL)
+	client, err := dialClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
 	}
 
-	keyStore := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
-	accounts := keyStore.Accounts()
+	toAddress, err := resolveAddress(context.Background(), client, c.String("to"))
+	if err != nil {
+		return err
+	}
 
-	if fromIndex < 0 || fromIndex >= len(accounts) {
-		return fmt.Errorf("invalid sender account index")
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
 	}
 
-	// Load the account from keystore
-	account := accounts[fromIndex]
+	reason, err := scamReason(tokenAddress)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		fmt.Printf("Warning: %s is flagged as a scam token: %s\n", tokenAddress.Hex(), reason)
+		if !c.Bool("bypass-scam-check") {
+			return fmt.Errorf("refusing to transfer a flagged token without --bypass-scam-check")
+		}
+	}
 
-	// Unlock the account
-	err = keyStore.Unlock(account, keystorePassword)
+	decimal, err := decimalsFlag(c, client, tokenAddress)
 	if err != nil {
-		return fmt.Errorf("failed to unlock account: %w", err)
+		return err
 	}
 
 	// Create token contract instance
-	tokenContract, err := Token.ERCToken(tokenAddress, decimal, client)
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
 	if err != nil {
 		return fmt.Errorf("failed to create token contract: %w", err)
 	}
 
-	// Calculate the amount in Wei
-	amountInWei := new(big.Int)
-	amountInWei.SetString(fmt.Sprintf("%f", amount*math.Pow10(int(decimal))), 10)
+	// Calculate the amount in the token's base units
+	amountInWei, err := parseAmount(amount, decimal)
+	if err != nil {
+		return err
+	}
+
+	// Warn before sending if the token keeps part of the transfer as a fee
+	received, err := simulateTransferReceived(client, tokenContract, tokenAddress, account.Address, toAddress, amountInWei)
+	if err == nil && received.Cmp(amountInWei) < 0 {
+		fee := new(big.Int).Sub(amountInWei, received)
+		fmt.Printf("Warning: token charges a transfer fee of %s; recipient will receive %s\n",
+			formatBigIntToDecimal(fee, decimal), formatBigIntToDecimal(received, decimal))
+	}
 
 	nonce, err := client.PendingNonceAt(context.Background(), account.Address)
 	if err != nil {
@@ -345,30 +3360,32 @@ func transferToken(c *cli.Context) error {
 	}
 
 	gasLimit := uint64(60000) // Gas limit for token transfer
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	txData, err := tokenContract.ABI.Pack("transfer", toAddress, amountInWei)
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
+		return fmt.Errorf("failed to pack transfer data: %w", err)
 	}
 
-	txData, err := tokenContract.ABI.Pack("transfer", common.HexToAddress(toAddress), amountInWei)
+	tx, err := buildTransaction(c, client, nonce, tokenAddress, big.NewInt(0), gasLimit, txData)
 	if err != nil {
-		return fmt.Errorf("failed to pack transfer data: %w", err)
+		return err
 	}
 
-	tx := types.NewTransaction(nonce, common.HexToAddress(tokenAddress), big.NewInt(0), gasLimit, gasPrice, txData)
-
 	// Sign transaction
-	signedTx, err := keyStore.SignTx(account, tx, &chainId)
+	signedTx, err := signTransaction(keyStore, account, tx)
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+		return err
 	}
 
 	// Send transaction
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		return fmt.Errorf("failed to send transaction: %w", err)
+	if err := broadcastTransaction(client, signedTx); err != nil {
+		return err
 	}
 
 	fmt.Printf("Token transfer transaction sent: %s\n", signedTx.Hash().Hex())
+	if c.Bool("wait") {
+		if _, err := waitMined(client, signedTx, c.Duration("timeout")); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// bigInt parses a decimal test value, failing the test on bad input
+func bigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		t.Fatalf("invalid test value %q", value)
+	}
+	return parsed
+}
+
+func TestFormatBigIntToDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int
+		want     string
+	}{
+		{"USDC zero", "0", 6, "0.000000"},
+		{"USDC one base unit", "1", 6, "0.000001"},
+		{"USDC cents", "1230000", 6, "1.230000"},
+		{"USDC large supply", "45000000000000000", 6, "45000000000.000000"},
+		{"WBTC one satoshi", "1", 8, "0.00000001"},
+		{"WBTC one coin", "100000000", 8, "1.00000000"},
+		{"WBTC large", "2100000000000000", 8, "21000000.00000000"},
+		{"WETH one wei", "1", 18, "0.000000000000000001"},
+		{"WETH one gwei", "1000000000", 18, "0.000000001000000000"},
+		{"WETH fractional", "1500000000000000000", 18, "1.500000000000000000"},
+		{"WETH beyond float64 precision", "123456789123456789123456789", 18, "123456789.123456789123456789"},
+		{"WETH max uint256", "115792089237316195423570985008687907853269984665640564039457584007913129639935", 18,
+			"115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+		{"negative", "-1500000", 6, "-1.500000"},
+		{"no decimals", "42", 0, "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatBigIntToDecimal(bigInt(t, tt.amount), tt.decimals); got != tt.want {
+				t.Errorf("formatBigIntToDecimal(%s, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int
+		want     string // base units, empty when the amount is rejected
+	}{
+		{"one gwei of ETH", "0.000000001", 18, "1000000000"},
+		{"fractional ETH", "100.5", 18, "100500000000000000000"},
+		{"whole ETH", "2", 18, "2000000000000000000"},
+		{"leading dot", ".5", 18, "500000000000000000"},
+		{"trailing dot", "7.", 6, "7000000"},
+		{"USDC", "1234.567891", 6, "1234567891"},
+		{"max uint256", "115792089237316195423570985008687907853269984665640564039457.584007913129639935", 18,
+			"115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{"above max uint256", "115792089237316195423570985008687907853269984665640564039457.584007913129639936", 18, ""},
+		{"too many decimal places", "1.0000001", 6, ""},
+		{"decimals on a whole-unit token", "1.5", 0, ""},
+		{"negative", "-1", 18, ""},
+		{"negative fraction", "0.-1", 18, ""},
+		{"explicit plus", "+1", 18, ""},
+		{"non-numeric", "abc", 18, ""},
+		{"hex", "0x10", 18, ""},
+		{"two dots", "1.2.3", 18, ""},
+		{"exponent", "1e18", 18, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAmount(tt.amount, tt.decimals)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("parseAmount(%q, %d) = %s, want an error", tt.amount, tt.decimals, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAmount(%q, %d) failed: %v", tt.amount, tt.decimals, err)
+			}
+			if got.Cmp(bigInt(t, tt.want)) != 0 {
+				t.Errorf("parseAmount(%q, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// signPersonalMessage signs message with the EIP-191 personal_sign prefix and
+// returns the signature with an Ethereum-style V of 27 or 28.
+func signPersonalMessage(keyStore *keystore.KeyStore, account accounts.Account, message []byte) ([]byte, error) {
+	signature, err := signHash(keyStore, account, accounts.TextHash(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// recoverPersonalSigner returns the address that produced a personal_sign
+// signature of message. V may be given as 0/1 or 27/28.
+func recoverPersonalSigner(message, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+
+	sig := append([]byte{}, signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// messageInput returns the message given with --message, or the raw bytes of
+// --message-file
+func messageInput(c *cli.Context) ([]byte, error) {
+	if c.IsSet("message") == c.IsSet("message-file") {
+		return nil, fmt.Errorf("exactly one of --message and --message-file is required")
+	}
+	if c.IsSet("message") {
+		return []byte(c.String("message")), nil
+	}
+
+	message, err := os.ReadFile(c.String("message-file"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message file: %w", err)
+	}
+	return message, nil
+}
+
+func signMessage(c *cli.Context) error {
+	message, err := messageInput(c)
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("index"))
+	if err != nil {
+		return err
+	}
+
+	signature, err := signPersonalMessage(keyStore, account, message)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signer:    %s\n", account.Address.Hex())
+	fmt.Printf("Signature: %s\n", hexutil.Encode(signature))
+	return nil
+}
+
+func verifyMessage(c *cli.Context) error {
+	message, err := messageInput(c)
+	if err != nil {
+		return err
+	}
+
+	signature, err := hexutil.Decode(c.String("signature"))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	expected, err := parseAddress(c.String("expected-address"))
+	if err != nil {
+		return err
+	}
+
+	signer, err := recoverPersonalSigner(message, signature)
+	if err == nil && signer == expected {
+		fmt.Printf("Signature is valid, signed by %s\n", signer.Hex())
+		return nil
+	}
+
+	// Contract accounts such as Safes sign through ERC-1271
+	client, dialErr := dialClient()
+	if dialErr == nil {
+		code, codeErr := client.CodeAt(context.Background(), expected, nil)
+		if codeErr == nil && len(code) > 0 {
+			if isValidContractSignature(client, expected, common.BytesToHash(accounts.TextHash(message)), signature) {
+				fmt.Printf("Signature is valid for contract account %s (ERC-1271)\n", expected.Hex())
+				return nil
+			}
+			return fmt.Errorf("contract account %s rejected the signature", expected.Hex())
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("signature was made by %s, expected %s", signer.Hex(), expected.Hex())
+}
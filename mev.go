@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// relayPayload is a delivered payload as reported by the relay data API
+type relayPayload struct {
+	Slot          string `json:"slot"`
+	BlockNumber   string `json:"block_number"`
+	BlockHash     string `json:"block_hash"`
+	BuilderPubkey string `json:"builder_pubkey"`
+	Value         string `json:"value"`
+	NumTx         string `json:"num_tx"`
+}
+
+func mevRelayStats(c *cli.Context) error {
+	relayURL := strings.TrimRight(c.String("relay-url"), "/")
+	builderPubkey := c.String("builder-pubkey")
+	limit := c.Int("limit")
+
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(limit))
+	if builderPubkey != "" {
+		query.Set("builder_pubkey", builderPubkey)
+	}
+
+	var payloads []relayPayload
+	err := httpGetJSON(relayURL+"/relay/v1/data/bidtraces/proposer_payload_delivered?"+query.Encode(), &payloads)
+	if err != nil {
+		return err
+	}
+
+	if len(payloads) == 0 {
+		fmt.Println("No delivered payloads found.")
+		return nil
+	}
+
+	for _, payload := range payloads {
+		value, ok := new(big.Int).SetString(payload.Value, 10)
+		if !ok {
+			value = big.NewInt(0)
+		}
+		fmt.Printf("Block: %s, Slot: %s, Value: %s ETH, Transactions: %s, Builder: %s\n",
+			payload.BlockNumber, payload.Slot, formatBigIntToDecimal(value, 18), payload.NumTx, payload.BuilderPubkey)
+	}
+	return nil
+}
+
+func checkBundle(c *cli.Context) error {
+	relayURL := c.String("relay-url")
+	bundleHash := c.String("bundle-hash")
+	blockNumber := c.Uint64("block-number")
+	index := c.Int("index")
+
+	request, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "flashbots_getBundleStatsV2",
+		"params": []interface{}{map[string]interface{}{
+			"bundleHash":  bundleHash,
+			"blockNumber": hexutil.EncodeUint64(blockNumber),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	// Flashbots authenticates stats queries with a signature over the request
+	// body from the key that submitted the bundle
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, index)
+	if err != nil {
+		return err
+	}
+
+	digest := accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(request))))
+	signature, err := signHash(keyStore, account, digest)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, relayURL, bytes.NewReader(request))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Flashbots-Signature", account.Address.Hex()+":"+hexutil.Encode(signature))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query relay: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read relay response: %w", err)
+	}
+
+	var response struct {
+		Result *struct {
+			IsHighPriority         bool   `json:"isHighPriority"`
+			IsSimulated            bool   `json:"isSimulated"`
+			SimulatedAt            string `json:"simulatedAt"`
+			ReceivedAt             string `json:"receivedAt"`
+			ConsideredByBuildersAt []struct {
+				Pubkey    string `json:"pubkey"`
+				Timestamp string `json:"timestamp"`
+			} `json:"consideredByBuildersAt"`
+			SealedByBuildersAt []struct {
+				Pubkey    string `json:"pubkey"`
+				Timestamp string `json:"timestamp"`
+			} `json:"sealedByBuildersAt"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to decode relay response (%s): %w", resp.Status, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("relay returned an error: %s", response.Error.Message)
+	}
+	if response.Result == nil {
+		return fmt.Errorf("relay returned no bundle stats")
+	}
+
+	stats := response.Result
+	fmt.Printf("Received at:   %s\n", stats.ReceivedAt)
+	fmt.Printf("Simulated:     %t %s\n", stats.IsSimulated, stats.SimulatedAt)
+	fmt.Printf("High priority: %t\n", stats.IsHighPriority)
+	fmt.Printf("Considered by %d builders\n", len(stats.ConsideredByBuildersAt))
+	fmt.Printf("Sealed by %d builders\n", len(stats.SealedByBuildersAt))
+	for _, builder := range stats.SealedByBuildersAt {
+		fmt.Printf("  %s at %s\n", builder.Pubkey, builder.Timestamp)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCheckBundleSignsRequest(t *testing.T) {
+	chain := newTestChain(t, 2, nil)
+	signer := chain.accounts[1]
+
+	var header string
+	var body []byte
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Flashbots-Signature")
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": {"isSimulated": true}}`))
+	}))
+	defer relay.Close()
+
+	set := flag.NewFlagSet("check-bundle", flag.ContinueOnError)
+	set.String("relay-url", relay.URL, "")
+	set.String("bundle-hash", "0x01", "")
+	set.Uint64("block-number", 100, "")
+	set.Int("index", 1, "")
+	if err := checkBundle(cli.NewContext(cli.NewApp(), set, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	address, signatureHex, ok := strings.Cut(header, ":")
+	if !ok || common.HexToAddress(address) != signer.Address {
+		t.Fatalf("X-Flashbots-Signature = %q, want it signed by %s", header, signer.Address.Hex())
+	}
+	signature, err := hexutil.Decode(signatureHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(body))))
+	publicKey, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered := crypto.PubkeyToAddress(*publicKey); recovered != signer.Address {
+		t.Errorf("signature recovers to %s, want %s", recovered.Hex(), signer.Address.Hex())
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// Migration contracts are not standardised; these are the most common names
+// for the rate and the entry point. Rates are new tokens per old token,
+// scaled by 1e18.
+const tokenMigrationABI = `[
+  {
+    "inputs": [],
+    "name": "getMigrationRate",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "migrationRate",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "totalMigrated",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "migrate",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var tokenMigration = mustParseABI(tokenMigrationABI)
+
+// migrationRate returns the rate reported by the migration contract, or 1:1
+// when it exposes neither known rate function.
+func migrationRate(client *ethclient.Client, migrationContract common.Address) (*big.Int, bool) {
+	for _, method := range []string{"getMigrationRate", "migrationRate"} {
+		result, err := callContract(client, migrationContract, tokenMigration, method)
+		if err == nil {
+			return result[0].(*big.Int), true
+		}
+	}
+	return big.NewInt(1e18), false
+}
+
+func tokenMigrate(c *cli.Context) error {
+	fromIndex := c.Int("from-index")
+
+	oldTokenAddress, err := parseAddress(c.String("old-token"))
+	if err != nil {
+		return err
+	}
+	newTokenAddress, err := parseAddress(c.String("new-token"))
+	if err != nil {
+		return err
+	}
+	migrationContract, err := parseAddress(c.String("migration-contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	oldToken, err := Token.ERCToken(oldTokenAddress.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	newToken, err := Token.ERCToken(newTokenAddress.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+
+	oldDecimals, err := oldToken.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get old token decimals: %w", err)
+	}
+	newDecimals, err := newToken.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get new token decimals: %w", err)
+	}
+
+	balance, err := oldToken.BalanceOf(account.Address.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get old token balance: %w", err)
+	}
+	if balance.Sign() == 0 {
+		return fmt.Errorf("account %s holds no old tokens", account.Address.Hex())
+	}
+
+	rate, known := migrationRate(client, migrationContract)
+	if !known {
+		fmt.Println("Migration contract does not report a rate, assuming 1:1")
+	}
+
+	// Convert between the two tokens' units before applying the rate
+	expected := new(big.Int).Mul(balance, rate)
+	expected.Div(expected, big.NewInt(1e18))
+	expected.Mul(expected, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(newDecimals)), nil))
+	expected.Div(expected, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(oldDecimals)), nil))
+
+	fmt.Printf("Old token balance: %s\n", formatBigIntToDecimal(balance, int(oldDecimals)))
+	fmt.Printf("Expected new tokens: %s\n", formatBigIntToDecimal(expected, int(newDecimals)))
+
+	approveData, err := oldToken.ABI.Pack("approve", migrationContract, balance)
+	if err != nil {
+		return fmt.Errorf("failed to pack approve data: %w", err)
+	}
+
+	approveTx, err := sendTransaction(client, keyStore, account, oldTokenAddress, big.NewInt(0), approveData)
+	if err != nil {
+		return fmt.Errorf("failed to approve migration contract: %w", err)
+	}
+	fmt.Printf("Approval transaction sent: %s\n", approveTx.Hash().Hex())
+
+	if _, err := waitForSuccess(client, approveTx); err != nil {
+		return fmt.Errorf("approval failed: %w", err)
+	}
+
+	migrateData, err := tokenMigration.Pack("migrate")
+	if err != nil {
+		return fmt.Errorf("failed to pack migrate data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, migrationContract, big.NewInt(0), migrateData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migration transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
+
+func checkMigrationStatus(c *cli.Context) error {
+	oldTokenAddress, err := parseAddress(c.String("old-token"))
+	if err != nil {
+		return err
+	}
+	newTokenAddress, err := parseAddress(c.String("new-token"))
+	if err != nil {
+		return err
+	}
+	migrationContract, err := parseAddress(c.String("migration-contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	oldToken, err := Token.ERCToken(oldTokenAddress.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	newToken, err := Token.ERCToken(newTokenAddress.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+
+	oldDecimals, err := oldToken.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get old token decimals: %w", err)
+	}
+	newDecimals, err := newToken.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get new token decimals: %w", err)
+	}
+
+	oldSupply, err := oldToken.TotalSupply()
+	if err != nil {
+		return fmt.Errorf("failed to get old token supply: %w", err)
+	}
+
+	// Prefer the contract's own counter; otherwise count the old tokens it holds
+	var migrated *big.Int
+	if result, err := callContract(client, migrationContract, tokenMigration, "totalMigrated"); err == nil {
+		migrated = result[0].(*big.Int)
+	} else {
+		migrated, err = oldToken.BalanceOf(migrationContract.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to get migrated balance: %w", err)
+		}
+	}
+
+	remaining, err := newToken.BalanceOf(migrationContract.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get new token reserve: %w", err)
+	}
+
+	fmt.Printf("Old token supply:      %s\n", formatBigIntToDecimal(oldSupply, int(oldDecimals)))
+	fmt.Printf("Migrated:              %s\n", formatBigIntToDecimal(migrated, int(oldDecimals)))
+	if oldSupply.Sign() > 0 {
+		progress, _ := new(big.Float).Quo(new(big.Float).SetInt(migrated), new(big.Float).SetInt(oldSupply)).Float64()
+		fmt.Printf("Progress:              %.2f%%\n", progress*100)
+	}
+	fmt.Printf("New tokens in reserve: %s\n", formatBigIntToDecimal(remaining, int(newDecimals)))
+
+	if rate, known := migrationRate(client, migrationContract); known {
+		fmt.Printf("Migration rate:        %s\n", formatBigIntToDecimal(rate, 18))
+	}
+	return nil
+}
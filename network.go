@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func networkInfo(c *cli.Context) error {
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	id, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	fmt.Printf("Network:      %s\n", network)
+	fmt.Printf("Chain ID:     %s\n", id.String())
+	fmt.Printf("Latest block: %s\n", header.Number.String())
+	fmt.Printf("Gas price:    %s gwei\n", formatBigIntToDecimal(gasPrice, 9))
+	if header.BaseFee != nil {
+		fmt.Printf("Base fee:     %s gwei\n", formatBigIntToDecimal(header.BaseFee, 9))
+	}
+
+	if isArbitrumChain(id) {
+		fmt.Println()
+		if err := printArbitrumInfo(client); err != nil {
+			return fmt.Errorf("failed to query Arbitrum precompiles: %w", err)
+		}
+	}
+
+	if isOptimismChain(id) {
+		fmt.Println()
+		if err := printOptimismInfo(); err != nil {
+			return fmt.Errorf("failed to query SystemConfig: %w", err)
+		}
+	}
+
+	return nil
+}
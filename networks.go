@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// networkPreset describes a network known to the CLI
+type networkPreset struct {
+	infuraName   string
+	alchemyName  string
+	chainID      int64
+	shortName    string // EIP-3770 chain short name
+	nativeSymbol string
+}
+
+// networkPresets maps the names accepted by --network to their Infura and
+// Alchemy endpoints, chain ID, EIP-3770 short name and native currency.
+var networkPresets = map[string]networkPreset{
+	"mainnet":  {infuraName: "mainnet", alchemyName: "eth-mainnet", chainID: 1, shortName: "eth", nativeSymbol: "ETH"},
+	"sepolia":  {infuraName: "sepolia", alchemyName: "eth-sepolia", chainID: 11155111, shortName: "sep", nativeSymbol: "ETH"},
+	"holesky":  {infuraName: "holesky", alchemyName: "eth-holesky", chainID: 17000, shortName: "holesky", nativeSymbol: "ETH"},
+	"arbitrum": {infuraName: "arbitrum-mainnet", alchemyName: "arb-mainnet", chainID: 42161, shortName: "arb1", nativeSymbol: "ETH"},
+	"optimism": {infuraName: "optimism-mainnet", alchemyName: "opt-mainnet", chainID: 10, shortName: "oeth", nativeSymbol: "ETH"},
+	"polygon":  {infuraName: "polygon-mainnet", alchemyName: "polygon-mainnet", chainID: 137, shortName: "pol", nativeSymbol: "POL"},
+	"base":     {infuraName: "base-mainnet", alchemyName: "base-mainnet", chainID: 8453, shortName: "base", nativeSymbol: "ETH"},
+	"bsc":      {infuraName: "bsc-mainnet", alchemyName: "bnb-mainnet", chainID: 56, shortName: "bnb", nativeSymbol: "BNB"},
+}
+
+// infuraURL returns the Infura endpoint for a network. Names without a preset
+// are used as the Infura subdomain verbatim.
+func infuraURL(network string) string {
+	name := network
+	if preset, ok := networkPresets[network]; ok {
+		name = preset.infuraName
+	}
+	return fmt.Sprintf("https://%s.infura.io/v3/%s", name, infuraKey)
+}
+
+// resolveChainID returns the chain ID to sign transactions with: the
+// --chain-id flag when given, otherwise the ID reported by the node. Without
+// a reachable node it falls back to the network preset so that offline
+// commands keep working. The node is only asked the first time a command
+// needs the chain ID, with a single request and none of the retries of
+// dialClient, and the answer is kept in chainId for the rest of the command.
+func resolveChainID() (*big.Int, error) {
+	if chainId.Sign() != 0 {
+		return &chainId, nil
+	}
+
+	if endpoint, err := nodeURL(); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		defer cancel()
+		if client, err := ethclient.DialContext(ctx, endpoint); err == nil {
+			defer client.Close()
+			if id, err := client.ChainID(ctx); err == nil {
+				chainId.Set(id)
+				return &chainId, nil
+			}
+		}
+	}
+
+	if preset, ok := networkPresets[network]; ok {
+		chainId.SetInt64(preset.chainID)
+		return &chainId, nil
+	}
+	return nil, fmt.Errorf("could not determine the chain ID of %q, pass --chain-id", network)
+}
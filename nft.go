@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+
+	NFT "eth-manage/nft"
+)
+
+func checkNftBalance(c *cli.Context) error {
+	index := c.Int("index")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	owner := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	nftContract, err := NFT.NewERC721Token(contractAddress, client)
+	if err != nil {
+		return err
+	}
+
+	balance, err := nftContract.BalanceOf(owner)
+	if err != nil {
+		return fmt.Errorf("failed to get NFT balance: %w", err)
+	}
+
+	fmt.Printf("NFT Balance of %s: %s\n", owner.Hex(), balance.String())
+	return nil
+}
+
+func transferNft(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	tokenID, err := parseUint256(c.String("token-id"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	toAddress, err := resolveAddress(context.Background(), client, c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	nftContract, err := NFT.NewERC721Token(contractAddress, client)
+	if err != nil {
+		return err
+	}
+
+	owner, err := nftContract.OwnerOf(tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get owner of token %s: %w", tokenID.String(), err)
+	}
+	if owner != account.Address {
+		return fmt.Errorf("token %s is owned by %s, not %s", tokenID.String(), owner.Hex(), account.Address.Hex())
+	}
+
+	txData, err := nftContract.ABI.Pack("safeTransferFrom", account.Address, toAddress, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to pack safeTransferFrom data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, contractAddress, big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("NFT transfer transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
+
+func listNfts(c *cli.Context) error {
+	index := c.Int("index")
+	count := c.Int("count")
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	owner := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	nftContract, err := NFT.NewERC721Token(contractAddress, client)
+	if err != nil {
+		return err
+	}
+
+	balance, err := nftContract.BalanceOf(owner)
+	if err != nil {
+		return fmt.Errorf("failed to get NFT balance: %w", err)
+	}
+	fmt.Printf("%s owns %s tokens\n", owner.Hex(), balance.String())
+
+	total := balance.Int64()
+	if count > 0 && int64(count) < total {
+		total = int64(count)
+	}
+
+	for i := int64(0); i < total; i++ {
+		tokenID, err := nftContract.TokenOfOwnerByIndex(owner, big.NewInt(i))
+		if err != nil {
+			if i == 0 {
+				fmt.Println("The contract does not implement ERC721Enumerable, so owned tokens cannot be listed")
+				return nil
+			}
+			return fmt.Errorf("failed to get token at index %d: %w", i, err)
+		}
+
+		uri, err := nftContract.TokenURI(tokenID)
+		if err != nil {
+			fmt.Printf("%s\n", tokenID.String())
+			continue
+		}
+		fmt.Printf("%s\t%s\n", tokenID.String(), uri)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package nft
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+//go:embed abi.json
+var erc721ABI string
+
+// ERC721Token is an ERC-721 contract accessed through an ethclient
+type ERC721Token struct {
+	address common.Address
+	ABI     abi.ABI
+	client  *ethclient.Client
+}
+
+// NewERC721Token creates an ERC721Token for the contract at address
+func NewERC721Token(address common.Address, client *ethclient.Client) (*ERC721Token, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(erc721ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-721 ABI: %w", err)
+	}
+
+	return &ERC721Token{
+		address: address,
+		ABI:     parsedABI,
+		client:  client,
+	}, nil
+}
+
+// BalanceOf returns the number of tokens owned by owner
+func (t *ERC721Token) BalanceOf(owner common.Address) (*big.Int, error) {
+	result, err := t.call("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// OwnerOf returns the owner of a token
+func (t *ERC721Token) OwnerOf(tokenID *big.Int) (common.Address, error) {
+	result, err := t.call("ownerOf", tokenID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return result[0].(common.Address), nil
+}
+
+// TokenURI returns the metadata URI of a token
+func (t *ERC721Token) TokenURI(tokenID *big.Int) (string, error) {
+	result, err := t.call("tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+	return result[0].(string), nil
+}
+
+// TokenOfOwnerByIndex returns the index-th token owned by owner. Only
+// contracts implementing the enumerable extension support it.
+func (t *ERC721Token) TokenOfOwnerByIndex(owner common.Address, index *big.Int) (*big.Int, error) {
+	result, err := t.call("tokenOfOwnerByIndex", owner, index)
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// call packs a read-only contract call, executes it and unpacks the outputs
+func (t *ERC721Token) call(method string, args ...interface{}) ([]interface{}, error) {
+	data, err := t.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for %s: %w", method, err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &t.address,
+		Data: data,
+	}
+
+	result, err := t.client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	outputs, err := t.ABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+	return outputs, nil
+}
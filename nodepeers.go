@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// adminPeer is the part of an admin_peers entry that is printed. Protocol
+// details differ between clients, so they are decoded per protocol.
+type adminPeer struct {
+	Enode     string                     `json:"enode"`
+	Name      string                     `json:"name"`
+	Protocols map[string]json.RawMessage `json:"protocols"`
+}
+
+// peerProtocol is a protocol entry of admin_peers. Clients that report the
+// peer's network ID include it here; others only report the version.
+type peerProtocol struct {
+	Version *uint64 `json:"version"`
+	Network *uint64 `json:"network"`
+}
+
+func nodePeers(c *cli.Context) error {
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+	rpcClient := client.Client()
+
+	var count hexutil.Uint64
+	if err := rpcClient.CallContext(context.Background(), &count, "net_peerCount"); err != nil {
+		return fmt.Errorf("failed to get peer count: %w", err)
+	}
+	fmt.Printf("Peer count: %d\n", count)
+
+	// Peers that do not report their network ID are on the node's network
+	var networkID string
+	if err := rpcClient.CallContext(context.Background(), &networkID, "net_version"); err != nil {
+		return fmt.Errorf("failed to get network ID: %w", err)
+	}
+
+	var peers []adminPeer
+	if err := rpcClient.CallContext(context.Background(), &peers, "admin_peers"); err != nil {
+		fmt.Printf("Peer details unavailable, the node does not expose the admin namespace: %v\n", err)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENODE\tCLIENT\tNETWORK ID\tPROTOCOLS")
+	for _, peer := range peers {
+		peerNetwork := networkID
+		var protocols []string
+		for name, raw := range peer.Protocols {
+			var protocol peerProtocol
+			if json.Unmarshal(raw, &protocol) != nil || protocol.Version == nil {
+				// Handshake not finished
+				protocols = append(protocols, name)
+				continue
+			}
+			protocols = append(protocols, fmt.Sprintf("%s/%d", name, *protocol.Version))
+			if protocol.Network != nil {
+				peerNetwork = fmt.Sprint(*protocol.Network)
+			}
+		}
+		sort.Strings(protocols)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", peer.Enode, peer.Name, peerNetwork, strings.Join(protocols, ","))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// OP Mainnet contract addresses
+const (
+	opSystemConfigAddress           = "0x229047fed2591dbec1eF1118d64F7aF3dB9EB290" // L1
+	opPortalAddress                 = "0xbEb5Fc579115071764c7423A4f12eDde41f106Ed" // L1
+	opL2CrossDomainMessengerAddress = "0x4200000000000000000000000000000000000007"
+	opL2ToL1MessagePasserAddress    = "0x4200000000000000000000000000000000000016"
+)
+
+const opSystemConfigABI = `[
+  {
+    "inputs": [],
+    "name": "gasLimit",
+    "outputs": [{ "name": "", "type": "uint64" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "overhead",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "scalar",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "batcherHash",
+    "outputs": [{ "name": "", "type": "bytes32" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+const opCrossDomainMessengerABI = `[
+  {
+    "anonymous": false,
+    "inputs": [
+      { "indexed": true, "name": "target", "type": "address" },
+      { "indexed": false, "name": "sender", "type": "address" },
+      { "indexed": false, "name": "message", "type": "bytes" },
+      { "indexed": false, "name": "messageNonce", "type": "uint256" },
+      { "indexed": false, "name": "gasLimit", "type": "uint256" }
+    ],
+    "name": "SentMessage",
+    "type": "event"
+  }
+]`
+
+const opL2ToL1MessagePasserABI = `[
+  {
+    "anonymous": false,
+    "inputs": [
+      { "indexed": true, "name": "nonce", "type": "uint256" },
+      { "indexed": true, "name": "sender", "type": "address" },
+      { "indexed": true, "name": "target", "type": "address" },
+      { "indexed": false, "name": "value", "type": "uint256" },
+      { "indexed": false, "name": "gasLimit", "type": "uint256" },
+      { "indexed": false, "name": "data", "type": "bytes" },
+      { "indexed": false, "name": "withdrawalHash", "type": "bytes32" }
+    ],
+    "name": "MessagePassed",
+    "type": "event"
+  }
+]`
+
+const opPortalABI = `[
+  {
+    "inputs": [{ "name": "", "type": "bytes32" }],
+    "name": "finalizedWithdrawals",
+    "outputs": [{ "name": "", "type": "bool" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	opSystemConfig         = mustParseABI(opSystemConfigABI)
+	opCrossDomainMessenger = mustParseABI(opCrossDomainMessengerABI)
+	opL2ToL1MessagePasser  = mustParseABI(opL2ToL1MessagePasserABI)
+	opPortal               = mustParseABI(opPortalABI)
+)
+
+// isOptimismChain reports whether chainID is OP Mainnet
+func isOptimismChain(chainID *big.Int) bool {
+	return chainID.Cmp(big.NewInt(10)) == 0
+}
+
+// dialOptimismL1 connects to Ethereum mainnet, where the OP Stack system
+// contracts live.
+func dialOptimismL1() (*ethclient.Client, error) {
+	endpoint, err := providerURL("mainnet")
+	if err != nil {
+		return nil, err
+	}
+	client, err := ethclient.Dial(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the L1 client: %w", err)
+	}
+	return client, nil
+}
+
+// printOptimismInfo reports the rollup configuration stored in the L1
+// SystemConfig contract.
+func printOptimismInfo() error {
+	l1Client, err := dialOptimismL1()
+	if err != nil {
+		return err
+	}
+
+	systemConfig := common.HexToAddress(opSystemConfigAddress)
+
+	result, err := callContract(l1Client, systemConfig, opSystemConfig, "gasLimit")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("L2 gas limit:   %d\n", result[0].(uint64))
+
+	result, err = callContract(l1Client, systemConfig, opSystemConfig, "overhead")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Fee overhead:   %s\n", result[0].(*big.Int).String())
+
+	result, err = callContract(l1Client, systemConfig, opSystemConfig, "scalar")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Fee scalar:     0x%x\n", result[0].(*big.Int))
+
+	result, err = callContract(l1Client, systemConfig, opSystemConfig, "batcherHash")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Batcher hash:   %s\n", common.Hash(result[0].([32]byte)).Hex())
+	return nil
+}
+
+func opL2ToL1Message(c *cli.Context) error {
+	txHash := common.HexToHash(c.String("tx-hash"))
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	messenger := common.HexToAddress(opL2CrossDomainMessengerAddress)
+	passer := common.HexToAddress(opL2ToL1MessagePasserAddress)
+	sentMessage := opCrossDomainMessenger.Events["SentMessage"]
+	messagePassed := opL2ToL1MessagePasser.Events["MessagePassed"]
+
+	var withdrawalHashes []common.Hash
+	found := false
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		switch {
+		case log.Address == messenger && log.Topics[0] == sentMessage.ID:
+			fields, err := opCrossDomainMessenger.Unpack("SentMessage", log.Data)
+			if err != nil {
+				return fmt.Errorf("failed to decode SentMessage event: %w", err)
+			}
+			found = true
+			fmt.Printf("Target:        %s\n", common.BytesToAddress(log.Topics[1].Bytes()).Hex())
+			fmt.Printf("Sender:        %s\n", fields[0].(common.Address).Hex())
+			fmt.Printf("Message nonce: %s\n", fields[2].(*big.Int).String())
+			fmt.Printf("Gas limit:     %s\n", fields[3].(*big.Int).String())
+			fmt.Printf("Message:       0x%x\n", fields[1].([]byte))
+
+		case log.Address == passer && log.Topics[0] == messagePassed.ID:
+			fields, err := opL2ToL1MessagePasser.Unpack("MessagePassed", log.Data)
+			if err != nil {
+				return fmt.Errorf("failed to decode MessagePassed event: %w", err)
+			}
+			withdrawalHashes = append(withdrawalHashes, common.Hash(fields[3].([32]byte)))
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("no SentMessage event found in transaction %s", txHash.Hex())
+	}
+
+	l1Client, err := dialOptimismL1()
+	if err != nil {
+		return err
+	}
+
+	for _, withdrawalHash := range withdrawalHashes {
+		result, err := callContract(l1Client, common.HexToAddress(opPortalAddress), opPortal, "finalizedWithdrawals", withdrawalHash)
+		if err != nil {
+			return fmt.Errorf("failed to check withdrawal status: %w", err)
+		}
+
+		status := "not finalized (waiting to be proven or for the challenge period)"
+		if result[0].(bool) {
+			status = "finalized on L1"
+		}
+		fmt.Printf("Withdrawal %s: %s\n", withdrawalHash.Hex(), status)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// solc accepts at most 2^32-1 optimizer runs, which stands in for "infinite"
+const maxOptimizerRuns = math.MaxUint32
+
+// The benchmark function is estimated against runtime code placed here with
+// a state override
+var benchmarkAddress = common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+
+// solcContract is one contract of solc's --combined-json output. The ABI is a
+// JSON string in older compilers and a JSON array in newer ones.
+type solcContract struct {
+	ABI        json.RawMessage `json:"abi"`
+	Bin        string          `json:"bin"`
+	BinRuntime string          `json:"bin-runtime"`
+}
+
+// optimizerResult is the cost of one optimizer setting
+type optimizerResult struct {
+	runs          uint64
+	runtimeSize   int
+	deployGas     uint64
+	deployApprox  bool
+	callGas       uint64
+	totalExpected float64
+}
+
+// parseOptimizerRuns parses a comma-separated list of run counts, where ∞,
+// inf or max mean solc's maximum.
+func parseOptimizerRuns(value string) ([]uint64, error) {
+	var runs []uint64
+	for _, item := range splitList(value) {
+		switch strings.ToLower(item) {
+		case "∞", "inf", "infinity", "max":
+			runs = append(runs, maxOptimizerRuns)
+			continue
+		}
+		parsed, err := strconv.ParseUint(item, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid optimizer runs %q", item)
+		}
+		runs = append(runs, parsed)
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no optimizer runs given")
+	}
+	return runs, nil
+}
+
+// compileWithRuns runs solc with the optimizer set to runs and returns the
+// named contract, or the only deployable contract when name is empty.
+func compileWithRuns(source, name string, runs uint64) (solcContract, abi.ABI, error) {
+	out, err := exec.Command("solc", "--combined-json", "abi,bin,bin-runtime",
+		"--optimize", "--optimize-runs", strconv.FormatUint(runs, 10), source).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return solcContract{}, abi.ABI{}, fmt.Errorf("solc failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return solcContract{}, abi.ABI{}, fmt.Errorf("failed to run solc: %w", err)
+	}
+
+	var output struct {
+		Contracts map[string]solcContract `json:"contracts"`
+	}
+	if err := json.Unmarshal(out, &output); err != nil {
+		return solcContract{}, abi.ABI{}, fmt.Errorf("failed to parse solc output: %w", err)
+	}
+
+	var matches []string
+	for id, contract := range output.Contracts {
+		contractName := id[strings.LastIndex(id, ":")+1:]
+		if (name == "" && contract.Bin != "") || contractName == name {
+			matches = append(matches, id)
+		}
+	}
+	sort.Strings(matches)
+	if len(matches) != 1 {
+		return solcContract{}, abi.ABI{}, fmt.Errorf("select one contract with --contract, found %v", matches)
+	}
+
+	contract := output.Contracts[matches[0]]
+	definition := contract.ABI
+	var text string
+	if json.Unmarshal(definition, &text) == nil {
+		definition = json.RawMessage(text)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(definition)))
+	if err != nil {
+		return solcContract{}, abi.ABI{}, fmt.Errorf("failed to parse ABI of %s: %w", matches[0], err)
+	}
+	return contract, parsed, nil
+}
+
+// deploymentGasFloor is the gas a deployment costs before the constructor
+// runs: the creation transaction, the init code calldata and the code deposit.
+func deploymentGasFloor(initCode []byte, runtimeSize int) uint64 {
+	gas := uint64(53000) + uint64(200*runtimeSize)
+	for _, b := range initCode {
+		if b == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+	return gas
+}
+
+// estimateWithCode estimates a call to runtime code installed at
+// benchmarkAddress through an eth_estimateGas state override.
+func estimateWithCode(client *ethclient.Client, runtime, data []byte) (uint64, error) {
+	var gas hexutil.Uint64
+	err := client.Client().CallContext(context.Background(), &gas, "eth_estimateGas",
+		map[string]interface{}{
+			"to":   benchmarkAddress,
+			"data": hexutil.Bytes(data),
+		},
+		"latest",
+		map[common.Address]map[string]interface{}{
+			benchmarkAddress: {"code": hexutil.Bytes(runtime)},
+		},
+	)
+	return uint64(gas), err
+}
+
+func optimizeBytecodeCost(c *cli.Context) error {
+	source := c.String("source")
+	expectedCalls := c.Float64("expected-calls")
+
+	runsList, err := parseOptimizerRuns(c.String("optimizer-runs"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	var results []optimizerResult
+	for _, runs := range runsList {
+		contract, contractABI, err := compileWithRuns(source, c.String("contract"), runs)
+		if err != nil {
+			return err
+		}
+
+		initCode, err := hexutil.Decode("0x" + contract.Bin)
+		if err != nil {
+			return fmt.Errorf("invalid bytecode from solc: %w", err)
+		}
+		runtime, err := hexutil.Decode("0x" + contract.BinRuntime)
+		if err != nil {
+			return fmt.Errorf("invalid runtime bytecode from solc: %w", err)
+		}
+
+		result := optimizerResult{runs: runs, runtimeSize: len(runtime)}
+
+		// Constructors that need arguments cannot be estimated, so fall
+		// back to the cost before the constructor runs
+		result.deployGas, err = client.EstimateGas(context.Background(), ethereum.CallMsg{Data: initCode})
+		if err != nil {
+			result.deployGas = deploymentGasFloor(initCode, len(runtime))
+			result.deployApprox = true
+		}
+
+		if c.IsSet("function") {
+			method, ok := contractABI.Methods[c.String("function")]
+			if !ok {
+				return fmt.Errorf("function %s not found in the contract ABI", c.String("function"))
+			}
+
+			inputs := splitList(c.String("args"))
+			if len(inputs) != len(method.Inputs) {
+				return fmt.Errorf("%s takes %d arguments, got %d", method.Sig, len(method.Inputs), len(inputs))
+			}
+			args := make([]interface{}, len(inputs))
+			for i, input := range inputs {
+				args[i], err = parseABIArgument(method.Inputs[i].Type, input)
+				if err != nil {
+					return err
+				}
+			}
+
+			data, err := contractABI.Pack(method.Name, args...)
+			if err != nil {
+				return fmt.Errorf("failed to pack %s: %w", method.Sig, err)
+			}
+			result.callGas, err = estimateWithCode(client, runtime, data)
+			if err != nil {
+				return fmt.Errorf("failed to estimate %s with %d runs: %w", method.Sig, runs, err)
+			}
+		}
+
+		result.totalExpected = float64(result.deployGas) + expectedCalls*float64(result.callGas)
+		results = append(results, result)
+	}
+
+	best := results[0]
+	for _, result := range results[1:] {
+		if result.totalExpected < best.totalExpected {
+			best = result
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUNS\tRUNTIME SIZE\tDEPLOY GAS\tCALL GAS\tTOTAL GAS")
+	for _, result := range results {
+		deployGas := strconv.FormatUint(result.deployGas, 10)
+		if result.deployApprox {
+			deployGas = "≥" + deployGas
+		}
+		callGas := "-"
+		if c.IsSet("function") {
+			callGas = strconv.FormatUint(result.callGas, 10)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%.0f\n", formatOptimizerRuns(result.runs), result.runtimeSize, deployGas, callGas, result.totalExpected)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if c.IsSet("function") {
+		fmt.Printf("Recommended --optimizer-runs: %s (lowest deploy gas + %g calls)\n", formatOptimizerRuns(best.runs), expectedCalls)
+	} else {
+		fmt.Printf("Recommended --optimizer-runs: %s (lowest deploy gas; pass --function to weigh call costs)\n", formatOptimizerRuns(best.runs))
+	}
+	if results[0].deployApprox {
+		fmt.Println("≥: the constructor could not be simulated, so deploy gas excludes its execution")
+	}
+	return nil
+}
+
+// formatOptimizerRuns prints solc's maximum as ∞
+func formatOptimizerRuns(runs uint64) string {
+	if runs == maxOptimizerRuns {
+		return "∞"
+	}
+	return strconv.FormatUint(runs, 10)
+}
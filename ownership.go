@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const ownableABI = `[
+  {
+    "inputs": [],
+    "name": "owner",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var ownable = mustParseABI(ownableABI)
+
+var (
+	ownershipTransferredTopic = crypto.Keccak256Hash([]byte("OwnershipTransferred(address,address)"))
+	roleGrantedTopic          = crypto.Keccak256Hash([]byte("RoleGranted(bytes32,address,address)"))
+	roleRevokedTopic          = crypto.Keccak256Hash([]byte("RoleRevoked(bytes32,address,address)"))
+)
+
+// ownershipChange describes one detected owner or role change. It is written
+// to the log file and sent to the webhook as JSON.
+type ownershipChange struct {
+	Contract  common.Address `json:"contract"`
+	Kind      string         `json:"kind"`
+	Role      string         `json:"role,omitempty"`
+	Old       string         `json:"old,omitempty"`
+	New       string         `json:"new,omitempty"`
+	Account   string         `json:"account,omitempty"`
+	TxHash    string         `json:"txHash,omitempty"`
+	Block     uint64         `json:"block"`
+	Timestamp uint64         `json:"timestamp"`
+}
+
+// ownershipChangeFromLog decodes an OwnershipTransferred, RoleGranted or
+// RoleRevoked log. All of their parameters are indexed.
+func ownershipChangeFromLog(log types.Log) (ownershipChange, bool) {
+	change := ownershipChange{
+		Contract: log.Address,
+		TxHash:   log.TxHash.Hex(),
+		Block:    log.BlockNumber,
+	}
+	if len(log.Topics) < 3 {
+		return change, false
+	}
+
+	switch log.Topics[0] {
+	case ownershipTransferredTopic:
+		change.Kind = "OwnershipTransferred"
+		change.Old = common.BytesToAddress(log.Topics[1].Bytes()).Hex()
+		change.New = common.BytesToAddress(log.Topics[2].Bytes()).Hex()
+	case roleGrantedTopic:
+		change.Kind = "RoleGranted"
+		change.Role = log.Topics[1].Hex()
+		change.Account = common.BytesToAddress(log.Topics[2].Bytes()).Hex()
+	case roleRevokedTopic:
+		change.Kind = "RoleRevoked"
+		change.Role = log.Topics[1].Hex()
+		change.Account = common.BytesToAddress(log.Topics[2].Bytes()).Hex()
+	default:
+		return change, false
+	}
+	return change, true
+}
+
+func watchOwnership(c *cli.Context) error {
+	interval := time.Duration(c.Int("interval")) * time.Second
+	webhook := c.String("webhook")
+
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	logPath := c.String("log-file")
+	if logPath == "" {
+		logPath, err = dataFilePath("ownership.log")
+		if err != nil {
+			return err
+		}
+	}
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	lastBlock, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	// Contracts without owner() are still watched for AccessControl events
+	var currentOwner common.Address
+	isOwnable := false
+	if result, err := callContract(client, contractAddress, ownable, "owner"); err == nil {
+		currentOwner = result[0].(common.Address)
+		isOwnable = true
+		fmt.Printf("Current owner: %s\n", currentOwner.Hex())
+	}
+	fmt.Printf("Watching %s from block %d every %s (log: %s)\n", contractAddress.Hex(), lastBlock, interval, logPath)
+
+	times := newBlockTimes(client)
+	topics := [][]common.Hash{{ownershipTransferredTopic, roleGrantedTopic, roleRevokedTopic}}
+
+	for {
+		time.Sleep(interval)
+
+		latest, err := client.BlockNumber(context.Background())
+		if err != nil {
+			fmt.Printf("Failed to get latest block: %v\n", err)
+			continue
+		}
+		if latest <= lastBlock {
+			continue
+		}
+
+		logs, err := fetchLogs(client, []common.Address{contractAddress}, topics, lastBlock+1, latest)
+		if err != nil {
+			fmt.Printf("Failed to get logs: %v\n", err)
+			continue
+		}
+
+		var changes []ownershipChange
+		for _, log := range logs {
+			if change, ok := ownershipChangeFromLog(log); ok {
+				changes = append(changes, change)
+			}
+		}
+
+		// Catch owner changes made without the standard event
+		if isOwnable {
+			if result, err := callContract(client, contractAddress, ownable, "owner"); err == nil {
+				owner := result[0].(common.Address)
+				if owner != currentOwner {
+					hasEvent := false
+					for _, change := range changes {
+						hasEvent = hasEvent || change.Kind == "OwnershipTransferred"
+					}
+					if !hasEvent {
+						changes = append(changes, ownershipChange{
+							Contract: contractAddress,
+							Kind:     "OwnerChanged",
+							Old:      currentOwner.Hex(),
+							New:      owner.Hex(),
+							Block:    latest,
+						})
+					}
+					currentOwner = owner
+				}
+			}
+		}
+
+		for _, change := range changes {
+			if timestamp, err := times.get(change.Block); err == nil {
+				change.Timestamp = timestamp
+			}
+			reportOwnershipChange(change, logFile, webhook)
+		}
+		lastBlock = latest
+	}
+}
+
+// reportOwnershipChange prints a change, appends it to the log file and sends
+// it to the webhook if one is configured.
+func reportOwnershipChange(change ownershipChange, logFile *os.File, webhook string) {
+	when := time.Unix(int64(change.Timestamp), 0).UTC().Format(time.RFC3339)
+	switch change.Kind {
+	case "RoleGranted", "RoleRevoked":
+		fmt.Printf("[%s] block %d: %s role %s account %s (tx %s)\n", when, change.Block, change.Kind, change.Role, change.Account, change.TxHash)
+	default:
+		fmt.Printf("[%s] block %d: %s %s -> %s (tx %s)\n", when, change.Block, change.Kind, change.Old, change.New, change.TxHash)
+	}
+
+	line, err := json.Marshal(change)
+	if err == nil {
+		if _, err := logFile.Write(append(line, '\n')); err != nil {
+			fmt.Printf("Failed to write log: %v\n", err)
+		}
+	}
+
+	if webhook != "" {
+		if err := httpPostJSON(webhook, change, nil); err != nil {
+			fmt.Printf("Failed to send webhook: %v\n", err)
+		}
+	}
+}
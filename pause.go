@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const pausableABI = `[
+  {
+    "inputs": [],
+    "name": "paused",
+    "outputs": [{ "name": "", "type": "bool" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var pausable = mustParseABI(pausableABI)
+
+// pauseEvents maps the pause and freeze events emitted by common token
+// implementations (OpenZeppelin, USDC, USDT) to a timeline label.
+var pauseEvents = map[common.Hash]string{
+	crypto.Keccak256Hash([]byte("Paused(address)")):           "Paused",
+	crypto.Keccak256Hash([]byte("Unpaused(address)")):         "Unpaused",
+	crypto.Keccak256Hash([]byte("Pause()")):                   "Paused",
+	crypto.Keccak256Hash([]byte("Unpause()")):                 "Unpaused",
+	crypto.Keccak256Hash([]byte("Blacklisted(address)")):      "Frozen",
+	crypto.Keccak256Hash([]byte("UnBlacklisted(address)")):    "Unfrozen",
+	crypto.Keccak256Hash([]byte("AddedBlackList(address)")):   "Frozen",
+	crypto.Keccak256Hash([]byte("RemovedBlackList(address)")): "Unfrozen",
+}
+
+func tokenPauseHistory(c *cli.Context) error {
+	fromBlock := c.Uint64("from-block")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	result, err := callContract(client, tokenAddress, pausable, "paused")
+	if err != nil {
+		fmt.Println("Current status: unknown (token does not expose paused())")
+	} else if result[0].(bool) {
+		fmt.Println("Current status: *** PAUSED ***")
+	} else {
+		fmt.Println("Current status: active")
+	}
+	fmt.Println()
+
+	var topics []common.Hash
+	for topic := range pauseEvents {
+		topics = append(topics, topic)
+	}
+
+	logs, err := fetchLogs(client, []common.Address{tokenAddress}, [][]common.Hash{topics}, fromBlock, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(logs) == 0 {
+		fmt.Println("No pause or freeze events found.")
+		return nil
+	}
+
+	times := newBlockTimes(client)
+	for _, log := range logs {
+		timestamp, err := times.get(log.BlockNumber)
+		if err != nil {
+			return err
+		}
+
+		// The transaction sender is whoever triggered the action
+		tx, _, err := client.TransactionByHash(context.Background(), log.TxHash)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		sender, err := client.TransactionSender(context.Background(), tx, log.BlockHash, log.TxIndex)
+		if err != nil {
+			return fmt.Errorf("failed to get sender of %s: %w", log.TxHash.Hex(), err)
+		}
+
+		label := pauseEvents[log.Topics[0]]
+		line := fmt.Sprintf("%s  block %d  %-8s by %s", time.Unix(int64(timestamp), 0).UTC().Format(time.RFC3339), log.BlockNumber, label, sender.Hex())
+
+		// Freeze events name the affected account, indexed or not
+		if label == "Frozen" || label == "Unfrozen" {
+			switch {
+			case len(log.Topics) > 1:
+				line += fmt.Sprintf("  account %s", common.BytesToAddress(log.Topics[1].Bytes()).Hex())
+			case len(log.Data) >= 32:
+				line += fmt.Sprintf("  account %s", common.BytesToAddress(log.Data[:32]).Hex())
+			}
+		}
+
+		fmt.Printf("%s  tx %s\n", line, log.TxHash.Hex())
+	}
+	return nil
+}
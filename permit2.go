@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/urfave/cli/v2"
+)
+
+// Permit2 is deployed at the same address on every supported chain
+const permit2Address = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
+
+// ISignatureTransfer.permitTransferFrom, batch variant
+const permit2SignatureTransferABI = `[
+  {
+    "inputs": [
+      {
+        "components": [
+          {
+            "components": [
+              { "name": "token", "type": "address" },
+              { "name": "amount", "type": "uint256" }
+            ],
+            "name": "permitted",
+            "type": "tuple[]"
+          },
+          { "name": "nonce", "type": "uint256" },
+          { "name": "deadline", "type": "uint256" }
+        ],
+        "name": "permit",
+        "type": "tuple"
+      },
+      {
+        "components": [
+          { "name": "to", "type": "address" },
+          { "name": "requestedAmount", "type": "uint256" }
+        ],
+        "name": "transferDetails",
+        "type": "tuple[]"
+      },
+      { "name": "owner", "type": "address" },
+      { "name": "signature", "type": "bytes" }
+    ],
+    "name": "permitTransferFrom",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var permit2SignatureTransfer = mustParseABI(permit2SignatureTransferABI)
+
+// jsonUint256 decodes a uint256 given either as a JSON number or a string
+type jsonUint256 struct {
+	*big.Int
+}
+
+func (v *jsonUint256) UnmarshalJSON(data []byte) error {
+	parsed, err := parseUint256(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	v.Int = parsed
+	return nil
+}
+
+// permit2Transfer is one entry of the --permit-batch-json file. All entries
+// share the spender, nonce and deadline of the signed batch; `to` defaults to
+// the spender.
+type permit2Transfer struct {
+	Token    string      `json:"token"`
+	Spender  string      `json:"spender"`
+	To       string      `json:"to"`
+	Amount   jsonUint256 `json:"amount"`
+	Nonce    jsonUint256 `json:"nonce"`
+	Deadline jsonUint256 `json:"deadline"`
+}
+
+// Go mirrors of the Permit2 structs for ABI packing
+type permit2TokenPermissions struct {
+	Token  common.Address
+	Amount *big.Int
+}
+
+type permit2BatchTransferFrom struct {
+	Permitted []permit2TokenPermissions
+	Nonce     *big.Int
+	Deadline  *big.Int
+}
+
+type permit2TransferDetails struct {
+	To              common.Address
+	RequestedAmount *big.Int
+}
+
+func permit2BatchTransfer(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	content, err := os.ReadFile(c.String("permit-batch-json"))
+	if err != nil {
+		return fmt.Errorf("failed to read permit batch file: %w", err)
+	}
+
+	var transfers []permit2Transfer
+	if err := json.Unmarshal(content, &transfers); err != nil {
+		return fmt.Errorf("failed to parse permit batch file: %w", err)
+	}
+	if len(transfers) == 0 {
+		return fmt.Errorf("permit batch file contains no transfers")
+	}
+
+	spender, err := parseAddress(transfers[0].Spender)
+	if err != nil {
+		return err
+	}
+	nonce := transfers[0].Nonce.Int
+	deadline := transfers[0].Deadline.Int
+	if nonce == nil || deadline == nil {
+		return fmt.Errorf("permit batch entries must include nonce and deadline")
+	}
+
+	batch := permit2BatchTransferFrom{Nonce: nonce, Deadline: deadline}
+	var details []permit2TransferDetails
+	var permitted []interface{}
+	for i, transfer := range transfers {
+		if transfer.Spender == "" || !strings.EqualFold(transfer.Spender, transfers[0].Spender) ||
+			transfer.Nonce.Int == nil || transfer.Nonce.Cmp(nonce) != 0 ||
+			transfer.Deadline.Int == nil || transfer.Deadline.Cmp(deadline) != 0 {
+			return fmt.Errorf("entry %d: all entries must share the same spender, nonce and deadline", i)
+		}
+		if transfer.Amount.Int == nil {
+			return fmt.Errorf("entry %d: missing amount", i)
+		}
+
+		token, err := parseAddress(transfer.Token)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+		to := spender
+		if transfer.To != "" {
+			to, err = parseAddress(transfer.To)
+			if err != nil {
+				return fmt.Errorf("entry %d: %w", i, err)
+			}
+		}
+
+		batch.Permitted = append(batch.Permitted, permit2TokenPermissions{Token: token, Amount: transfer.Amount.Int})
+		details = append(details, permit2TransferDetails{To: to, RequestedAmount: transfer.Amount.Int})
+		permitted = append(permitted, map[string]interface{}{
+			"token":  token.Hex(),
+			"amount": transfer.Amount.String(),
+		})
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveChainID()
+	if err != nil {
+		return err
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"PermitBatchTransferFrom": {
+				{Name: "permitted", Type: "TokenPermissions[]"},
+				{Name: "spender", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+			"TokenPermissions": {
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+		},
+		PrimaryType: "PermitBatchTransferFrom",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Permit2",
+			ChainId:           math.NewHexOrDecimal256(id.Int64()),
+			VerifyingContract: permit2Address,
+		},
+		Message: apitypes.TypedDataMessage{
+			"permitted": permitted,
+			"spender":   spender.Hex(),
+			"nonce":     nonce.String(),
+			"deadline":  deadline.String(),
+		},
+	}
+
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return fmt.Errorf("failed to hash permit: %w", err)
+	}
+
+	signature, err := signHash(keyStore, account, digest)
+	if err != nil {
+		return fmt.Errorf("failed to sign permit: %w", err)
+	}
+	signature[64] += 27 // Permit2 expects the Ethereum-style recovery ID
+
+	txData, err := permit2SignatureTransfer.Pack("permitTransferFrom", batch, details, account.Address, signature)
+	if err != nil {
+		return fmt.Errorf("failed to pack permitTransferFrom data: %w", err)
+	}
+
+	fmt.Printf("Permit signature: %s\n", hexutil.Encode(signature))
+
+	// Permit2 only honours the permit when called by the spender
+	if spender != account.Address {
+		fmt.Printf("Spender %s must submit the transfer; calldata for %s:\n%s\n", spender.Hex(), permit2Address, hexutil.Encode(txData))
+		return nil
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, common.HexToAddress(permit2Address), big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Permit2 batch transfer sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
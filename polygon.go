@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// RootChainManagerProxy of the Polygon PoS bridge on Ethereum mainnet
+const polygonRootChainManagerAddress = "0xA0c68C638235ee32657e8f720a23ceC1bFc77C77"
+
+// Placeholder token address the bridge uses for native ETH
+const polygonEtherAddress = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+
+const polygonRootChainManagerABI = `[
+  {
+    "inputs": [{ "name": "user", "type": "address" }],
+    "name": "depositEtherFor",
+    "outputs": [],
+    "stateMutability": "payable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "user", "type": "address" },
+      { "name": "rootToken", "type": "address" },
+      { "name": "depositData", "type": "bytes" }
+    ],
+    "name": "depositFor",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "", "type": "address" }],
+    "name": "rootToChildToken",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "", "type": "address" }],
+    "name": "tokenToType",
+    "outputs": [{ "name": "", "type": "bytes32" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "", "type": "bytes32" }],
+    "name": "typeToPredicate",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var polygonRootChainManager = mustParseABI(polygonRootChainManagerABI)
+
+// polygonDepositReceiptTime is how long the bridge usually takes to credit a
+// deposit on Polygon once the Ethereum transaction is confirmed.
+const polygonDepositReceiptTime = "about 20-30 minutes"
+
+// checkPolygonMapping verifies the bridge has a child token mapped for
+// rootToken and returns it.
+func checkPolygonMapping(client *ethclient.Client, rootToken common.Address) (common.Address, error) {
+	result, err := callContract(client, common.HexToAddress(polygonRootChainManagerAddress), polygonRootChainManager, "rootToChildToken", rootToken)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to check bridge status: %w", err)
+	}
+
+	childToken := result[0].(common.Address)
+	if childToken == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("token %s is not mapped on the Polygon PoS bridge", rootToken.Hex())
+	}
+	return childToken, nil
+}
+
+// polygonPredicate returns the predicate contract that escrows rootToken and
+// therefore needs the token allowance.
+func polygonPredicate(client *ethclient.Client, rootToken common.Address) (common.Address, error) {
+	manager := common.HexToAddress(polygonRootChainManagerAddress)
+
+	result, err := callContract(client, manager, polygonRootChainManager, "tokenToType", rootToken)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	result, err = callContract(client, manager, polygonRootChainManager, "typeToPredicate", result[0].([32]byte))
+	if err != nil {
+		return common.Address{}, err
+	}
+	return result[0].(common.Address), nil
+}
+
+func polygonDepositEth(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	amount, err := parseAmount(c.String("amount"), 18)
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	childToken, err := checkPolygonMapping(client, common.HexToAddress(polygonEtherAddress))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Bridge status: ETH is mapped to %s on Polygon\n", childToken.Hex())
+
+	txData, err := polygonRootChainManager.Pack("depositEtherFor", account.Address)
+	if err != nil {
+		return fmt.Errorf("failed to pack deposit data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, common.HexToAddress(polygonRootChainManagerAddress), amount, txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deposit transaction sent: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("Funds are expected on Polygon in %s after confirmation\n", polygonDepositReceiptTime)
+	return nil
+}
+
+func polygonDepositErc20(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	rootToken, err := parseAddress(c.String("token"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	childToken, err := checkPolygonMapping(client, rootToken)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Bridge status: %s is mapped to %s on Polygon\n", rootToken.Hex(), childToken.Hex())
+
+	tokenContract, err := Token.ERCToken(rootToken.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+
+	decimals, err := tokenContract.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	amountInUnits, err := parseAmount(c.String("amount"), int(decimals))
+	if err != nil {
+		return err
+	}
+
+	// The predicate, not the RootChainManager, pulls the tokens into escrow
+	predicate, err := polygonPredicate(client, rootToken)
+	if err != nil {
+		return fmt.Errorf("failed to look up bridge predicate: %w", err)
+	}
+
+	approveData, err := tokenContract.ABI.Pack("approve", predicate, amountInUnits)
+	if err != nil {
+		return fmt.Errorf("failed to pack approve data: %w", err)
+	}
+
+	approveTx, err := sendTransaction(client, keyStore, account, rootToken, big.NewInt(0), approveData)
+	if err != nil {
+		return fmt.Errorf("failed to approve bridge: %w", err)
+	}
+	fmt.Printf("Approval transaction sent: %s\n", approveTx.Hash().Hex())
+
+	// The deposit cannot be estimated until the allowance is in place
+	if _, err := waitForSuccess(client, approveTx); err != nil {
+		return fmt.Errorf("approval failed: %w", err)
+	}
+
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	depositData, err := abi.Arguments{{Type: uint256Type}}.Pack(amountInUnits)
+	if err != nil {
+		return fmt.Errorf("failed to encode deposit amount: %w", err)
+	}
+
+	txData, err := polygonRootChainManager.Pack("depositFor", account.Address, rootToken, depositData)
+	if err != nil {
+		return fmt.Errorf("failed to pack deposit data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, common.HexToAddress(polygonRootChainManagerAddress), big.NewInt(0), txData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deposit transaction sent: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("Tokens are expected on Polygon in %s after confirmation\n", polygonDepositReceiptTime)
+	return nil
+}
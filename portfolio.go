@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+const portfolioFile = "portfolio.json"
+
+// portfolioToken is a token tracked in the portfolio. Tokens added without
+// an account are tracked for every account.
+type portfolioToken struct {
+	Token   common.Address  `json:"token"`
+	Label   string          `json:"label"`
+	Network string          `json:"network"`
+	Account *common.Address `json:"account,omitempty"`
+}
+
+// tracks reports whether the entry applies to an account on the network
+func (p portfolioToken) tracks(account common.Address) bool {
+	return p.Network == network && (p.Account == nil || *p.Account == account)
+}
+
+// loadPortfolio reads the tracked tokens. A missing file is an empty
+// portfolio.
+func loadPortfolio() ([]portfolioToken, error) {
+	path, err := dataFilePath(portfolioFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []portfolioToken
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read portfolio: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse portfolio: %w", err)
+	}
+	return tokens, nil
+}
+
+// savePortfolio writes the tracked tokens
+func savePortfolio(tokens []portfolioToken) error {
+	path, err := dataFilePath(portfolioFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode portfolio: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write portfolio: %w", err)
+	}
+	return nil
+}
+
+// portfolioAccount returns the account selected with --index, or nil when
+// the flag is not set.
+func portfolioAccount(c *cli.Context) (*common.Address, error) {
+	if !c.IsSet("index") {
+		return nil, nil
+	}
+	index := c.Int("index")
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return nil, fmt.Errorf("invalid account index")
+	}
+	return &accountList[index].Address, nil
+}
+
+// sameScope reports whether two entries track the same token for the same
+// accounts on the same network
+func sameScope(a, b portfolioToken) bool {
+	if a.Token != b.Token || a.Network != b.Network || (a.Account == nil) != (b.Account == nil) {
+		return false
+	}
+	return a.Account == nil || *a.Account == *b.Account
+}
+
+// describeScope names the accounts an entry applies to
+func describeScope(entry portfolioToken) string {
+	if entry.Account == nil {
+		return "all accounts on " + entry.Network
+	}
+	return entry.Account.Hex() + " on " + entry.Network
+}
+
+func portfolioAdd(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+	account, err := portfolioAccount(c)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := loadPortfolio()
+	if err != nil {
+		return err
+	}
+
+	entry := portfolioToken{Token: tokenAddress, Label: c.String("label"), Network: network, Account: account}
+	replaced := false
+	for i := range tokens {
+		if sameScope(tokens[i], entry) {
+			tokens[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		tokens = append(tokens, entry)
+	}
+
+	if err := savePortfolio(tokens); err != nil {
+		return err
+	}
+	fmt.Printf("Tracking %s as %q for %s\n", tokenAddress.Hex(), entry.Label, describeScope(entry))
+	return nil
+}
+
+func portfolioRemove(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+	account, err := portfolioAccount(c)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := loadPortfolio()
+	if err != nil {
+		return err
+	}
+
+	entry := portfolioToken{Token: tokenAddress, Network: network, Account: account}
+	remaining := tokens[:0]
+	for _, token := range tokens {
+		if !sameScope(token, entry) {
+			remaining = append(remaining, token)
+		}
+	}
+	if len(remaining) == len(tokens) {
+		return fmt.Errorf("%s is not tracked for %s", tokenAddress.Hex(), describeScope(entry))
+	}
+
+	if err := savePortfolio(remaining); err != nil {
+		return err
+	}
+	fmt.Printf("Stopped tracking %s for %s\n", tokenAddress.Hex(), describeScope(entry))
+	return nil
+}
+
+func portfolioShow(c *cli.Context) error {
+	account, err := portfolioAccount(c)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := loadPortfolio()
+	if err != nil {
+		return err
+	}
+
+	// Account-specific entries override the label of shared ones
+	labels := map[common.Address]string{}
+	var tracked []common.Address
+	for _, token := range tokens {
+		if !token.tracks(*account) {
+			continue
+		}
+		if _, ok := labels[token.Token]; !ok {
+			tracked = append(tracked, token.Token)
+		}
+		if _, ok := labels[token.Token]; !ok || token.Account != nil {
+			labels[token.Token] = token.Label
+		}
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	ethBalance, err := client.BalanceAt(context.Background(), *account, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	prices := map[common.Address]float64{}
+	if len(tracked) > 0 {
+		prices, err = coingeckoTokenPrices(tracked)
+		if err != nil {
+			fmt.Printf("Warning: token prices unavailable: %v\n", err)
+		}
+	}
+
+	var total float64
+	unpriced := false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Portfolio of %s on %s\n\n", account.Hex(), network)
+	fmt.Fprintln(w, "LABEL\tTOKEN\tBALANCE\tUSD")
+
+	if ethPrice, err := ethUSDPrice(client); err == nil {
+		usd := weiToUSD(ethBalance, ethPrice)
+		total += usd
+		fmt.Fprintf(w, "ETH\t-\t%s\t%.2f\n", formatBigIntToDecimal(ethBalance, 18), usd)
+	} else {
+		unpriced = true
+		fmt.Fprintf(w, "ETH\t-\t%s\t-\n", formatBigIntToDecimal(ethBalance, 18))
+	}
+
+	id, err := resolveChainID()
+	if err != nil {
+		return err
+	}
+
+	for _, tokenAddress := range tracked {
+		symbol, decimal, err := tokenDisplayInfo(c, client, id.Int64(), tokenAddress)
+		if err != nil {
+			return err
+		}
+		tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimal, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		balance, err := tokenContract.BalanceOf(account.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to get %s balance: %w", labels[tokenAddress], err)
+		}
+
+		amount := formatBigIntToDecimal(balance, decimal)
+		if symbol != "" {
+			amount += " " + symbol
+		}
+		usd := "-"
+		if price, ok := prices[tokenAddress]; ok {
+			value := normalizedAmount(balance, decimal) * price
+			total += value
+			usd = fmt.Sprintf("%.2f", value)
+		} else {
+			unpriced = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", labels[tokenAddress], tokenAddress.Hex(), amount, usd)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nTotal: $%.2f\n", total)
+	if unpriced {
+		fmt.Println("Assets without a price are excluded from the total")
+	}
+	return nil
+}
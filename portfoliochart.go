@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// portfolioSlice is one asset in the portfolio chart
+type portfolioSlice struct {
+	Symbol  string  `json:"symbol"`
+	Address string  `json:"address,omitempty"`
+	Balance string  `json:"balance"`
+	USD     float64 `json:"usd"`
+	Percent float64 `json:"percent"`
+}
+
+// pieSymbols are the fill characters used for consecutive slices
+var pieSymbols = []rune{'#', '@', '*', '+', '=', '%', 'o', 'x', '~', ':'}
+
+// renderPieChart draws slices as an ASCII pie of the given radius. Each row
+// is sampled at half the horizontal resolution to compensate for the
+// height of terminal characters.
+func renderPieChart(slices []portfolioSlice, radius int) string {
+	var out strings.Builder
+	for y := -radius; y <= radius; y++ {
+		for x := -2 * radius; x <= 2*radius; x++ {
+			fx, fy := float64(x)/2, float64(y)
+			if fx*fx+fy*fy > float64(radius*radius) {
+				out.WriteByte(' ')
+				continue
+			}
+
+			// Angle clockwise from 12 o'clock, as a fraction of a turn
+			angle := math.Atan2(fx, -fy) / (2 * math.Pi)
+			if angle < 0 {
+				angle++
+			}
+
+			symbol := pieSymbols[(len(slices)-1)%len(pieSymbols)]
+			cumulative := 0.0
+			for i, slice := range slices {
+				cumulative += slice.Percent / 100
+				if angle < cumulative {
+					symbol = pieSymbols[i%len(pieSymbols)]
+					break
+				}
+			}
+			out.WriteRune(symbol)
+		}
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+func portfolioChart(c *cli.Context) error {
+	index := c.Int("index")
+	output := c.String("output")
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	holder := accountList[index].Address
+
+	lines, err := readLines(c.String("token-addresses"))
+	if err != nil {
+		return err
+	}
+	tokens := make([]common.Address, 0, len(lines))
+	for _, line := range lines {
+		token, err := parseAddress(line)
+		if err != nil {
+			return err
+		}
+		tokens = append(tokens, token)
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	ethPrice, err := ethUSDPrice(client)
+	if err != nil {
+		return err
+	}
+	ethBalance, err := client.BalanceAt(context.Background(), holder, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get ETH balance: %w", err)
+	}
+	ethPriceFloat, _ := ethPrice.Float64()
+
+	slices := []portfolioSlice{{
+		Symbol:  "ETH",
+		Balance: formatBigIntToDecimal(ethBalance, 18),
+		USD:     weiToUSD(ethBalance, ethPrice),
+	}}
+
+	prices := map[common.Address]float64{}
+	if len(tokens) > 0 {
+		prices, err = coingeckoTokenPrices(tokens)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tokenAddress := range tokens {
+		tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+		symbol, err := tokenContract.Symbol()
+		if err != nil {
+			symbol = tokenAddress.Hex()
+		}
+		decimals, err := tokenDecimals(client, tokenAddress)
+		if err != nil {
+			return err
+		}
+		balance, err := tokenContract.BalanceOf(holder.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to get balance of %s: %w", symbol, err)
+		}
+
+		scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+		amount, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), scale).Float64()
+
+		slices = append(slices, portfolioSlice{
+			Symbol:  symbol,
+			Address: tokenAddress.Hex(),
+			Balance: formatBigIntToDecimal(balance, decimals),
+			USD:     amount * prices[tokenAddress],
+		})
+	}
+
+	total := 0.0
+	for _, slice := range slices {
+		total += slice.USD
+	}
+	if total == 0 {
+		return fmt.Errorf("portfolio has no priced assets")
+	}
+
+	// Fold allocations under 2% into a single Other slice
+	var chart []portfolioSlice
+	other := portfolioSlice{Symbol: "Other", Balance: "-"}
+	for _, slice := range slices {
+		slice.Percent = slice.USD / total * 100
+		if slice.Percent < 2 {
+			other.USD += slice.USD
+			other.Percent += slice.Percent
+			continue
+		}
+		chart = append(chart, slice)
+	}
+	sort.Slice(chart, func(i, j int) bool { return chart[i].USD > chart[j].USD })
+	if other.USD > 0 {
+		chart = append(chart, other)
+	}
+
+	if output == "json" {
+		encoded, err := json.MarshalIndent(chart, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode portfolio: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	fmt.Print(renderPieChart(chart, 10))
+	fmt.Println()
+	for i, slice := range chart {
+		fmt.Printf("%c %-8s %6.2f%%  $%.2f\n", pieSymbols[i%len(pieSymbols)], slice.Symbol, slice.Percent, slice.USD)
+	}
+	fmt.Printf("Total: $%.2f (%.4f ETH)\n", total, total/ethPriceFloat)
+	return nil
+}
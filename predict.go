@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+func predictContractAddress(c *cli.Context) error {
+	nonce := c.Uint64("nonce")
+	untilNonce := nonce
+	if c.IsSet("until-nonce") {
+		untilNonce = c.Uint64("until-nonce")
+	}
+	if untilNonce < nonce {
+		return fmt.Errorf("until-nonce must not be below nonce")
+	}
+
+	from, err := parseAddress(c.String("from"))
+	if err != nil {
+		return err
+	}
+
+	for n := nonce; n <= untilNonce; n++ {
+		fmt.Printf("nonce %d: %s\n", n, crypto.CreateAddress(from, n).Hex())
+	}
+	return nil
+}
+
+func predictCreate2Address(c *cli.Context) error {
+	factory, err := parseAddress(c.String("factory"))
+	if err != nil {
+		return err
+	}
+
+	salt, err := hexutil.Decode(c.String("salt"))
+	if err != nil || len(salt) > 32 {
+		return fmt.Errorf("salt must be at most 32 bytes of 0x-prefixed hex")
+	}
+
+	initCodeHash, err := hexutil.Decode(c.String("init-code-hash"))
+	if err != nil || len(initCodeHash) != 32 {
+		return fmt.Errorf("init code hash must be 32 bytes of 0x-prefixed hex")
+	}
+
+	// Short salts are treated as uint256 values, as Solidity's bytes32(uint256(x)) does
+	var salt32 [32]byte
+	copy(salt32[:], common.LeftPadBytes(salt, 32))
+
+	fmt.Println(crypto.CreateAddress2(factory, salt32, initCodeHash).Hex())
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	localNodeURL   = "http://localhost:8545"
+	localNodeWSURL = "ws://localhost:8546"
+
+	// An endpoint is abandoned for the fallback after this many consecutive
+	// connection errors
+	dialAttempts   = 3
+	dialRetryDelay = time.Second
+	dialTimeout    = 10 * time.Second
+)
+
+var (
+	provider       string
+	rpcURL         string
+	rpcURLFallback string
+	wsURL          string
+	alchemyKey     string
+	quicknodeURL   string
+)
+
+// providers lists the values accepted by --provider
+var providers = []string{"infura", "alchemy", "quicknode", "local", "custom"}
+
+// checkProvider validates --provider and the settings it needs
+func checkProvider() error {
+	switch provider {
+	case "infura", "alchemy", "local":
+		return nil
+	case "quicknode":
+		if quicknodeURL == "" && rpcURL == "" {
+			return fmt.Errorf("--provider quicknode requires QUICKNODE_URL or --rpc-url")
+		}
+		return nil
+	case "custom":
+		if rpcURL == "" {
+			return fmt.Errorf("--provider custom requires --rpc-url")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown provider %q, expected one of %v", provider, providers)
+}
+
+// providerURL returns the endpoint of the selected provider for a network.
+// QuickNode endpoints are issued per network, so only the configured network
+// can be reached through them.
+func providerURL(name string) (string, error) {
+	switch provider {
+	case "infura":
+		return infuraURL(name), nil
+	case "alchemy":
+		preset, ok := networkPresets[name]
+		if !ok {
+			return "", fmt.Errorf("no Alchemy endpoint known for network %q", name)
+		}
+		return fmt.Sprintf("https://%s.g.alchemy.com/v2/%s", preset.alchemyName, alchemyKey), nil
+	case "quicknode":
+		if name == network && quicknodeURL != "" {
+			return quicknodeURL, nil
+		}
+	case "local":
+		if name == network {
+			return localNodeURL, nil
+		}
+	}
+	return "", fmt.Errorf("cannot reach network %q with --provider %s", name, provider)
+}
+
+// nodeURL returns the endpoint of the configured network: --rpc-url when
+// given, otherwise the provider's endpoint.
+func nodeURL() (string, error) {
+	if rpcURL != "" {
+		return rpcURL, nil
+	}
+	return providerURL(network)
+}
+
+// websocketURL returns the endpoint for subscriptions: --ws-url when given,
+// otherwise the provider's websocket endpoint.
+func websocketURL() (string, error) {
+	if wsURL != "" {
+		return wsURL, nil
+	}
+
+	switch provider {
+	case "infura":
+		name := network
+		if preset, ok := networkPresets[network]; ok {
+			name = preset.infuraName
+		}
+		return fmt.Sprintf("wss://%s.infura.io/ws/v3/%s", name, infuraKey), nil
+	case "alchemy":
+		if preset, ok := networkPresets[network]; ok {
+			return fmt.Sprintf("wss://%s.g.alchemy.com/v2/%s", preset.alchemyName, alchemyKey), nil
+		}
+	case "local":
+		return localNodeWSURL, nil
+	}
+	return "", fmt.Errorf("--ws-url is required with --provider %s on %q", provider, network)
+}
+
+// dialEndpoint connects to an endpoint and checks that it answers. Dialing
+// over HTTP does not contact the node, so the chain ID query is what
+// surfaces connection errors.
+func dialEndpoint(endpoint string) (*ethclient.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.ChainID(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// dialClient connects to the configured network. After dialAttempts
+// consecutive connection errors it switches to --rpc-url-fallback.
+func dialClient() (*ethclient.Client, error) {
+	primary, err := nodeURL()
+	if err != nil {
+		return nil, err
+	}
+	endpoints := []string{primary}
+	if rpcURLFallback != "" {
+		endpoints = append(endpoints, rpcURLFallback)
+	}
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		if i > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %v; switching to the fallback endpoint\n", lastErr)
+		}
+		for attempt := 1; attempt <= dialAttempts; attempt++ {
+			client, err := dialEndpoint(endpoint)
+			if err == nil {
+				return client, nil
+			}
+			lastErr = err
+			if attempt < dialAttempts {
+				time.Sleep(dialRetryDelay)
+			}
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// transparentProxyBin and proxyAdminBin are the init code of the
+// TransparentUpgradeableProxy and ProxyAdmin of OpenZeppelin Contracts 4.9.6,
+// whose proxy takes the admin as a constructor argument; the 5.x proxy
+// deploys its own ProxyAdmin instead. go generate extracts them from the
+// pinned npm package and records their SHA-256 in
+// contracts/openzeppelin.sha256, which is checked when they are loaded.
+//
+//go:generate sh contracts/openzeppelin.sh 4.9.6
+//go:embed contracts/TransparentUpgradeableProxy.bin
+var transparentProxyBin string
+
+//go:embed contracts/ProxyAdmin.bin
+var proxyAdminBin string
+
+//go:embed contracts/openzeppelin.sha256
+var openZeppelinChecksums string
+
+var (
+	transparentProxyCode = mustVendoredCode("TransparentUpgradeableProxy.bin", transparentProxyBin, openZeppelinChecksums)
+	proxyAdminCode       = mustVendoredCode("ProxyAdmin.bin", proxyAdminBin, openZeppelinChecksums)
+)
+
+// vendoredCode decodes a bundled .bin file after checking it against its
+// entry in a sha256sum listing
+func vendoredCode(name, bin, checksums string) ([]byte, error) {
+	var want string
+	for _, line := range strings.Split(checksums, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == name {
+			want = fields[0]
+		}
+	}
+	if want == "" {
+		return nil, fmt.Errorf("no checksum for %s", name)
+	}
+	sum := sha256.Sum256([]byte(bin))
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("%s has sha256 %s, want %s", name, got, want)
+	}
+
+	code, err := hexutil.Decode("0x" + strings.TrimSpace(bin))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode in %s: %w", name, err)
+	}
+	return code, nil
+}
+
+func mustVendoredCode(name, bin, checksums string) []byte {
+	code, err := vendoredCode(name, bin, checksums)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// transparentProxyABI is TransparentUpgradeableProxy's constructor and events
+// together with ITransparentUpgradeableProxy, the functions its fallback
+// serves to the admin. Every other call, and every call from other accounts,
+// is delegated to the implementation.
+const transparentProxyABI = `[
+  {
+    "inputs": [
+      { "name": "_logic", "type": "address" },
+      { "name": "admin_", "type": "address" },
+      { "name": "_data", "type": "bytes" }
+    ],
+    "stateMutability": "payable",
+    "type": "constructor"
+  },
+  {
+    "inputs": [],
+    "name": "admin",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "implementation",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "", "type": "address" }],
+    "name": "changeAdmin",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "", "type": "address" }],
+    "name": "upgradeTo",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "", "type": "address" },
+      { "name": "", "type": "bytes" }
+    ],
+    "name": "upgradeToAndCall",
+    "outputs": [],
+    "stateMutability": "payable",
+    "type": "function"
+  },
+  {
+    "anonymous": false,
+    "inputs": [
+      { "indexed": false, "name": "previousAdmin", "type": "address" },
+      { "indexed": false, "name": "newAdmin", "type": "address" }
+    ],
+    "name": "AdminChanged",
+    "type": "event"
+  },
+  {
+    "anonymous": false,
+    "inputs": [{ "indexed": true, "name": "beacon", "type": "address" }],
+    "name": "BeaconUpgraded",
+    "type": "event"
+  },
+  {
+    "anonymous": false,
+    "inputs": [{ "indexed": true, "name": "implementation", "type": "address" }],
+    "name": "Upgraded",
+    "type": "event"
+  }
+]`
+
+// proxyAdminABI is the part of ProxyAdmin upgrade-proxy uses
+const proxyAdminABI = `[
+  {
+    "inputs": [],
+    "name": "owner",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proxy", "type": "address" }],
+    "name": "getProxyAdmin",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "proxy", "type": "address" }],
+    "name": "getProxyImplementation",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "proxy", "type": "address" },
+      { "name": "implementation", "type": "address" }
+    ],
+    "name": "upgrade",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "proxy", "type": "address" },
+      { "name": "implementation", "type": "address" },
+      { "name": "data", "type": "bytes" }
+    ],
+    "name": "upgradeAndCall",
+    "outputs": [],
+    "stateMutability": "payable",
+    "type": "function"
+  }
+]`
+
+var (
+	transparentProxy = mustParseABI(transparentProxyABI)
+	proxyAdmin       = mustParseABI(proxyAdminABI)
+)
+
+// readBytecode decodes bytecode given as hex or as the path of a file holding
+// hex, such as solc's .bin output
+func readBytecode(value string) ([]byte, error) {
+	if contents, err := os.ReadFile(value); err == nil {
+		value = string(contents)
+	}
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "0x") {
+		value = "0x" + value
+	}
+	code, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("bytecode is empty")
+	}
+	return code, nil
+}
+
+// optionalCalldata decodes a hex calldata flag, which may be left out
+func optionalCalldata(c *cli.Context, name string) ([]byte, error) {
+	if !c.IsSet(name) {
+		return []byte{}, nil
+	}
+	data, err := hexutil.Decode(c.String(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", name, err)
+	}
+	return data, nil
+}
+
+func deployUpgradeable(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	implementationCode, err := readBytecode(c.String("implementation"))
+	if err != nil {
+		return err
+	}
+	var admin common.Address
+	if c.IsSet("admin") {
+		admin, err = parseAddress(c.String("admin"))
+		if err != nil {
+			return err
+		}
+		if admin == (common.Address{}) {
+			return fmt.Errorf("admin must not be the zero address")
+		}
+	}
+	initData, err := optionalCalldata(c, "init-calldata")
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	implementationTx, implementation, err := deployContract(client, keyStore, account, implementationCode)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Implementation deployment sent: %s\n", implementationTx.Hash().Hex())
+
+	// Without --admin the proxy is managed by a new ProxyAdmin owned by the
+	// deploying account
+	if admin == (common.Address{}) {
+		adminTx, address, err := deployContract(client, keyStore, account, proxyAdminCode)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("ProxyAdmin deployment sent: %s\n", adminTx.Hash().Hex())
+		admin = address
+	}
+
+	// The proxy constructor checks the implementation's code and runs the
+	// initializer against it, so it must be mined first
+	if _, err := waitForSuccess(client, implementationTx); err != nil {
+		return err
+	}
+
+	args, err := transparentProxy.Pack("", implementation, admin, initData)
+	if err != nil {
+		return fmt.Errorf("failed to pack proxy constructor arguments: %w", err)
+	}
+	proxyTx, proxy, err := deployContract(client, keyStore, account, append(append([]byte{}, transparentProxyCode...), args...))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Proxy deployment sent: %s\n", proxyTx.Hash().Hex())
+	fmt.Printf("Proxy address: %s\n", proxy.Hex())
+	fmt.Printf("Implementation address: %s\n", implementation.Hex())
+	fmt.Printf("Admin: %s\n", admin.Hex())
+	if admin == account.Address {
+		fmt.Println("Note: calls from the admin are not forwarded to the implementation; use another account to interact with the proxy")
+	}
+	return nil
+}
+
+// proxyUpgradeCall returns the admin of a transparent proxy and the call that
+// upgrades it when sent from the given account. An account admin upgrades
+// the proxy directly; a ProxyAdmin contract upgrades it for its owner.
+// Without migration calldata the plain upgrade functions are used, since the
+// AndCall variants always call the new implementation.
+func proxyUpgradeCall(client *ethclient.Client, from, proxy, newImplementation common.Address, migrateData []byte) (admin, target common.Address, data []byte, err error) {
+	code, err := client.CodeAt(context.Background(), newImplementation, nil)
+	if err != nil {
+		return admin, target, nil, fmt.Errorf("failed to get implementation code: %w", err)
+	}
+	if len(code) == 0 {
+		return admin, target, nil, fmt.Errorf("new implementation %s has no code", newImplementation.Hex())
+	}
+
+	slot, err := client.StorageAt(context.Background(), proxy, storagePatterns["eip1967-admin"].slot, nil)
+	if err != nil {
+		return admin, target, nil, fmt.Errorf("failed to read proxy admin: %w", err)
+	}
+	admin = common.BytesToAddress(slot)
+	if admin == (common.Address{}) {
+		return admin, target, nil, fmt.Errorf("%s has no ERC-1967 admin; it is not a transparent proxy", proxy.Hex())
+	}
+
+	if admin == from {
+		if len(migrateData) == 0 {
+			data, err = transparentProxy.Pack("upgradeTo", newImplementation)
+		} else {
+			data, err = transparentProxy.Pack("upgradeToAndCall", newImplementation, migrateData)
+		}
+		if err != nil {
+			return admin, target, nil, fmt.Errorf("failed to pack upgrade data: %w", err)
+		}
+		return admin, proxy, data, nil
+	}
+
+	result, err := callContract(client, admin, proxyAdmin, "owner")
+	if err != nil {
+		return admin, target, nil, fmt.Errorf("proxy admin %s is neither this account nor a ProxyAdmin: %w", admin.Hex(), err)
+	}
+	if owner := result[0].(common.Address); owner != from {
+		return admin, target, nil, fmt.Errorf("ProxyAdmin %s is owned by %s, not %s", admin.Hex(), owner.Hex(), from.Hex())
+	}
+	if len(migrateData) == 0 {
+		data, err = proxyAdmin.Pack("upgrade", proxy, newImplementation)
+	} else {
+		data, err = proxyAdmin.Pack("upgradeAndCall", proxy, newImplementation, migrateData)
+	}
+	if err != nil {
+		return admin, target, nil, fmt.Errorf("failed to pack upgrade data: %w", err)
+	}
+	return admin, admin, data, nil
+}
+
+func upgradeProxy(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	proxy, err := parseAddress(c.String("proxy"))
+	if err != nil {
+		return err
+	}
+	newImplementation, err := parseAddress(c.String("new-implementation"))
+	if err != nil {
+		return err
+	}
+	migrateData, err := optionalCalldata(c, "migrate-calldata")
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	admin, target, data, err := proxyUpgradeCall(client, account.Address, proxy, newImplementation, migrateData)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, target, big.NewInt(0), data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Upgrade transaction sent: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("Proxy %s -> implementation %s (admin %s)\n", proxy.Hex(), newImplementation.Hex(), admin.Hex())
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestVendoredCode(t *testing.T) {
+	bin := "6080604052\n"
+	sum := sha256.Sum256([]byte(bin))
+	checksums := hex.EncodeToString(sum[:]) + "  Example.bin\n"
+
+	code, err := vendoredCode("Example.bin", bin, checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hexutil.Encode(code) != "0x6080604052" {
+		t.Errorf("code = %x, want 6080604052", code)
+	}
+
+	if _, err := vendoredCode("Example.bin", "6080604053\n", checksums); err == nil {
+		t.Error("code that does not match its checksum accepted")
+	}
+	if _, err := vendoredCode("Other.bin", bin, checksums); err == nil {
+		t.Error("code without a checksum accepted")
+	}
+}
+
+func TestTransparentProxyUpgrade(t *testing.T) {
+	chain := newTestChain(t, 3, nil)
+	owner, adminAccount, user := chain.accounts[0], chain.accounts[1], chain.accounts[2]
+
+	deploy := func(code []byte) common.Address {
+		t.Helper()
+		tx, address, err := deployContract(chain.client, chain.keyStore, owner, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain.mine(t, tx)
+		return address
+	}
+	deployProxy := func(implementation, admin common.Address, initData []byte) common.Address {
+		t.Helper()
+		args, err := transparentProxy.Pack("", implementation, admin, initData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deploy(append(append([]byte{}, transparentProxyCode...), args...))
+	}
+	slot := func(proxy common.Address, pattern string) common.Address {
+		t.Helper()
+		value, err := chain.client.StorageAt(context.Background(), proxy, storagePatterns[pattern].slot, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return common.BytesToAddress(value)
+	}
+	upgrade := func(from accounts.Account, proxy, implementation common.Address, migrateData []byte) error {
+		t.Helper()
+		_, target, data, err := proxyUpgradeCall(chain.client, from.Address, proxy, implementation, migrateData)
+		if err != nil {
+			return err
+		}
+		tx, err := sendTransaction(chain.client, chain.keyStore, from, target, big.NewInt(0), data)
+		if err != nil {
+			return err
+		}
+		receipt := chain.mine(t, tx)
+		upgraded := transparentProxy.Events["Upgraded"].ID
+		for _, log := range receipt.Logs {
+			if log.Address == proxy && log.Topics[0] == upgraded && common.BytesToAddress(log.Topics[1][:]) == implementation {
+				return nil
+			}
+		}
+		t.Fatalf("upgrade of %s to %s emitted no Upgraded event", proxy.Hex(), implementation.Hex())
+		return nil
+	}
+
+	// Any contract will do as an implementation; only its code is checked
+	implementation := func() common.Address {
+		args, err := feeWrapper.Pack("", common.Address{}, big.NewInt(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deploy(append(append([]byte{}, feeWrapperCode...), args...))
+	}
+	v1, v2 := implementation(), implementation()
+	admin := deploy(proxyAdminCode)
+
+	// Upgrades through a ProxyAdmin owned by the deployer
+	proxy := deployProxy(v1, admin, nil)
+	if got := slot(proxy, "eip1967-implementation"); got != v1 {
+		t.Fatalf("implementation slot = %s, want %s", got.Hex(), v1.Hex())
+	}
+	if got := slot(proxy, "eip1967-admin"); got != admin {
+		t.Fatalf("admin slot = %s, want %s", got.Hex(), admin.Hex())
+	}
+	result, err := callContract(chain.client, admin, proxyAdmin, "owner")
+	if err != nil || result[0].(common.Address) != owner.Address {
+		t.Fatalf("owner = %v, %v, want %s", result, err, owner.Address.Hex())
+	}
+	result, err = callContract(chain.client, admin, proxyAdmin, "getProxyAdmin", proxy)
+	if err != nil || result[0].(common.Address) != admin {
+		t.Fatalf("getProxyAdmin = %v, %v, want %s", result, err, admin.Hex())
+	}
+	result, err = callContract(chain.client, admin, proxyAdmin, "getProxyImplementation", proxy)
+	if err != nil || result[0].(common.Address) != v1 {
+		t.Fatalf("getProxyImplementation = %v, %v, want %s", result, err, v1.Hex())
+	}
+
+	if err := upgrade(user, proxy, v2, nil); err == nil {
+		t.Fatal("upgrade by an account that does not own the ProxyAdmin succeeded")
+	}
+	data, err := proxyAdmin.Pack("upgrade", proxy, v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sendTransaction(chain.client, chain.keyStore, user, admin, big.NewInt(0), data); err == nil {
+		t.Fatal("ProxyAdmin accepted an upgrade from an account other than its owner")
+	}
+	if got := slot(proxy, "eip1967-implementation"); got != v1 {
+		t.Fatalf("implementation slot after rejected upgrades = %s, want %s", got.Hex(), v1.Hex())
+	}
+
+	if err := upgrade(owner, proxy, v2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := slot(proxy, "eip1967-implementation"); got != v2 {
+		t.Fatalf("implementation slot after upgrade = %s, want %s", got.Hex(), v2.Hex())
+	}
+
+	// Migration calldata runs against the new implementation
+	migrateData, err := feeWrapper.Pack("underlying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := upgrade(owner, proxy, v1, migrateData); err != nil {
+		t.Fatal(err)
+	}
+	if got := slot(proxy, "eip1967-implementation"); got != v1 {
+		t.Fatalf("implementation slot after upgradeAndCall = %s, want %s", got.Hex(), v1.Hex())
+	}
+
+	// Upgrades by an account admin; other accounts reach the implementation
+	direct := deployProxy(v1, adminAccount.Address, nil)
+	data, err = transparentProxy.Pack("upgradeTo", v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sendTransaction(chain.client, chain.keyStore, user, direct, big.NewInt(0), data); err == nil {
+		t.Fatal("proxy accepted upgradeTo from an account other than its admin")
+	}
+	if err := upgrade(user, direct, v2, nil); err == nil {
+		t.Fatal("upgrade-proxy accepted an account that is not the admin")
+	}
+	if err := upgrade(adminAccount, direct, v2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := slot(direct, "eip1967-implementation"); got != v2 {
+		t.Fatalf("implementation slot after direct upgrade = %s, want %s", got.Hex(), v2.Hex())
+	}
+}
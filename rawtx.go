@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+// printTransactionSummary prints the fields of a signed transaction
+func printTransactionSummary(tx *types.Transaction) {
+	from := "unknown (invalid signature)"
+	if sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		from = sender.Hex()
+	}
+	to := "contract creation"
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+
+	fmt.Printf("Hash:      %s\n", tx.Hash().Hex())
+	fmt.Printf("Type:      %s\n", txTypeName(tx.Type()))
+	fmt.Printf("Chain ID:  %s\n", tx.ChainId())
+	fmt.Printf("From:      %s\n", from)
+	fmt.Printf("To:        %s\n", to)
+	fmt.Printf("Value:     %s ETH\n", formatBigIntToDecimal(tx.Value(), 18))
+	fmt.Printf("Nonce:     %d\n", tx.Nonce())
+	fmt.Printf("Gas limit: %d\n", tx.Gas())
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		fmt.Printf("Gas price: %s gwei\n", formatBigIntToDecimal(tx.GasPrice(), 9))
+	} else {
+		fmt.Printf("Max fee:   %s gwei\n", formatBigIntToDecimal(tx.GasFeeCap(), 9))
+		fmt.Printf("Max tip:   %s gwei\n", formatBigIntToDecimal(tx.GasTipCap(), 9))
+	}
+	fmt.Printf("Data:      %d bytes\n", len(tx.Data()))
+}
+
+func sendRawTx(c *cli.Context) error {
+	rawTx, err := hexutil.Decode(c.String("tx"))
+	if err != nil {
+		return fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	if c.Bool("decode") {
+		printTransactionSummary(tx)
+		fmt.Println()
+	}
+
+	if !c.Bool("skip-chain-id-check") {
+		id, err := resolveChainID()
+		if err != nil {
+			return err
+		}
+		if tx.ChainId().Cmp(id) != 0 {
+			return fmt.Errorf("transaction is signed for chain ID %s but the network has chain ID %s; pass --skip-chain-id-check to send anyway",
+				tx.ChainId(), id.String())
+		}
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	if err := broadcastTransaction(client, tx); err != nil {
+		return err
+	}
+	fmt.Printf("Transaction sent: %s\n", tx.Hash().Hex())
+
+	if c.Bool("wait") {
+		if _, err := waitMined(client, tx, c.Duration("timeout")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
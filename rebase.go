@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// Share accounting functions of common rebasing tokens. sharesOf is used by
+// Lido stETH, scaledBalanceOf by Aave aTokens and Ampleforth.
+const rebaseTokenABI = `[
+  {
+    "inputs": [{ "name": "account", "type": "address" }],
+    "name": "sharesOf",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "user", "type": "address" }],
+    "name": "scaledBalanceOf",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var rebaseToken = mustParseABI(rebaseTokenABI)
+
+// rebasePatterns maps each shares function to the token family that uses it
+var rebasePatterns = []struct {
+	method string
+	family string
+}{
+	{"sharesOf", "Lido stETH shares"},
+	{"scaledBalanceOf", "Aave aToken / Ampleforth scaled balance"},
+}
+
+func checkRebaseBalance(c *cli.Context) error {
+	index := c.Int("index")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	holder := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	decimals, err := tokenContract.Decimals()
+	if err != nil {
+		return fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	balance, err := tokenContract.BalanceOf(holder.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	fmt.Printf("Account:          %s\n", holder.Hex())
+	fmt.Printf("Rebased balance:  %s\n", formatBigIntToDecimal(balance, int(decimals)))
+
+	// Rebasing tokens rarely implement ERC-165, so probe the known functions
+	for _, pattern := range rebasePatterns {
+		result, err := callContract(client, tokenAddress, rebaseToken, pattern.method, holder)
+		if err != nil {
+			continue
+		}
+		shares := result[0].(*big.Int)
+
+		fmt.Printf("Pattern:          %s (%s)\n", pattern.family, pattern.method)
+		fmt.Printf("Shares:           %s\n", formatBigIntToDecimal(shares, int(decimals)))
+		if shares.Sign() == 0 {
+			fmt.Println("Rebase ratio:     n/a (no shares)")
+			return nil
+		}
+		ratio := new(big.Float).Quo(new(big.Float).SetInt(balance), new(big.Float).SetInt(shares))
+		fmt.Printf("Rebase ratio:     %s tokens per share\n", ratio.Text('f', 18))
+		return nil
+	}
+
+	fmt.Println("Token does not expose a known shares function; it does not appear to rebase.")
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+// printRLPTree prints a decoded RLP value with one line per node. rlp decodes
+// lists into []interface{} and strings into []byte.
+func printRLPTree(value interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch v := value.(type) {
+	case []interface{}:
+		fmt.Printf("%slist (%d items)\n", indent, len(v))
+		for _, item := range v {
+			printRLPTree(item, depth+1)
+		}
+	case []byte:
+		fmt.Printf("%sstring (%d bytes) %s\n", indent, len(v), hexutil.Encode(v))
+	default:
+		fmt.Printf("%sunexpected %T\n", indent, v)
+	}
+}
+
+func decodeRLP(c *cli.Context) error {
+	input := c.String("data")
+	if input == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		input = string(data)
+	}
+
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "0x") {
+		input = "0x" + input
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return fmt.Errorf("invalid hex data: %w", err)
+	}
+
+	var tree interface{}
+	if err := rlp.DecodeBytes(data, &tree); err != nil {
+		return fmt.Errorf("failed to decode RLP: %w", err)
+	}
+
+	printRLPTree(tree, 0)
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/urfave/cli/v2"
+)
+
+const defaultSafeTxServiceURL = "https://safe-transaction-mainnet.safe.global"
+
+// safeMultisigTx is a multisig transaction as returned by the Safe
+// Transaction Service
+type safeMultisigTx struct {
+	Safe                  common.Address `json:"safe"`
+	To                    common.Address `json:"to"`
+	Value                 string         `json:"value"`
+	Data                  *string        `json:"data"`
+	Operation             uint8          `json:"operation"`
+	SafeTxGas             jsonUint256    `json:"safeTxGas"`
+	BaseGas               jsonUint256    `json:"baseGas"`
+	GasPrice              string         `json:"gasPrice"`
+	GasToken              common.Address `json:"gasToken"`
+	RefundReceiver        common.Address `json:"refundReceiver"`
+	Nonce                 jsonUint256    `json:"nonce"`
+	SafeTxHash            string         `json:"safeTxHash"`
+	IsExecuted            bool           `json:"isExecuted"`
+	ConfirmationsRequired int            `json:"confirmationsRequired"`
+	Confirmations         []struct {
+		Owner     common.Address `json:"owner"`
+		Signature string         `json:"signature"`
+	} `json:"confirmations"`
+	DataDecoded *struct {
+		Method string `json:"method"`
+	} `json:"dataDecoded"`
+}
+
+// safeServiceURL returns the configured Safe Transaction Service base URL
+func safeServiceURL() string {
+	if safeTxServiceURL != "" {
+		return strings.TrimRight(safeTxServiceURL, "/")
+	}
+	return defaultSafeTxServiceURL
+}
+
+// description summarises what a Safe transaction does
+func (tx safeMultisigTx) description() string {
+	action := "call"
+	if tx.DataDecoded != nil && tx.DataDecoded.Method != "" {
+		action = tx.DataDecoded.Method
+	} else if tx.Data == nil || *tx.Data == "0x" {
+		action = "transfer"
+	}
+	return fmt.Sprintf("%s to %s, value %s wei", action, tx.To.Hex(), tx.Value)
+}
+
+// hash recomputes the EIP-712 SafeTx hash so the service's hash can be checked
+// before signing it.
+func (tx safeMultisigTx) hash(chainID *big.Int) (common.Hash, error) {
+	data := "0x"
+	if tx.Data != nil {
+		data = *tx.Data
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: tx.Safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value,
+			"data":           data,
+			"operation":      fmt.Sprint(tx.Operation),
+			"safeTxGas":      tx.SafeTxGas.String(),
+			"baseGas":        tx.BaseGas.String(),
+			"gasPrice":       tx.GasPrice,
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          tx.Nonce.String(),
+		},
+	}
+
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash Safe transaction: %w", err)
+	}
+	return common.BytesToHash(digest), nil
+}
+
+func safeListPending(c *cli.Context) error {
+	safeAddress, err := parseAddress(c.String("safe"))
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Results []safeMultisigTx `json:"results"`
+	}
+	err = httpGetJSON(fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/?executed=false", safeServiceURL(), safeAddress.Hex()), &response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Results) == 0 {
+		fmt.Println("No pending transactions.")
+		return nil
+	}
+
+	for _, tx := range response.Results {
+		fmt.Printf("Nonce %s: %s\n", tx.Nonce.String(), tx.description())
+		fmt.Printf("  Safe tx hash:  %s\n", tx.SafeTxHash)
+		fmt.Printf("  Confirmations: %d of %d\n", len(tx.Confirmations), tx.ConfirmationsRequired)
+		for _, confirmation := range tx.Confirmations {
+			fmt.Printf("    %s %s\n", confirmation.Owner.Hex(), confirmation.Signature)
+		}
+	}
+	return nil
+}
+
+func safeSignPending(c *cli.Context) error {
+	fromIndex := c.Int("from")
+	safeTxHash := common.HexToHash(c.String("safe-tx-hash"))
+
+	safeAddress, err := parseAddress(c.String("safe"))
+	if err != nil {
+		return err
+	}
+
+	var tx safeMultisigTx
+	err = httpGetJSON(fmt.Sprintf("%s/api/v1/multisig-transactions/%s/", safeServiceURL(), safeTxHash.Hex()), &tx)
+	if err != nil {
+		return err
+	}
+	if tx.Safe != safeAddress {
+		return fmt.Errorf("transaction %s belongs to Safe %s, not %s", safeTxHash.Hex(), tx.Safe.Hex(), safeAddress.Hex())
+	}
+	if tx.IsExecuted {
+		return fmt.Errorf("transaction %s has already been executed", safeTxHash.Hex())
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	// Never sign a hash that does not match the transaction shown to the user
+	computed, err := tx.hash(chainID)
+	if err != nil {
+		return err
+	}
+	if computed != safeTxHash {
+		return fmt.Errorf("service returned a transaction hashing to %s, not %s", computed.Hex(), safeTxHash.Hex())
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+	for _, confirmation := range tx.Confirmations {
+		if confirmation.Owner == account.Address {
+			return fmt.Errorf("%s has already confirmed this transaction", account.Address.Hex())
+		}
+	}
+
+	signature, err := signHash(keyStore, account, safeTxHash.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to sign Safe transaction: %w", err)
+	}
+	signature[64] += 27 // Safe expects the Ethereum-style recovery ID
+
+	err = httpPostJSON(fmt.Sprintf("%s/api/v1/multisig-transactions/%s/confirmations/", safeServiceURL(), safeTxHash.Hex()), map[string]string{
+		"signature": hexutil.Encode(signature),
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Confirmed nonce %s: %s\n", tx.Nonce.String(), tx.description())
+	fmt.Printf("Confirmations: %d of %d\n", len(tx.Confirmations)+1, tx.ConfirmationsRequired)
+	return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Uniswap V2 style routers on Ethereum mainnet and the factory each one uses
+var v2Routers = map[common.Address]struct {
+	name    string
+	factory common.Address
+}{
+	common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"): {"Uniswap V2", common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")},
+	common.HexToAddress("0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F"): {"SushiSwap", common.HexToAddress("0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac")},
+}
+
+const v2RouterABI = `[
+  {
+    "inputs": [
+      { "name": "amountIn", "type": "uint256" },
+      { "name": "amountOutMin", "type": "uint256" },
+      { "name": "path", "type": "address[]" },
+      { "name": "to", "type": "address" },
+      { "name": "deadline", "type": "uint256" }
+    ],
+    "name": "swapExactTokensForTokens",
+    "outputs": [{ "name": "amounts", "type": "uint256[]" }],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "amountIn", "type": "uint256" },
+      { "name": "amountOutMin", "type": "uint256" },
+      { "name": "path", "type": "address[]" },
+      { "name": "to", "type": "address" },
+      { "name": "deadline", "type": "uint256" }
+    ],
+    "name": "swapExactTokensForETH",
+    "outputs": [{ "name": "amounts", "type": "uint256[]" }],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "amountOutMin", "type": "uint256" },
+      { "name": "path", "type": "address[]" },
+      { "name": "to", "type": "address" },
+      { "name": "deadline", "type": "uint256" }
+    ],
+    "name": "swapExactETHForTokens",
+    "outputs": [{ "name": "amounts", "type": "uint256[]" }],
+    "stateMutability": "payable",
+    "type": "function"
+  }
+]`
+
+const v2PairABI = `[
+  {
+    "inputs": [
+      { "name": "", "type": "address" },
+      { "name": "", "type": "address" }
+    ],
+    "name": "getPair",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "getReserves",
+    "outputs": [
+      { "name": "reserve0", "type": "uint112" },
+      { "name": "reserve1", "type": "uint112" },
+      { "name": "blockTimestampLast", "type": "uint32" }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var (
+	v2Router = mustParseABI(v2RouterABI)
+	v2Pair   = mustParseABI(v2PairABI)
+)
+
+// v2Reserves returns the reserves of the pair for tokenIn/tokenOut, ordered
+// as (reserveIn, reserveOut).
+func v2Reserves(client *ethclient.Client, factory, tokenIn, tokenOut common.Address) (*big.Int, *big.Int, error) {
+	result, err := callContract(client, factory, v2Pair, "getPair", tokenIn, tokenOut)
+	if err != nil {
+		return nil, nil, err
+	}
+	pair := result[0].(common.Address)
+	if pair == (common.Address{}) {
+		return nil, nil, fmt.Errorf("no pair for %s/%s", tokenIn.Hex(), tokenOut.Hex())
+	}
+
+	result, err = callContract(client, pair, v2Pair, "getReserves")
+	if err != nil {
+		return nil, nil, err
+	}
+	reserve0, reserve1 := result[0].(*big.Int), result[1].(*big.Int)
+
+	// The pair sorts its tokens by address
+	if bytes.Compare(tokenIn.Bytes(), tokenOut.Bytes()) < 0 {
+		return reserve0, reserve1, nil
+	}
+	return reserve1, reserve0, nil
+}
+
+// v2AmountOut applies the constant product formula with the 0.3% pool fee
+func v2AmountOut(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(997))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Add(new(big.Int).Mul(reserveIn, big.NewInt(1000)), amountInWithFee)
+	return numerator.Div(numerator, denominator)
+}
+
+func sandwichRisk(c *cli.Context) error {
+	rawTx, err := hexutil.Decode(c.String("tx-hex"))
+	if err != nil {
+		return fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if tx.To() == nil {
+		return fmt.Errorf("transaction is a contract deployment, not a swap")
+	}
+
+	router, ok := v2Routers[*tx.To()]
+	if !ok || len(tx.Data()) < 4 {
+		fmt.Println("Transaction is not a call to a known Uniswap V2 style router; no swap to analyze.")
+		return nil
+	}
+
+	method, err := v2Router.MethodById(tx.Data()[:4])
+	if err != nil {
+		fmt.Printf("Router call to %s is not an exact-input swap; no analysis available.\n", router.name)
+		return nil
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return fmt.Errorf("failed to decode %s arguments: %w", method.Name, err)
+	}
+
+	var amountIn, amountOutMin *big.Int
+	var path []common.Address
+	if method.Name == "swapExactETHForTokens" {
+		amountIn = tx.Value()
+		amountOutMin = args[0].(*big.Int)
+		path = args[1].([]common.Address)
+	} else {
+		amountIn = args[0].(*big.Int)
+		amountOutMin = args[1].(*big.Int)
+		path = args[2].([]common.Address)
+	}
+	if len(path) < 2 {
+		return fmt.Errorf("swap path is too short")
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	// Walk the path to get the expected output and the output at the spot
+	// price (no price impact, fees included).
+	expectedOut := new(big.Int).Set(amountIn)
+	spotOut := new(big.Float).SetInt(amountIn)
+	for i := 0; i < len(path)-1; i++ {
+		reserveIn, reserveOut, err := v2Reserves(client, router.factory, path[i], path[i+1])
+		if err != nil {
+			return err
+		}
+		expectedOut = v2AmountOut(expectedOut, reserveIn, reserveOut)
+		spotOut.Mul(spotOut, new(big.Float).Quo(new(big.Float).SetInt(reserveOut), new(big.Float).SetInt(reserveIn)))
+		spotOut.Mul(spotOut, big.NewFloat(0.997))
+	}
+
+	if expectedOut.Sign() == 0 {
+		return fmt.Errorf("pool returns nothing for this trade")
+	}
+	spot, _ := spotOut.Float64()
+	expected, _ := new(big.Float).SetInt(expectedOut).Float64()
+	minimum, _ := new(big.Float).SetInt(amountOutMin).Float64()
+
+	priceImpact := (1 - expected/spot) * 100
+	tolerance := (1 - minimum/expected) * 100
+
+	// A sandwicher can move the price until the victim receives exactly
+	// amountOutMin, so the slack between expected and minimum output is what
+	// can be extracted. Large trades make the attack worth its gas.
+	score := tolerance*20 + priceImpact*10
+	if score > 100 {
+		score = 100
+	}
+	if score < 0 {
+		score = 0
+	}
+
+	pathNames := make([]string, len(path))
+	for i, token := range path {
+		pathNames[i] = token.Hex()
+	}
+
+	fmt.Printf("Router:             %s (%s)\n", router.name, method.Name)
+	fmt.Printf("Path:               %s\n", strings.Join(pathNames, " -> "))
+	fmt.Printf("Amount in:          %s\n", amountIn.String())
+	fmt.Printf("Expected output:    %s\n", expectedOut.String())
+	fmt.Printf("Minimum output:     %s\n", amountOutMin.String())
+	fmt.Printf("Price impact:       %.3f%%\n", priceImpact)
+	fmt.Printf("Slippage tolerance: %.3f%%\n", tolerance)
+
+	risk := "LOW"
+	if score >= 60 {
+		risk = "HIGH"
+	} else if score >= 20 {
+		risk = "MEDIUM"
+	}
+	fmt.Printf("Sandwich risk:      %s (%.0f/100)\n", risk, score)
+
+	if risk != "LOW" {
+		// Suggest a minimum output 0.5% below the current expected output
+		suggested := new(big.Int).Mul(expectedOut, big.NewInt(995))
+		suggested.Div(suggested, big.NewInt(1000))
+		fmt.Printf("Suggestion: tighten slippage to 0.5%% (amountOutMin %s) or use a private mempool.\n", suggested.String())
+	}
+	return nil
+}
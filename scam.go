@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Scam "eth-manage/scam"
+)
+
+const (
+	blocklistFile   = "blocklist.json"
+	cryptoScamDBAPI = "https://api.cryptoscamdb.org/v1/check"
+
+	// defaultBlocklistURL is ScamSniffer's public list of scam addresses
+	defaultBlocklistURL = "https://raw.githubusercontent.com/scamsniffer/scam-database/main/blacklist/address.json"
+)
+
+// loadBlocklist loads the locally updated scam blocklist, or the embedded
+// one if update-blocklist has never been run.
+func loadBlocklist() (Scam.Blocklist, error) {
+	path, err := dataFilePath(blocklistFile)
+	if err != nil {
+		return nil, err
+	}
+	return Scam.Load(path)
+}
+
+// lookupCryptoScamDB checks an address against the CryptoScamDB API and
+// returns the reason it was flagged, or "" when it is not listed.
+func lookupCryptoScamDB(address common.Address) (string, error) {
+	var response struct {
+		Success bool `json:"success"`
+		Result  struct {
+			Status  string `json:"status"`
+			Entries []struct {
+				Name        string `json:"name"`
+				Category    string `json:"category"`
+				Description string `json:"description"`
+			} `json:"entries"`
+		} `json:"result"`
+	}
+
+	if err := httpGetJSON(cryptoScamDBAPI+"/"+address.Hex(), &response); err != nil {
+		return "", err
+	}
+	if !response.Success || response.Result.Status != "blocked" {
+		return "", nil
+	}
+
+	var reasons []string
+	for _, entry := range response.Result.Entries {
+		reason := strings.TrimSpace(entry.Category + " " + entry.Description)
+		if reason == "" {
+			reason = entry.Name
+		}
+		reasons = append(reasons, reason)
+	}
+	if len(reasons) == 0 {
+		return "flagged by CryptoScamDB", nil
+	}
+	return strings.Join(reasons, "; "), nil
+}
+
+// scamReason checks the blocklist and then CryptoScamDB, returning why an
+// address is flagged or "" if it is not. API failures are reported on stderr
+// and otherwise ignored so the check never blocks on an outage.
+func scamReason(address common.Address) (string, error) {
+	blocklist, err := loadBlocklist()
+	if err != nil {
+		return "", err
+	}
+	if len(blocklist) == 0 {
+		fmt.Fprintln(os.Stderr, "Warning: the scam blocklist is empty; run update-blocklist to download it")
+	}
+	if entry, ok := blocklist.Lookup(address); ok {
+		return entry.Reason, nil
+	}
+
+	reason, err := lookupCryptoScamDB(address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "CryptoScamDB lookup failed: %v\n", err)
+		return "", nil
+	}
+	return reason, nil
+}
+
+func checkScam(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	reason, err := scamReason(tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	if reason == "" {
+		fmt.Printf("%s is not flagged\n", tokenAddress.Hex())
+		return nil
+	}
+	fmt.Printf("%s is FLAGGED: %s\n", tokenAddress.Hex(), reason)
+	return nil
+}
+
+func updateBlocklist(c *cli.Context) error {
+	url := c.String("url")
+
+	data, err := httpGet(url)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to overwrite the local copy with something we cannot read back
+	blocklist, err := Scam.Parse(data)
+	if err != nil {
+		return err
+	}
+	if len(blocklist) == 0 {
+		return fmt.Errorf("blocklist at %s has no addresses", url)
+	}
+
+	// Stored in the entry format, with the feed as the source of entries
+	// that do not name one
+	entries := blocklist.Entries()
+	for i := range entries {
+		if entries[i].Source == "" {
+			entries[i].Source = url
+		}
+	}
+	data, err = json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode blocklist: %w", err)
+	}
+
+	path, err := dataFilePath(blocklistFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write blocklist: %w", err)
+	}
+
+	fmt.Printf("Blocklist updated with %d addresses: %s\n", len(blocklist), path)
+	return nil
+}
@@ -0,0 +1,80 @@
+package scam
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed blocklist.json
+var embeddedBlocklist []byte
+
+// Entry describes a flagged scam or phishing address
+type Entry struct {
+	Address common.Address `json:"address"`
+	Reason  string         `json:"reason"`
+	Source  string         `json:"source,omitempty"`
+}
+
+// Blocklist indexes flagged addresses
+type Blocklist map[common.Address]Entry
+
+// Load reads the blocklist from path, falling back to the copy embedded in
+// the binary when the file does not exist.
+func Load(path string) (Blocklist, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Parse(embeddedBlocklist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blocklist: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a JSON list of blocklist entries, or a JSON list of bare
+// addresses such as ScamSniffer's blacklist/address.json, whose entries get a
+// generic reason
+func Parse(data []byte) (Blocklist, error) {
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var addresses []common.Address
+		if json.Unmarshal(data, &addresses) != nil {
+			return nil, fmt.Errorf("failed to parse blocklist: %w", err)
+		}
+		entries = make([]Entry, len(addresses))
+		for i, address := range addresses {
+			entries[i] = Entry{Address: address, Reason: "listed as a scam address"}
+		}
+	}
+
+	blocklist := make(Blocklist, len(entries))
+	for _, entry := range entries {
+		blocklist[entry.Address] = entry
+	}
+	return blocklist, nil
+}
+
+// Lookup returns the blocklist entry for address, if any
+func (b Blocklist) Lookup(address common.Address) (Entry, bool) {
+	entry, ok := b[address]
+	return entry, ok
+}
+
+// Entries lists the blocklist in address order
+func (b Blocklist) Entries() []Entry {
+	entries := make([]Entry, 0, len(b))
+	for _, entry := range b {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Address[:], entries[j].Address[:]) < 0
+	})
+	return entries
+}
@@ -0,0 +1,65 @@
+package scam
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParse(t *testing.T) {
+	flagged := common.HexToAddress("0x00000000000000000000000000000000000000AA")
+	other := common.HexToAddress("0x00000000000000000000000000000000000000BB")
+
+	tests := []struct {
+		name   string
+		data   string
+		reason string
+	}{
+		{"entries", `[{"address": "0x00000000000000000000000000000000000000aa", "reason": "fake airdrop", "source": "manual"}]`, "fake airdrop"},
+		{"bare addresses", `["0x00000000000000000000000000000000000000aa"]`, "listed as a scam address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocklist, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry, ok := blocklist.Lookup(flagged)
+			if !ok || entry.Reason != tt.reason {
+				t.Errorf("Lookup(%s) = %+v, %v, want reason %q", flagged.Hex(), entry, ok, tt.reason)
+			}
+			if _, ok := blocklist.Lookup(other); ok {
+				t.Errorf("%s flagged", other.Hex())
+			}
+		})
+	}
+
+	for _, data := range []string{`{"address": "0x00000000000000000000000000000000000000aa"}`, `["not an address"]`} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want an error", data)
+		}
+	}
+}
+
+func TestEntries(t *testing.T) {
+	blocklist, err := Parse([]byte(`["0x00000000000000000000000000000000000000cc", "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := blocklist.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for i, want := range []string{"0xaa", "0xbb", "0xcc"} {
+		if entries[i].Address != common.HexToAddress(want) {
+			t.Errorf("entry %d = %s, want %s", i, entries[i].Address.Hex(), want)
+		}
+	}
+}
+
+func TestEmbeddedBlocklist(t *testing.T) {
+	if _, err := Parse(embeddedBlocklist); err != nil {
+		t.Fatal(err)
+	}
+}
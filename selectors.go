@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const selectorsFile = "selectors.json"
+
+// seedFunctionSignatures pre-seed the registry with common ERC-20, ERC-721,
+// WETH and Uniswap functions
+var seedFunctionSignatures = []string{
+	// ERC-20
+	"name()",
+	"symbol()",
+	"decimals()",
+	"totalSupply()",
+	"balanceOf(address)",
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	"allowance(address,address)",
+	// ERC-721
+	"ownerOf(uint256)",
+	"tokenURI(uint256)",
+	"getApproved(uint256)",
+	"setApprovalForAll(address,bool)",
+	"isApprovedForAll(address,address)",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	// WETH
+	"deposit()",
+	"withdraw(uint256)",
+	// Uniswap V2 router
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+	"swapExactETHForTokens(uint256,address[],address,uint256)",
+	"swapETHForExactTokens(uint256,address[],address,uint256)",
+	"swapExactTokensForETH(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactETH(uint256,uint256,address[],address,uint256)",
+	"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+	"addLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+	// Uniswap V3 router and Universal Router
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactInput((bytes,address,uint256,uint256,uint256))",
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactOutput((bytes,address,uint256,uint256,uint256))",
+	"multicall(bytes[])",
+	"multicall(uint256,bytes[])",
+	"execute(bytes,bytes[])",
+	"execute(bytes,bytes[],uint256)",
+}
+
+// functionSelector returns the hex selector of a function signature
+func functionSelector(signature string) string {
+	return hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// parseSelector normalizes a 4-byte hex selector
+func parseSelector(value string) (string, error) {
+	selector, err := hexutil.Decode(value)
+	if err != nil || len(selector) != 4 {
+		return "", fmt.Errorf("invalid selector %q, expected 4 bytes of hex", value)
+	}
+	return hexutil.Encode(selector), nil
+}
+
+// loadSelectorRegistry reads the registered function signatures by
+// selector. Without a registry file the seeded signatures are returned.
+func loadSelectorRegistry() (map[string]string, error) {
+	path, err := dataFilePath(selectorsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := map[string]string{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		for _, signature := range seedFunctionSignatures {
+			registry[functionSelector(signature)] = signature
+		}
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read function registry: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse function registry: %w", err)
+	}
+	return registry, nil
+}
+
+// saveSelectorRegistry writes the registered function signatures
+func saveSelectorRegistry(registry map[string]string) error {
+	path, err := dataFilePath(selectorsFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode function registry: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write function registry: %w", err)
+	}
+	return nil
+}
+
+// registeredFunction returns the locally registered signature of a selector
+func registeredFunction(selector string) (string, bool) {
+	registry, err := loadSelectorRegistry()
+	if err != nil {
+		return "", false
+	}
+	signature, ok := registry[strings.ToLower(selector)]
+	return signature, ok
+}
+
+func registerFunction(c *cli.Context) error {
+	selector, err := parseSelector(c.String("selector"))
+	if err != nil {
+		return err
+	}
+	signature := strings.ReplaceAll(c.String("signature"), " ", "")
+
+	// Names may be registered for selectors whose signature is unknown, so a
+	// mismatch is only reported
+	if computed := functionSelector(signature); computed != selector {
+		fmt.Printf("Warning: the selector of %s is %s, not %s\n", signature, computed, selector)
+	}
+
+	registry, err := loadSelectorRegistry()
+	if err != nil {
+		return err
+	}
+	if previous, ok := registry[selector]; ok && previous != signature {
+		fmt.Printf("Replacing %s\n", previous)
+	}
+	registry[selector] = signature
+	if err := saveSelectorRegistry(registry); err != nil {
+		return err
+	}
+
+	fmt.Printf("Registered %s %s\n", selector, signature)
+	return nil
+}
+
+func listRegisteredFunctions(c *cli.Context) error {
+	registry, err := loadSelectorRegistry()
+	if err != nil {
+		return err
+	}
+
+	selectors := make([]string, 0, len(registry))
+	for selector := range registry {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+
+	for _, selector := range selectors {
+		fmt.Printf("%s %s\n", selector, registry[selector])
+	}
+	return nil
+}
+
+func unregisterFunction(c *cli.Context) error {
+	selector, err := parseSelector(c.String("selector"))
+	if err != nil {
+		return err
+	}
+
+	registry, err := loadSelectorRegistry()
+	if err != nil {
+		return err
+	}
+	signature, ok := registry[selector]
+	if !ok {
+		return fmt.Errorf("no function registered for %s", selector)
+	}
+	delete(registry, selector)
+	if err := saveSelectorRegistry(registry); err != nil {
+		return err
+	}
+
+	fmt.Printf("Unregistered %s %s\n", selector, signature)
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/urfave/cli/v2"
+)
+
+const opSelfdestruct = 0xff
+
+// dencunConfigs are the chains whose Cancun (Dencun) activation time is known
+var dencunConfigs = []*params.ChainConfig{
+	params.MainnetChainConfig,
+	params.SepoliaChainConfig,
+	params.HoleskyChainConfig,
+}
+
+// callFrame is the subset of callTracer output needed to find SELFDESTRUCT
+type callFrame struct {
+	Type  string      `json:"type"`
+	Error string      `json:"error"`
+	Calls []callFrame `json:"calls"`
+}
+
+// selfdestructOffsets returns the offsets of SELFDESTRUCT opcodes in code,
+// skipping PUSH operands. Trailing metadata is stripped first because it is
+// data, not code.
+func selfdestructOffsets(code []byte) []int {
+	code = stripMetadata(code)
+
+	var offsets []int
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op == opSelfdestruct {
+			offsets = append(offsets, pc)
+		}
+		if op >= 0x60 && op <= 0x7f {
+			pc += int(op-0x60) + 1
+		}
+	}
+	return offsets
+}
+
+// dencunTime returns the Cancun activation time of a chain
+func dencunTime(chainID uint64) (uint64, bool) {
+	for _, config := range dencunConfigs {
+		if config.ChainID.Uint64() == chainID && config.CancunTime != nil {
+			return *config.CancunTime, true
+		}
+	}
+	return 0, false
+}
+
+// tracedSelfdestruct reports whether a call frame or any of its children
+// executed SELFDESTRUCT.
+func tracedSelfdestruct(frame callFrame) bool {
+	if frame.Type == "SELFDESTRUCT" {
+		return true
+	}
+	for _, child := range frame.Calls {
+		if tracedSelfdestruct(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSelfdestruct(c *cli.Context) error {
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	data, err := hexutil.Decode(c.String("data"))
+	if err != nil {
+		return fmt.Errorf("invalid call data: %w", err)
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	code, err := client.CodeAt(context.Background(), contractAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code: %w", err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%s has no code", contractAddress.Hex())
+	}
+
+	offsets := selfdestructOffsets(code)
+	if len(offsets) == 0 {
+		fmt.Printf("%s contains no SELFDESTRUCT opcode\n", contractAddress.Hex())
+		return nil
+	}
+	fmt.Printf("%s contains SELFDESTRUCT at offsets %v\n", contractAddress.Hex(), offsets)
+
+	// A synthetic call shows whether SELFDESTRUCT is reachable without any
+	// special caller or arguments. Tracing needs the debug namespace.
+	var trace callFrame
+	err = client.Client().CallContext(context.Background(), &trace, "debug_traceCall",
+		map[string]interface{}{
+			"from": common.Address{},
+			"to":   contractAddress,
+			"data": hexutil.Bytes(data),
+		},
+		"latest",
+		map[string]interface{}{"tracer": "callTracer"},
+	)
+	switch {
+	case err != nil:
+		fmt.Printf("Trace: unavailable (%v)\n", err)
+	case tracedSelfdestruct(trace):
+		fmt.Println("Trace: a call from the zero address with the given data reaches SELFDESTRUCT")
+	case trace.Error != "":
+		fmt.Printf("Trace: the synthetic call failed (%s) before reaching SELFDESTRUCT\n", trace.Error)
+	default:
+		fmt.Println("Trace: the synthetic call does not reach SELFDESTRUCT")
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	activation, known := dencunTime(chainID.Uint64())
+
+	creation, err := findContractCreation(client, contractAddress, c.Int("max-binary-search-depth"))
+	if err != nil {
+		fmt.Printf("Deployment: unknown (%v)\n", err)
+	} else {
+		deployed := time.Unix(int64(creation.timestamp), 0).UTC()
+		relation := "relative to Dencun unknown on this chain"
+		if known && creation.timestamp >= activation {
+			relation = "after Dencun"
+		} else if known {
+			relation = "before Dencun"
+		}
+		fmt.Printf("Deployment: block %d at %s (%s)\n", creation.block, deployed.Format(time.RFC3339), relation)
+	}
+
+	fmt.Println()
+	fmt.Println("Since Dencun (EIP-6780), SELFDESTRUCT only deletes code and storage when it runs in the")
+	fmt.Println("transaction that created the contract. Called later, it just sends the contract's ETH")
+	fmt.Println("to the beneficiary: the code stays, storage is kept, and the address cannot be redeployed")
+	fmt.Println("with different code through CREATE2. Upgrade or kill-switch patterns relying on")
+	fmt.Println("destroy-and-redeploy no longer work for this contract.")
+	return nil
+}
@@ -0,0 +1,178 @@
+//go:build pkcs11
+
+package signer
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/miekg/pkcs11"
+)
+
+// secp256k1 group order, used to normalise signatures to low-s (EIP-2)
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// PKCS11Signer signs with a secp256k1 key held in a hardware security module
+type PKCS11Signer struct {
+	ctx       *pkcs11.Ctx
+	session   pkcs11.SessionHandle
+	key       pkcs11.ObjectHandle
+	publicKey []byte // uncompressed, 65 bytes
+	address   common.Address
+}
+
+// NewPKCS11Signer loads the PKCS#11 library, logs in to slot with pin and
+// uses the first EC key pair found on the token.
+func NewPKCS11Signer(lib string, slot uint, pin string) (*PKCS11Signer, error) {
+	ctx := pkcs11.New(lib)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 library %s", lib)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialise PKCS#11 library: %w", err)
+	}
+
+	s := &PKCS11Signer{ctx: ctx}
+	if err := s.open(slot, pin); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *PKCS11Signer) open(slot uint, pin string) error {
+	session, err := s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open session on slot %d: %w", slot, err)
+	}
+	s.session = session
+
+	if err := s.ctx.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		return fmt.Errorf("failed to log in to slot %d: %w", slot, err)
+	}
+
+	key, err := s.findObject([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+	})
+	if err != nil {
+		return fmt.Errorf("no EC private key found: %w", err)
+	}
+	s.key = key
+
+	// The public point lives on the matching public key object
+	attrs, err := s.ctx.GetAttributeValue(session, key, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_ID, nil)})
+	if err != nil {
+		return fmt.Errorf("failed to read key ID: %w", err)
+	}
+	publicKey, err := s.findObject([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, attrs[0].Value),
+	})
+	if err != nil {
+		return fmt.Errorf("no public key found for the private key: %w", err)
+	}
+
+	attrs, err = s.ctx.GetAttributeValue(session, publicKey, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	// CKA_EC_POINT is a DER OCTET STRING, though some modules return the raw point
+	point := attrs[0].Value
+	var unwrapped []byte
+	if _, err := asn1.Unmarshal(point, &unwrapped); err == nil {
+		point = unwrapped
+	}
+
+	pub, err := crypto.UnmarshalPubkey(point)
+	if err != nil {
+		return fmt.Errorf("key is not a secp256k1 key: %w", err)
+	}
+	s.publicKey = crypto.FromECDSAPub(pub)
+	s.address = crypto.PubkeyToAddress(*pub)
+	return nil
+}
+
+func (s *PKCS11Signer) findObject(template []*pkcs11.Attribute) (pkcs11.ObjectHandle, error) {
+	if err := s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return 0, err
+	}
+	defer s.ctx.FindObjectsFinal(s.session)
+
+	objects, _, err := s.ctx.FindObjects(s.session, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("object not found")
+	}
+	return objects[0], nil
+}
+
+func (s *PKCS11Signer) Address() common.Address {
+	return s.address
+}
+
+// SignHash returns a 65-byte [R || S || V] signature of hash, with V as the
+// recovery ID (0 or 1).
+func (s *PKCS11Signer) SignHash(hash []byte) ([]byte, error) {
+	if err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, s.key); err != nil {
+		return nil, fmt.Errorf("failed to start signing: %w", err)
+	}
+	rs, err := s.ctx.Sign(s.session, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("unexpected signature length %d", len(rs))
+	}
+
+	// HSMs don't enforce low-s, which Ethereum requires
+	sValue := new(big.Int).SetBytes(rs[32:])
+	if sValue.Cmp(secp256k1HalfN) > 0 {
+		sValue.Sub(secp256k1N, sValue)
+	}
+
+	signature := make([]byte, 65)
+	copy(signature, rs[:32])
+	sValue.FillBytes(signature[32:64])
+
+	// The HSM doesn't return the recovery ID, so find the one matching our key
+	for v := byte(0); v < 2; v++ {
+		signature[64] = v
+		recovered, err := crypto.Ecrecover(hash, signature)
+		if err == nil && bytes.Equal(recovered, s.publicKey) {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("signature does not recover to %s", s.address.Hex())
+}
+
+func (s *PKCS11Signer) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	txSigner := types.LatestSignerForChainID(chainID)
+	signature, err := s.SignHash(txSigner.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, signature)
+}
+
+// Close logs out and releases the PKCS#11 library
+func (s *PKCS11Signer) Close() {
+	if s.session != 0 {
+		s.ctx.Logout(s.session)
+		s.ctx.CloseSession(s.session)
+	}
+	s.ctx.Finalize()
+	s.ctx.Destroy()
+}
@@ -0,0 +1,33 @@
+//go:build !pkcs11
+
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PKCS11Signer is unavailable unless the binary is built with -tags pkcs11,
+// which needs cgo and github.com/miekg/pkcs11.
+type PKCS11Signer struct{}
+
+func NewPKCS11Signer(lib string, slot uint, pin string) (*PKCS11Signer, error) {
+	return nil, fmt.Errorf("HSM support is not compiled in, rebuild with -tags pkcs11")
+}
+
+func (s *PKCS11Signer) Address() common.Address {
+	return common.Address{}
+}
+
+func (s *PKCS11Signer) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, fmt.Errorf("HSM support is not compiled in")
+}
+
+func (s *PKCS11Signer) SignHash(hash []byte) ([]byte, error) {
+	return nil, fmt.Errorf("HSM support is not compiled in")
+}
+
+func (s *PKCS11Signer) Close() {}
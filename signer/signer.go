@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer signs transactions and hashes for a single account
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignHash returns a 65-byte [R || S || V] signature of a 32-byte hash,
+	// with V as the recovery ID (0 or 1)
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// KeystoreSigner signs with an unlocked account of a local keystore
+type KeystoreSigner struct {
+	keyStore *keystore.KeyStore
+	account  accounts.Account
+}
+
+// NewKeystoreSigner wraps an account that is already unlocked in keyStore
+func NewKeystoreSigner(keyStore *keystore.KeyStore, account accounts.Account) *KeystoreSigner {
+	return &KeystoreSigner{keyStore: keyStore, account: account}
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *KeystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.keyStore.SignTx(s.account, tx, chainID)
+}
+
+func (s *KeystoreSigner) SignHash(hash []byte) ([]byte, error) {
+	return s.keyStore.SignHash(s.account, hash)
+}
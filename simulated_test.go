@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+// testChain is a simulated chain with funded keystore accounts, wired into
+// the globals the commands read
+type testChain struct {
+	backend  *simulated.Backend
+	client   *ethclient.Client
+	keyStore *keystore.KeyStore
+	accounts []accounts.Account
+}
+
+// newTestChain starts a simulated chain with count keystore accounts holding
+// 1000 ETH each, plus the genesis allocation alloc returns for them when alloc
+// is not nil. The keystore, chain ID and history file are pointed at the chain
+// and restored when the test ends.
+func newTestChain(t *testing.T, count int, alloc func(accounts []accounts.Account) types.GenesisAlloc) *testChain {
+	t.Helper()
+
+	savedDir, savedPassword, savedChainID, savedHistory := keystoreDir, keystorePassword, chainId, historyPath
+	t.Cleanup(func() {
+		closeHistory()
+		keystoreDir, keystorePassword, chainId, historyPath = savedDir, savedPassword, savedChainID, savedHistory
+	})
+	keystoreDir = t.TempDir()
+	keystorePassword = "test"
+	historyPath = filepath.Join(t.TempDir(), "history")
+
+	chain := &testChain{
+		keyStore: keystore.NewKeyStore(keystoreDir, keystore.LightScryptN, keystore.LightScryptP),
+	}
+
+	genesis := types.GenesisAlloc{}
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	for i := 0; i < count; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := chain.keyStore.ImportECDSA(key, keystorePassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := chain.keyStore.Unlock(account, keystorePassword); err != nil {
+			t.Fatal(err)
+		}
+		chain.accounts = append(chain.accounts, account)
+		genesis[account.Address] = types.Account{Balance: funds}
+	}
+
+	if alloc != nil {
+		for address, account := range alloc(chain.accounts) {
+			genesis[address] = account
+		}
+	}
+
+	chain.backend = simulated.NewBackend(genesis)
+	t.Cleanup(func() { chain.backend.Close() })
+
+	// The simulated client wraps an *ethclient.Client, which is what the
+	// command helpers take
+	chain.client = reflect.ValueOf(chain.backend.Client()).Field(0).Interface().(*ethclient.Client)
+
+	id, err := chain.client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainId = *id
+	return chain
+}
+
+// mine commits a block and returns the receipt of tx, failing the test if
+// it reverted
+func (chain *testChain) mine(t *testing.T, tx *types.Transaction) *types.Receipt {
+	t.Helper()
+	chain.backend.Commit()
+	receipt, err := waitForSuccess(chain.client, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return receipt
+}
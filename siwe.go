@@ -0,0 +1,198 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+const siweNonceAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// siweNonce returns a random alphanumeric nonce, as EIP-4361 requires at
+// least 8 characters of that alphabet.
+func siweNonce() (string, error) {
+	nonce := make([]byte, 17)
+	for i := range nonce {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(siweNonceAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		nonce[i] = siweNonceAlphabet[n.Int64()]
+	}
+	return string(nonce), nil
+}
+
+// siweMessage holds the fields of an EIP-4361 message
+type siweMessage struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime time.Time
+	NotBefore      time.Time
+}
+
+// String renders the message in the EIP-4361 plaintext format
+func (m siweMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s wants you to sign in with your Ethereum account:\n%s\n\n", m.Domain, m.Address.Hex())
+	if m.Statement != "" {
+		fmt.Fprintf(&b, "%s\n\n", m.Statement)
+	} else {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "URI: %s\nVersion: 1\nChain ID: %d\nNonce: %s\nIssued At: %s",
+		m.URI, m.ChainID, m.Nonce, m.IssuedAt.UTC().Format(time.RFC3339))
+	if !m.ExpirationTime.IsZero() {
+		fmt.Fprintf(&b, "\nExpiration Time: %s", m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	return b.String()
+}
+
+// parseSIWEMessage extracts the address and validity window of an EIP-4361
+// message. Fields other than those are not needed for verification.
+func parseSIWEMessage(text string) (siweMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], " wants you to sign in with your Ethereum account:") {
+		return siweMessage{}, fmt.Errorf("not an EIP-4361 message")
+	}
+
+	message := siweMessage{Domain: strings.TrimSuffix(lines[0], " wants you to sign in with your Ethereum account:")}
+	if !common.IsHexAddress(lines[1]) {
+		return siweMessage{}, fmt.Errorf("invalid address line: %s", lines[1])
+	}
+	message.Address = common.HexToAddress(lines[1])
+
+	for _, line := range lines[2:] {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch key {
+		case "URI":
+			message.URI = value
+		case "Nonce":
+			message.Nonce = value
+		case "Issued At":
+			message.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case "Expiration Time":
+			message.ExpirationTime, err = time.Parse(time.RFC3339, value)
+		case "Not Before":
+			message.NotBefore, err = time.Parse(time.RFC3339, value)
+		}
+		if err != nil {
+			return siweMessage{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return message, nil
+}
+
+func siweCreate(c *cli.Context) error {
+	index := c.Int("index")
+	expiry := c.Duration("expiry")
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, index)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := siweNonce()
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveChainID()
+	if err != nil {
+		return err
+	}
+
+	issuedAt := time.Now()
+	message := siweMessage{
+		Domain:    c.String("domain"),
+		Address:   account.Address,
+		Statement: c.String("statement"),
+		URI:       c.String("uri"),
+		ChainID:   id.Int64(),
+		Nonce:     nonce,
+		IssuedAt:  issuedAt,
+	}
+	if expiry > 0 {
+		message.ExpirationTime = issuedAt.Add(expiry)
+	}
+
+	text := message.String()
+	signature, err := signPersonalMessage(keyStore, account, []byte(text))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(text)
+	fmt.Println()
+	fmt.Printf("Signature: %s\n", hexutil.Encode(signature))
+	return nil
+}
+
+func siweVerify(c *cli.Context) error {
+	content, err := os.ReadFile(c.String("message-file"))
+	if err != nil {
+		return fmt.Errorf("failed to read message file: %w", err)
+	}
+	// Editors usually add a trailing newline that was not part of the signed message
+	text := strings.TrimRight(string(content), "\r\n")
+
+	signature, err := hexutil.Decode(c.String("signature"))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	expected, err := parseAddress(c.String("expected-address"))
+	if err != nil {
+		return err
+	}
+
+	message, err := parseSIWEMessage(text)
+	if err != nil {
+		return err
+	}
+
+	signer, err := recoverPersonalSigner([]byte(text), signature)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Domain:     %s\n", message.Domain)
+	fmt.Printf("Signer:     %s\n", signer.Hex())
+	if signer != expected {
+		return fmt.Errorf("signature was made by %s, expected %s", signer.Hex(), expected.Hex())
+	}
+	if message.Address != expected {
+		return fmt.Errorf("message is for %s, expected %s", message.Address.Hex(), expected.Hex())
+	}
+
+	now := time.Now()
+	if !message.ExpirationTime.IsZero() {
+		fmt.Printf("Expires:    %s\n", message.ExpirationTime.Format(time.RFC3339))
+		if now.After(message.ExpirationTime) {
+			return fmt.Errorf("message expired at %s", message.ExpirationTime.Format(time.RFC3339))
+		}
+	}
+	if !message.NotBefore.IsZero() && now.Before(message.NotBefore) {
+		return fmt.Errorf("message is not valid before %s", message.NotBefore.Format(time.RFC3339))
+	}
+
+	fmt.Println("Signature is valid")
+	return nil
+}
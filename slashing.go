@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	Beacon "eth-manage/beacon"
+)
+
+// Beacon chain slot time
+const slotDuration = 12 * time.Second
+
+// blockSlashings returns the validator indices slashed in a block, mapped to
+// the kind of slashing.
+func blockSlashings(block *Beacon.Block) map[string]string {
+	slashed := map[string]string{}
+	for _, slashing := range block.Message.Body.ProposerSlashings {
+		slashed[slashing.SignedHeader1.Message.ProposerIndex] = "proposer slashing"
+	}
+	for _, slashing := range block.Message.Body.AttesterSlashings {
+		for _, index := range slashing.SlashedIndices() {
+			slashed[index] = "attester slashing"
+		}
+	}
+	return slashed
+}
+
+func watchSlashing(c *cli.Context) error {
+	pubkeys, err := readLines(c.String("validator-pubkeys"))
+	if err != nil {
+		return err
+	}
+	if len(pubkeys) == 0 {
+		return fmt.Errorf("no validator public keys given")
+	}
+
+	client, err := newBeaconClient()
+	if err != nil {
+		return err
+	}
+
+	// Slashings identify validators by index, so look up each key once
+	watched := map[string]string{}
+	for _, pubkey := range pubkeys {
+		validator, err := client.Validator(strings.ToLower(pubkey))
+		if err != nil {
+			return fmt.Errorf("failed to get validator %s: %w", pubkey, err)
+		}
+		watched[validator.Index] = validator.Validator.Pubkey
+		if validator.Validator.Slashed {
+			fmt.Printf("Validator %s (%s) is already slashed\n", validator.Index, validator.Validator.Pubkey)
+		}
+	}
+
+	head, err := client.Block("head")
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %w", err)
+	}
+	lastSlot, err := strconv.ParseUint(head.Message.Slot, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid head slot %q: %w", head.Message.Slot, err)
+	}
+	fmt.Printf("Watching %d validators for slashings from slot %d\n", len(watched), lastSlot)
+
+	for {
+		time.Sleep(slotDuration)
+
+		head, err := client.Block("head")
+		if err != nil {
+			fmt.Printf("Failed to get head block: %v\n", err)
+			continue
+		}
+		headSlot, err := strconv.ParseUint(head.Message.Slot, 10, 64)
+		if err != nil {
+			fmt.Printf("Invalid head slot %q: %v\n", head.Message.Slot, err)
+			continue
+		}
+
+		for slot := lastSlot + 1; slot <= headSlot; slot++ {
+			block := head
+			if slot != headSlot {
+				block, err = client.Block(strconv.FormatUint(slot, 10))
+				if errors.Is(err, Beacon.ErrNotFound) {
+					// Missed slot
+					continue
+				}
+				if err != nil {
+					fmt.Printf("Failed to get block at slot %d: %v\n", slot, err)
+					headSlot = slot - 1
+					break
+				}
+			}
+
+			for index, kind := range blockSlashings(block) {
+				if pubkey, ok := watched[index]; ok {
+					fmt.Printf("ALERT: validator %s (%s) slashed by %s in slot %d\n", index, pubkey, kind, slot)
+				}
+			}
+		}
+		lastSlot = headSlot
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const snapshotGraphQLAPI = "https://hub.snapshot.org/graphql"
+
+// snapshotQuery runs a query against the Snapshot hub GraphQL API and decodes
+// its data field into out.
+func snapshotQuery(query string, variables map[string]interface{}, out interface{}) error {
+	var response struct {
+		Data   interface{} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	response.Data = out
+
+	err := httpPostJSON(snapshotGraphQLAPI, map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}, &response)
+	if err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("snapshot query failed: %s", response.Errors[0].Message)
+	}
+	return nil
+}
+
+func snapshotProposals(c *cli.Context) error {
+	space := c.String("space")
+
+	var data struct {
+		Proposals []struct {
+			ID          string    `json:"id"`
+			Title       string    `json:"title"`
+			End         int64     `json:"end"`
+			Votes       int       `json:"votes"`
+			Choices     []string  `json:"choices"`
+			Scores      []float64 `json:"scores"`
+			ScoresTotal float64   `json:"scores_total"`
+		} `json:"proposals"`
+	}
+
+	err := snapshotQuery(`query Proposals($space: String!) {
+  proposals(first: 100, where: { space: $space, state: "active" }, orderBy: "end", orderDirection: asc) {
+    id
+    title
+    end
+    votes
+    choices
+    scores
+    scores_total
+  }
+}`, map[string]interface{}{"space": space}, &data)
+	if err != nil {
+		return err
+	}
+
+	if len(data.Proposals) == 0 {
+		fmt.Printf("No open proposals in %s\n", space)
+		return nil
+	}
+
+	for _, proposal := range data.Proposals {
+		fmt.Printf("%s\n", proposal.Title)
+		fmt.Printf("  ID:    %s\n", proposal.ID)
+		fmt.Printf("  Ends:  %s\n", time.Unix(proposal.End, 0).UTC().Format(time.RFC3339))
+		fmt.Printf("  Votes: %d (%.2f voting power)\n", proposal.Votes, proposal.ScoresTotal)
+		for i, choice := range proposal.Choices {
+			if i < len(proposal.Scores) {
+				fmt.Printf("    %s: %.2f\n", choice, proposal.Scores[i])
+			}
+		}
+	}
+	return nil
+}
+
+func snapshotVotePower(c *cli.Context) error {
+	space := c.String("space")
+	proposalID := c.String("proposal-id")
+
+	voter, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	var data struct {
+		VP *struct {
+			VP           float64   `json:"vp"`
+			VPByStrategy []float64 `json:"vp_by_strategy"`
+			VPState      string    `json:"vp_state"`
+		} `json:"vp"`
+	}
+
+	err = snapshotQuery(`query VotingPower($voter: String!, $space: String!, $proposal: String!) {
+  vp(voter: $voter, space: $space, proposal: $proposal) {
+    vp
+    vp_by_strategy
+    vp_state
+  }
+}`, map[string]interface{}{"voter": voter.Hex(), "space": space, "proposal": proposalID}, &data)
+	if err != nil {
+		return err
+	}
+	if data.VP == nil {
+		return fmt.Errorf("no voting power returned for %s", voter.Hex())
+	}
+
+	fmt.Printf("Voting power of %s: %.4f (%s)\n", voter.Hex(), data.VP.VP, data.VP.VPState)
+	for i, vp := range data.VP.VPByStrategy {
+		fmt.Printf("  Strategy %d: %.4f\n", i, vp)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const sourcifyAPI = "https://sourcify.dev/server"
+
+// fetchSourcifyABI downloads the ABI of a contract verified on Sourcify
+func fetchSourcifyABI(chainID int64, address common.Address) (abi.ABI, error) {
+	var response struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+
+	err := httpGetJSON(fmt.Sprintf("%s/v2/contract/%d/%s?fields=abi", sourcifyAPI, chainID, address.Hex()), &response)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("contract is not verified on Sourcify: %w", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(response.ABI)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse Sourcify ABI: %w", err)
+	}
+	return parsed, nil
+}
+
+// fetchSourcifySources downloads the source files of a contract verified on
+// Sourcify, keyed by path.
+func fetchSourcifySources(chainID int64, address common.Address) (map[string]string, error) {
+	var response struct {
+		Sources map[string]struct {
+			Content string `json:"content"`
+		} `json:"sources"`
+	}
+
+	err := httpGetJSON(fmt.Sprintf("%s/v2/contract/%d/%s?fields=sources", sourcifyAPI, chainID, address.Hex()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("contract is not verified on Sourcify: %w", err)
+	}
+
+	sources := make(map[string]string, len(response.Sources))
+	for path, source := range response.Sources {
+		sources[path] = source.Content
+	}
+	return sources, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// storagePatterns describes the storage layouts known to storage-slot. Mapping
+// patterns give the base slot of the mapping; fixed patterns give the slot
+// itself.
+var storagePatterns = map[string]struct {
+	slot    common.Hash
+	mapping bool
+	keys    int
+}{
+	"erc20-balance":          {slot: common.BigToHash(big.NewInt(0)), mapping: true, keys: 1},
+	"erc20-allowance":        {slot: common.BigToHash(big.NewInt(1)), mapping: true, keys: 2},
+	"ownable-owner":          {slot: common.BigToHash(big.NewInt(0))},
+	"eip1967-implementation": {slot: common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")},
+	"eip1967-admin":          {slot: common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")},
+	"eip1967-beacon":         {slot: common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")},
+}
+
+// mappingSlot returns the storage slot of mapping[key] for a mapping stored
+// at slot: keccak256(abi.encodePacked(bytes32(key), bytes32(slot))).
+func mappingSlot(key common.Address, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(common.LeftPadBytes(key.Bytes(), 32), slot.Bytes())
+}
+
+func storageSlot(c *cli.Context) error {
+	patternName := c.String("pattern")
+
+	pattern, ok := storagePatterns[patternName]
+	if !ok {
+		return fmt.Errorf("unknown pattern %q", patternName)
+	}
+
+	slot := pattern.slot
+	if c.IsSet("custom-slot") {
+		custom, err := parseUint256(c.String("custom-slot"))
+		if err != nil {
+			return err
+		}
+		slot = common.BigToHash(custom)
+	}
+
+	if pattern.mapping {
+		key, err := parseAddress(c.String("key"))
+		if err != nil {
+			return fmt.Errorf("--key is required for %s: %w", patternName, err)
+		}
+		slot = mappingSlot(key, slot)
+
+		// allowance[owner][spender] hashes the spender into the inner mapping
+		if pattern.keys == 2 {
+			spender, err := parseAddress(c.String("key2"))
+			if err != nil {
+				return fmt.Errorf("--key2 (spender) is required for %s: %w", patternName, err)
+			}
+			slot = mappingSlot(spender, slot)
+		}
+	}
+
+	fmt.Printf("Slot: %s\n", slot.Hex())
+
+	if !c.IsSet("contract") {
+		return nil
+	}
+	contractAddress, err := parseAddress(c.String("contract"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	value, err := client.StorageAt(context.Background(), contractAddress, slot, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read storage: %w", err)
+	}
+
+	word := common.BytesToHash(value)
+	fmt.Printf("Value: %s\n", word.Hex())
+	number := new(big.Int).SetBytes(word.Bytes())
+	fmt.Printf("  as uint256: %s\n", number.String())
+	if number.BitLen() <= 160 {
+		fmt.Printf("  as address: %s\n", common.BytesToAddress(word.Bytes()).Hex())
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// supplyChangeLogs returns the mint and burn Transfer events of a token
+// between two blocks, in chain order.
+func supplyChangeLogs(client *ethclient.Client, token common.Address, fromBlock, toBlock uint64) ([]types.Log, error) {
+	zero := common.Hash{}
+
+	// Topic filters cannot OR across positions, so mints and burns are
+	// fetched separately.
+	mints, err := fetchLogs(client, []common.Address{token}, [][]common.Hash{{transferTopic}, {zero}}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	burns, err := fetchLogs(client, []common.Address{token}, [][]common.Hash{{transferTopic}, nil, {zero}}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := append(mints, burns...)
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, nil
+}
+
+func watchSupplyChanges(c *cli.Context) error {
+	fromBlock := c.Uint64("from-block")
+	live := c.Bool("live")
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	decimals, err := tokenDecimals(client, tokenAddress)
+	if err != nil {
+		return err
+	}
+
+	var writer *csv.Writer
+	if out := c.String("out"); out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+		defer file.Close()
+
+		writer = csv.NewWriter(file)
+		defer writer.Flush()
+		if err := writer.Write([]string{"block", "tx_hash", "type", "amount", "cumulative_change"}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cumulative := new(big.Int)
+	report := func(logs []types.Log) error {
+		for _, log := range logs {
+			// Transfers with both sides zero are neither a mint nor a burn
+			if len(log.Topics) < 3 || log.Topics[1] == log.Topics[2] {
+				continue
+			}
+
+			amount := new(big.Int).SetBytes(log.Data)
+			kind := "MINT"
+			if log.Topics[2] == (common.Hash{}) {
+				kind = "BURN"
+				cumulative.Sub(cumulative, amount)
+			} else {
+				cumulative.Add(cumulative, amount)
+			}
+
+			fmt.Printf("Block %d %s %s %s (cumulative %s)\n", log.BlockNumber, log.TxHash.Hex(), kind,
+				formatBigIntToDecimal(amount, decimals), formatBigIntToDecimal(cumulative, decimals))
+
+			if writer != nil {
+				err := writer.Write([]string{
+					strconv.FormatUint(log.BlockNumber, 10),
+					log.TxHash.Hex(),
+					kind,
+					formatBigIntToDecimal(amount, decimals),
+					formatBigIntToDecimal(cumulative, decimals),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to write CSV: %w", err)
+				}
+				writer.Flush()
+			}
+		}
+		return nil
+	}
+
+	latest, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	logs, err := supplyChangeLogs(client, tokenAddress, fromBlock, latest)
+	if err != nil {
+		return err
+	}
+	if err := report(logs); err != nil {
+		return err
+	}
+	if !live {
+		return nil
+	}
+
+	// The configured endpoint is HTTP, so live mode polls for new blocks
+	// instead of subscribing.
+	fmt.Printf("Watching for new mints and burns from block %d...\n", latest+1)
+	for {
+		time.Sleep(12 * time.Second)
+
+		head, err := client.BlockNumber(context.Background())
+		if err != nil {
+			fmt.Printf("Failed to get latest block: %v\n", err)
+			continue
+		}
+		if head <= latest {
+			continue
+		}
+
+		logs, err := supplyChangeLogs(client, tokenAddress, latest+1, head)
+		if err != nil {
+			fmt.Printf("Failed to get logs: %v\n", err)
+			continue
+		}
+		if err := report(logs); err != nil {
+			return err
+		}
+		latest = head
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// Uniswap V3 QuoterV2 on Ethereum mainnet
+const uniswapV3QuoterAddress = "0x61fFE014bA17989E743c5F6cB21bF9697530B21e"
+
+const uniswapV3QuoterABI = `[
+  {
+    "inputs": [
+      { "name": "path", "type": "bytes" },
+      { "name": "amountIn", "type": "uint256" }
+    ],
+    "name": "quoteExactInput",
+    "outputs": [
+      { "name": "amountOut", "type": "uint256" },
+      { "name": "sqrtPriceX96AfterList", "type": "uint160[]" },
+      { "name": "initializedTicksCrossedList", "type": "uint32[]" },
+      { "name": "gasEstimate", "type": "uint256" }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {
+        "components": [
+          { "name": "tokenIn", "type": "address" },
+          { "name": "tokenOut", "type": "address" },
+          { "name": "amountIn", "type": "uint256" },
+          { "name": "fee", "type": "uint24" },
+          { "name": "sqrtPriceLimitX96", "type": "uint160" }
+        ],
+        "name": "params",
+        "type": "tuple"
+      }
+    ],
+    "name": "quoteExactInputSingle",
+    "outputs": [
+      { "name": "amountOut", "type": "uint256" },
+      { "name": "sqrtPriceX96After", "type": "uint160" },
+      { "name": "initializedTicksCrossed", "type": "uint32" },
+      { "name": "gasEstimate", "type": "uint256" }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var uniswapV3Quoter = mustParseABI(uniswapV3QuoterABI)
+
+// swapPath is a parsed Uniswap V3 multi-hop route
+type swapPath struct {
+	tokens []common.Address
+	fees   []uint32
+}
+
+// quoteExactInputSingleParams mirrors QuoterV2.QuoteExactInputSingleParams
+type quoteExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	AmountIn          *big.Int
+	Fee               *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// parseSwapPath parses the `<token>-<fee>-<token>[-<fee>-<token>...]`
+// shorthand, where fees are in hundredths of a basis point (e.g. 3000 = 0.3%).
+func parseSwapPath(shorthand string) (swapPath, error) {
+	parts := strings.Split(shorthand, "-")
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return swapPath{}, fmt.Errorf("invalid swap path: expected <token>-<fee>-<token>[-<fee>-<token>...]")
+	}
+
+	var path swapPath
+	for i, part := range parts {
+		if i%2 == 0 {
+			token, err := parseAddress(part)
+			if err != nil {
+				return swapPath{}, fmt.Errorf("invalid token in swap path: %w", err)
+			}
+			path.tokens = append(path.tokens, token)
+			continue
+		}
+
+		fee, err := strconv.ParseUint(part, 10, 24)
+		if err != nil {
+			return swapPath{}, fmt.Errorf("invalid fee tier in swap path: %s", part)
+		}
+		path.fees = append(path.fees, uint32(fee))
+	}
+	return path, nil
+}
+
+// encode packs the path the way the Uniswap V3 router and quoter expect it:
+// 20-byte token addresses interleaved with 3-byte big-endian fee tiers.
+func (p swapPath) encode() []byte {
+	encoded := make([]byte, 0, len(p.tokens)*20+len(p.fees)*3)
+	for i, token := range p.tokens {
+		encoded = append(encoded, token.Bytes()...)
+		if i < len(p.fees) {
+			fee := p.fees[i]
+			encoded = append(encoded, byte(fee>>16), byte(fee>>8), byte(fee))
+		}
+	}
+	return encoded
+}
+
+// quoteExactInput returns the amount out and gas estimate for swapping amountIn
+// along path.
+func quoteExactInput(client *ethclient.Client, quoter common.Address, path swapPath, amountIn *big.Int) (*big.Int, *big.Int, error) {
+	result, err := callContract(client, quoter, uniswapV3Quoter, "quoteExactInput", path.encode(), amountIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result[0].(*big.Int), result[3].(*big.Int), nil
+}
+
+func simulateSwap(c *cli.Context) error {
+	quoter, err := parseAddress(c.String("quoter"))
+	if err != nil {
+		return err
+	}
+
+	path, err := parseSwapPath(c.String("path"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	// Look up symbols and decimals for every token on the route
+	symbols := make([]string, len(path.tokens))
+	decimals := make([]int, len(path.tokens))
+	for i, address := range path.tokens {
+		tokenContract, err := Token.ERCToken(address.Hex(), 0, client)
+		if err != nil {
+			return fmt.Errorf("failed to create token contract: %w", err)
+		}
+
+		tokenDecimals, err := tokenContract.Decimals()
+		if err != nil {
+			return fmt.Errorf("failed to get decimals of %s: %w", address.Hex(), err)
+		}
+		decimals[i] = int(tokenDecimals)
+
+		symbols[i], err = tokenContract.Symbol()
+		if err != nil {
+			symbols[i] = address.Hex()
+		}
+	}
+
+	amountInUnits, err := parseAmount(c.String("amount-in"), decimals[0])
+	if err != nil {
+		return err
+	}
+
+	// Quote each hop on its own to show the intermediate amounts
+	hopAmount := amountInUnits
+	for i, fee := range path.fees {
+		params := quoteExactInputSingleParams{
+			TokenIn:           path.tokens[i],
+			TokenOut:          path.tokens[i+1],
+			AmountIn:          hopAmount,
+			Fee:               big.NewInt(int64(fee)),
+			SqrtPriceLimitX96: big.NewInt(0),
+		}
+		result, err := callContract(client, quoter, uniswapV3Quoter, "quoteExactInputSingle", params)
+		if err != nil {
+			return fmt.Errorf("failed to quote hop %d: %w", i+1, err)
+		}
+		hopOut := result[0].(*big.Int)
+
+		fmt.Printf("Hop %d: %s %s -> %s %s (fee %.2f%%)\n", i+1,
+			formatBigIntToDecimal(hopAmount, decimals[i]), symbols[i],
+			formatBigIntToDecimal(hopOut, decimals[i+1]), symbols[i+1],
+			float64(fee)/10000)
+		hopAmount = hopOut
+	}
+
+	amountOut, gasEstimate, err := quoteExactInput(client, quoter, path, amountInUnits)
+	if err != nil {
+		return fmt.Errorf("failed to quote swap: %w", err)
+	}
+
+	last := len(path.tokens) - 1
+	fmt.Printf("Amount out: %s %s\n", formatBigIntToDecimal(amountOut, decimals[last]), symbols[last])
+	fmt.Printf("Gas estimate: %s\n", gasEstimate.String())
+
+	// Approximate the spot rate with a quote for a tiny fraction of the input
+	smallIn := new(big.Int).Div(amountInUnits, big.NewInt(10000))
+	if smallIn.Sign() == 0 {
+		smallIn = big.NewInt(1)
+	}
+	smallOut, _, err := quoteExactInput(client, quoter, path, smallIn)
+	if err != nil || smallOut.Sign() == 0 || amountInUnits.Sign() == 0 {
+		fmt.Println("Price impact: unavailable")
+		return nil
+	}
+
+	spotRate := new(big.Float).Quo(new(big.Float).SetInt(smallOut), new(big.Float).SetInt(smallIn))
+	executionRate := new(big.Float).Quo(new(big.Float).SetInt(amountOut), new(big.Float).SetInt(amountInUnits))
+	ratio, _ := new(big.Float).Quo(executionRate, spotRate).Float64()
+	fmt.Printf("Price impact: %.4f%%\n", (1-ratio)*100)
+
+	return nil
+}
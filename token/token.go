@@ -2,10 +2,10 @@ package token
 
 import (
 	"context"
+	"embed"
 	"fmt"
-	"io"
+	"io/fs"
 	"math/big"
-	"os"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
@@ -22,9 +22,17 @@ type Token struct {
 	client  *ethclient.Client
 }
 
-// NewToken function
+//go:embed abi.json
+var abiFS embed.FS
+
+// ERCToken creates a Token with the ERC-20 ABI embedded in the package
 func ERCToken(address string, decimal int, client *ethclient.Client) (*Token, error) {
-	parsedABI, err := loadTokenABI("./token/abi.json") // Update the path to abi.json
+	return ERCTokenFromFS(abiFS, address, decimal, client)
+}
+
+// ERCTokenFromFS creates a Token whose ABI is read from abi.json in fsys
+func ERCTokenFromFS(fsys fs.FS, address string, decimal int, client *ethclient.Client) (*Token, error) {
+	parsedABI, err := loadTokenABI(fsys, "abi.json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load token ABI: %w", err)
 	}
@@ -38,14 +46,8 @@ func ERCToken(address string, decimal int, client *ethclient.Client) (*Token, er
 }
 
 // loadTokenABI function
-func loadTokenABI(filename string) (abi.ABI, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return abi.ABI{}, fmt.Errorf("failed to open ABI file: %w", err)
-	}
-	defer file.Close()
-
-	abiBytes, err := io.ReadAll(file)
+func loadTokenABI(fsys fs.FS, filename string) (abi.ABI, error) {
+	abiBytes, err := fs.ReadFile(fsys, filename)
 	if err != nil {
 		return abi.ABI{}, fmt.Errorf("failed to read ABI file: %w", err)
 	}
@@ -85,3 +87,63 @@ func (t *Token) BalanceOf(address string) (*big.Int, error) {
 
 	return balance, nil
 }
+
+// Decimals method using ethclient
+func (t *Token) Decimals() (uint8, error) {
+	result, err := t.call("decimals")
+	if err != nil {
+		return 0, err
+	}
+	return result[0].(uint8), nil
+}
+
+// Symbol method using ethclient
+func (t *Token) Symbol() (string, error) {
+	result, err := t.call("symbol")
+	if err != nil {
+		return "", err
+	}
+	return result[0].(string), nil
+}
+
+// TotalSupply method using ethclient
+func (t *Token) TotalSupply() (*big.Int, error) {
+	result, err := t.call("totalSupply")
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// Allowance method using ethclient
+func (t *Token) Allowance(owner, spender common.Address) (*big.Int, error) {
+	result, err := t.call("allowance", owner, spender)
+	if err != nil {
+		return nil, err
+	}
+	return result[0].(*big.Int), nil
+}
+
+// call packs a read-only contract call, executes it and unpacks the outputs
+func (t *Token) call(method string, args ...interface{}) ([]interface{}, error) {
+	data, err := t.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for %s: %w", method, err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &t.address,
+		Data: data,
+	}
+
+	result, err := t.client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	outputs, err := t.ABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,66 @@
+package token
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+const balanceOfABI = `[{
+  "inputs": [{ "name": "account", "type": "address" }],
+  "name": "balanceOf",
+  "outputs": [{ "name": "", "type": "uint256" }],
+  "stateMutability": "view",
+  "type": "function"
+}]`
+
+func TestERCTokenFromFS(t *testing.T) {
+	fsys := fstest.MapFS{"abi.json": {Data: []byte(balanceOfABI)}}
+
+	token, err := ERCTokenFromFS(fsys, "0x00000000000000000000000000000000000000AA", 6, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.address.Hex() != "0x00000000000000000000000000000000000000AA" {
+		t.Errorf("address = %s", token.address.Hex())
+	}
+	if token.decimal != 6 {
+		t.Errorf("decimal = %d, want 6", token.decimal)
+	}
+	if _, ok := token.ABI.Methods["balanceOf"]; !ok {
+		t.Error("balanceOf missing from the parsed ABI")
+	}
+	if len(token.ABI.Methods) != 1 {
+		t.Errorf("parsed %d methods, want 1", len(token.ABI.Methods))
+	}
+}
+
+func TestERCTokenFromFSErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing abi.json", fstest.MapFS{"other.json": {Data: []byte(balanceOfABI)}}},
+		{"invalid JSON", fstest.MapFS{"abi.json": {Data: []byte(`[{"name": `)}}},
+		{"not an ABI", fstest.MapFS{"abi.json": {Data: []byte(`{"name": "balanceOf"}`)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ERCTokenFromFS(tt.fsys, "0x00000000000000000000000000000000000000AA", 18, nil); err == nil {
+				t.Error("ERCTokenFromFS succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestERCTokenEmbeddedABI(t *testing.T) {
+	token, err := ERCToken("0x00000000000000000000000000000000000000AA", 18, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"balanceOf", "transfer", "transferFrom", "approve", "allowance", "decimals", "symbol", "totalSupply"} {
+		if _, ok := token.ABI.Methods[method]; !ok {
+			t.Errorf("%s missing from the embedded ABI", method)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	tokenListFile         = "tokenlist.json"
+	defaultTokenListURL   = "https://tokens.uniswap.org"
+	defaultIPFSGatewayURL = "https://ipfs.io/ipfs/"
+)
+
+// tokenListEntry is one token of a Uniswap-format token list
+type tokenListEntry struct {
+	ChainID  int64  `json:"chainId"`
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals uint8  `json:"decimals"`
+	LogoURI  string `json:"logoURI,omitempty"`
+}
+
+// tokenList is a Uniswap-format token list (https://tokenlists.org)
+type tokenList struct {
+	Name   string           `json:"name"`
+	Tokens []tokenListEntry `json:"tokens"`
+}
+
+// tokenListURL rewrites ipfs:// links to the public IPFS gateway
+func tokenListURL(url string) string {
+	if cid, ok := strings.CutPrefix(url, "ipfs://"); ok {
+		return defaultIPFSGatewayURL + cid
+	}
+	return url
+}
+
+// loadTokenList reads the cached token list. A missing cache file is treated
+// as an empty list.
+func loadTokenList() (tokenList, error) {
+	path, err := dataFilePath(tokenListFile)
+	if err != nil {
+		return tokenList{}, err
+	}
+
+	var list tokenList
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return list, nil
+	}
+	if err != nil {
+		return tokenList{}, fmt.Errorf("failed to read token list: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return tokenList{}, fmt.Errorf("failed to parse token list: %w", err)
+	}
+	return list, nil
+}
+
+// lookupTokenList finds a token in the cached list by chain and address
+func lookupTokenList(chainID int64, tokenAddress common.Address) (tokenListEntry, bool) {
+	list, err := loadTokenList()
+	if err != nil {
+		return tokenListEntry{}, false
+	}
+
+	for _, entry := range list.Tokens {
+		if entry.ChainID == chainID && common.HexToAddress(entry.Address) == tokenAddress {
+			return entry, true
+		}
+	}
+	return tokenListEntry{}, false
+}
+
+func updateTokenList(c *cli.Context) error {
+	url := tokenListURL(c.String("url"))
+
+	var list tokenList
+	if err := httpGetJSON(url, &list); err != nil {
+		return err
+	}
+	if len(list.Tokens) == 0 {
+		return fmt.Errorf("%s contains no tokens", url)
+	}
+
+	path, err := dataFilePath(tokenListFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token list: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write token list: %w", err)
+	}
+
+	fmt.Printf("Saved %s with %d tokens to %s\n", list.Name, len(list.Tokens), path)
+	return nil
+}
+
+func tokenListSearch(c *cli.Context) error {
+	symbol := c.String("symbol")
+
+	list, err := loadTokenList()
+	if err != nil {
+		return err
+	}
+	if len(list.Tokens) == 0 {
+		return fmt.Errorf("no token list cached, run update-token-list first")
+	}
+
+	// Restrict results to the configured network when it is known
+	var chainID int64
+	if preset, ok := networkPresets[network]; ok {
+		chainID = preset.chainID
+	}
+
+	found := 0
+	for _, entry := range list.Tokens {
+		if !strings.EqualFold(entry.Symbol, symbol) || (chainID != 0 && entry.ChainID != chainID) {
+			continue
+		}
+		fmt.Printf("%s\t%s\t%s\tchain %d\t%d decimals\n", entry.Symbol, entry.Address, entry.Name, entry.ChainID, entry.Decimals)
+		found++
+	}
+
+	if found == 0 {
+		fmt.Printf("No token with symbol %s in %s\n", symbol, list.Name)
+	}
+	return nil
+}
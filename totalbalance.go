@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+const lifiAPI = "https://li.quest/v1"
+
+// lifiToken is the token metadata returned by the LI.FI token endpoint
+type lifiToken struct {
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+	PriceUSD string `json:"priceUSD"`
+}
+
+// lifiTokenPrice returns the USD price of a token on a chain from LI.FI. The
+// zero address stands for the chain's native currency.
+func lifiTokenPrice(chainID int64, token common.Address) (float64, error) {
+	body, err := httpGetWithHeaders(fmt.Sprintf("%s/token?chain=%d&token=%s", lifiAPI, chainID, token.Hex()), map[string]string{
+		"x-lifi-api-key": lifiKey,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var info lifiToken
+	if err := json.Unmarshal(body, &info); err != nil {
+		return 0, fmt.Errorf("failed to decode LI.FI response: %w", err)
+	}
+	return strconv.ParseFloat(info.PriceUSD, 64)
+}
+
+// chainHolding is a balance of one asset on one chain
+type chainHolding struct {
+	symbol string
+	amount float64
+	usd    float64
+	priced bool
+}
+
+func totalBalance(c *cli.Context) error {
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	// Optional extra tokens, one "<network>,<token>" per line
+	tokensByChain := map[string][]common.Address{}
+	if path := c.String("tokens"); path != "" {
+		lines, err := readLines(path)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fields := strings.Split(line, ",")
+			if len(fields) != 2 {
+				return fmt.Errorf("invalid token line %q, expected <network>,<token>", line)
+			}
+			token := strings.TrimSpace(fields[1])
+			if !common.IsHexAddress(token) {
+				return fmt.Errorf("invalid token address %q", token)
+			}
+			name := strings.TrimSpace(fields[0])
+			tokensByChain[name] = append(tokensByChain[name], common.HexToAddress(token))
+		}
+	}
+
+	if lifiKey == "" {
+		fmt.Println("LIFI_API_KEY is not set; only ETH is priced, using the Chainlink feed on mainnet")
+	}
+
+	var ethPrice *big.Float
+	holdings := map[string]*chainHolding{}
+	addHolding := func(symbol string, amount, usd float64, priced bool) {
+		holding, ok := holdings[symbol]
+		if !ok {
+			holding = &chainHolding{symbol: symbol, priced: true}
+			holdings[symbol] = holding
+		}
+		holding.amount += amount
+		holding.usd += usd
+		holding.priced = holding.priced && priced
+	}
+
+	for _, name := range strings.Split(c.String("chains"), ",") {
+		name = strings.TrimSpace(name)
+		preset, ok := networkPresets[name]
+		if !ok {
+			return fmt.Errorf("unknown chain %q", name)
+		}
+
+		endpoint, err := providerURL(name)
+		if err != nil {
+			return err
+		}
+		client, err := ethclient.Dial(endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", name, err)
+		}
+
+		balance, err := client.BalanceAt(context.Background(), address, nil)
+		if err != nil {
+			fmt.Printf("%s: failed to get balance: %v\n", name, err)
+			continue
+		}
+		amount, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e18)).Float64()
+
+		price, priced := 0.0, false
+		if lifiKey != "" {
+			if p, err := lifiTokenPrice(preset.chainID, common.Address{}); err == nil {
+				price, priced = p, true
+			}
+		} else if preset.nativeSymbol == "ETH" {
+			if ethPrice == nil {
+				if endpoint, err := providerURL("mainnet"); err == nil {
+					if mainnet, err := ethclient.Dial(endpoint); err == nil {
+						ethPrice, _ = ethUSDPrice(mainnet)
+					}
+				}
+			}
+			if ethPrice != nil {
+				price, _ = ethPrice.Float64()
+				priced = true
+			}
+		}
+		fmt.Printf("%-10s %s %s\n", name, strconv.FormatFloat(amount, 'f', 6, 64), preset.nativeSymbol)
+		addHolding(preset.nativeSymbol, amount, amount*price, priced)
+
+		for _, tokenAddress := range tokensByChain[name] {
+			tokenContract, err := Token.ERCToken(tokenAddress.Hex(), 0, client)
+			if err != nil {
+				return fmt.Errorf("failed to create token contract: %w", err)
+			}
+			symbol, err := tokenContract.Symbol()
+			if err != nil {
+				symbol = tokenAddress.Hex()
+			}
+			decimals, err := tokenContract.Decimals()
+			if err != nil {
+				fmt.Printf("%s: failed to get decimals of %s: %v\n", name, tokenAddress.Hex(), err)
+				continue
+			}
+			tokenBalance, err := tokenContract.BalanceOf(address.Hex())
+			if err != nil {
+				fmt.Printf("%s: failed to get balance of %s: %v\n", name, symbol, err)
+				continue
+			}
+
+			scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+			tokenAmount, _ := new(big.Float).Quo(new(big.Float).SetInt(tokenBalance), scale).Float64()
+
+			tokenPrice, tokenPriced := 0.0, false
+			if lifiKey != "" {
+				if p, err := lifiTokenPrice(preset.chainID, tokenAddress); err == nil {
+					tokenPrice, tokenPriced = p, true
+				}
+			}
+			fmt.Printf("%-10s %s %s\n", name, strconv.FormatFloat(tokenAmount, 'f', 6, 64), symbol)
+			addHolding(symbol, tokenAmount, tokenAmount*tokenPrice, tokenPriced)
+		}
+	}
+
+	sorted := make([]*chainHolding, 0, len(holdings))
+	for _, holding := range holdings {
+		sorted = append(sorted, holding)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].usd > sorted[j].usd })
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SYMBOL\tAMOUNT\tUSD")
+	total := 0.0
+	for _, holding := range sorted {
+		usd := "-"
+		if holding.priced {
+			usd = fmt.Sprintf("%.2f", holding.usd)
+		}
+		fmt.Fprintf(w, "%s\t%.6f\t%s\n", holding.symbol, holding.amount, usd)
+		total += holding.usd
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("Total: $%.2f\n", total)
+	return nil
+}
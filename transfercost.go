@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// referenceStablecoin is a mainnet stablecoin and its Chainlink USD feed
+type referenceStablecoin struct {
+	symbol   string
+	token    common.Address
+	decimals int
+	feed     common.Address
+}
+
+var referenceStablecoins = map[string]referenceStablecoin{
+	"usdc": {"USDC", common.HexToAddress("0xA0b86991c6218b36c1d19D4a2E9Eb0cE3606eB48"), 6, common.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6")},
+	"usdt": {"USDT", common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"), 6, common.HexToAddress("0x3E7d1eAB13ad0104d2750B8863b489D65364e32D")},
+}
+
+// Used when the sender cannot fund the transfer being estimated. The token
+// figure matches the fixed gas limit of transfer-token.
+const (
+	fallbackETHTransferGas   = 21000
+	fallbackTokenTransferGas = 60000
+)
+
+// usdToUnits converts a USD value to base units of an asset priced in USD
+func usdToUnits(usd float64, price *big.Float, decimals int) *big.Int {
+	amount := new(big.Float).Quo(big.NewFloat(usd), price)
+	amount.Mul(amount, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	units, _ := amount.Int(nil)
+	return units
+}
+
+func compareTransferCost(c *cli.Context) error {
+	index := c.Int("from")
+	amountUSD := c.Float64("amount-usd")
+
+	stablecoin, ok := referenceStablecoins[c.String("stablecoin")]
+	if !ok {
+		return fmt.Errorf("unsupported stablecoin %q", c.String("stablecoin"))
+	}
+	if amountUSD <= 0 {
+		return fmt.Errorf("amount-usd must be positive")
+	}
+
+	toAddress, err := parseAddress(c.String("to"))
+	if err != nil {
+		return err
+	}
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	from := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	ethPrice, err := ethUSDPrice(client)
+	if err != nil {
+		return err
+	}
+	stablePrice, err := chainlinkPrice(client, stablecoin.feed)
+	if err != nil {
+		return fmt.Errorf("failed to get %s price: %w", stablecoin.symbol, err)
+	}
+
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	ethAmount := usdToUnits(amountUSD, ethPrice, 18)
+	tokenAmount := usdToUnits(amountUSD, stablePrice, stablecoin.decimals)
+
+	estimated := true
+	ethGas, err := client.EstimateGas(context.Background(), ethereum.CallMsg{From: from, To: &toAddress, Value: ethAmount})
+	if err != nil {
+		ethGas, estimated = fallbackETHTransferGas, false
+	}
+
+	tokenContract, err := Token.ERCToken(stablecoin.token.Hex(), stablecoin.decimals, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	transferData, err := tokenContract.ABI.Pack("transfer", toAddress, tokenAmount)
+	if err != nil {
+		return fmt.Errorf("failed to pack transfer data: %w", err)
+	}
+	tokenGas, err := client.EstimateGas(context.Background(), ethereum.CallMsg{From: from, To: &stablecoin.token, Data: transferData})
+	if err != nil {
+		tokenGas, estimated = fallbackTokenTransferGas, false
+	}
+
+	ethFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(ethGas))
+	tokenFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tokenGas))
+	ethFeeUSD := weiToUSD(ethFee, ethPrice)
+	tokenFeeUSD := weiToUSD(tokenFee, ethPrice)
+
+	fmt.Printf("Gas price: %s gwei, ETH: $%s, %s: $%s\n",
+		formatBigIntToDecimal(gasPrice, 9), ethPrice.Text('f', 2), stablecoin.symbol, stablePrice.Text('f', 4))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSET\tAMOUNT\tGAS\tFEE (ETH)\tFEE (USD)\tFEE %")
+	fmt.Fprintf(w, "ETH\t%s\t%d\t%s\t$%.4f\t%.4f%%\n",
+		formatBigIntToDecimal(ethAmount, 18), ethGas, formatBigIntToDecimal(ethFee, 18), ethFeeUSD, ethFeeUSD/amountUSD*100)
+	fmt.Fprintf(w, "%s\t%s\t%d\t%s\t$%.4f\t%.4f%%\n",
+		stablecoin.symbol, formatBigIntToDecimal(tokenAmount, stablecoin.decimals), tokenGas, formatBigIntToDecimal(tokenFee, 18), tokenFeeUSD, tokenFeeUSD/amountUSD*100)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !estimated {
+		fmt.Println("Note: the sender could not fund a transfer, so typical gas amounts were used for at least one row")
+	}
+
+	cheaper, dearer, saving := "ETH", stablecoin.symbol, tokenFeeUSD-ethFeeUSD
+	if saving < 0 {
+		cheaper, dearer, saving = stablecoin.symbol, "ETH", -saving
+	}
+	fmt.Printf("%s is cheaper than %s by $%.4f (%.1f%%)\n", cheaper, dearer, saving, saving/max(ethFeeUSD, tokenFeeUSD)*100)
+	return nil
+}
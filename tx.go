@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// sendTransaction builds a transaction from an unlocked account to the given
+// address, estimates its gas limit, signs it with the keystore and broadcasts it.
+func sendTransaction(client *ethclient.Client, keyStore *keystore.KeyStore, account accounts.Account, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	return submitTransaction(client, keyStore, account, &to, value, data)
+}
+
+// deployContract sends a contract creation transaction with the given init
+// code and returns it along with the address the contract will have.
+func deployContract(client *ethclient.Client, keyStore *keystore.KeyStore, account accounts.Account, code []byte) (*types.Transaction, common.Address, error) {
+	signedTx, err := submitTransaction(client, keyStore, account, nil, big.NewInt(0), code)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	return signedTx, crypto.CreateAddress(account.Address, signedTx.Nonce()), nil
+}
+
+// submitTransaction is sendTransaction with a nil recipient allowed for
+// contract creation.
+func submitTransaction(client *ethclient.Client, keyStore *keystore.KeyStore, account accounts.Account, to *common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	nonce, err := client.PendingNonceAt(context.Background(), account.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:  account.Address,
+		To:    to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Value:    value,
+		Gas:      gasLimit,
+		GasPrice: gasPrice,
+		Data:     data,
+	})
+
+	// Sign transaction
+	signedTx, err := signTransaction(keyStore, account, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send transaction
+	if err := broadcastTransaction(client, signedTx); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// buildTransaction creates an unsigned transaction with the fee flags of the
+// transfer commands. EIP-1559 is used when --eip1559 is set, or by default
+// when the latest block has a base fee; otherwise a legacy transaction is
+// built with the suggested gas price.
+func buildTransaction(c *cli.Context, client *ethclient.Client, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, data []byte) (*types.Transaction, error) {
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block header: %w", err)
+	}
+
+	useDynamicFee := header.BaseFee != nil
+	if c.IsSet("eip1559") {
+		useDynamicFee = c.Bool("eip1559")
+	}
+
+	if !useDynamicFee {
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		return types.NewTransaction(nonce, to, value, gasLimit, gasPrice, data), nil
+	}
+	if header.BaseFee == nil {
+		return nil, fmt.Errorf("network does not support EIP-1559 transactions")
+	}
+
+	var tipCap *big.Int
+	if c.IsSet("max-priority-fee") {
+		tipCap, err = parseAmount(c.String("max-priority-fee"), 9)
+	} else {
+		tipCap, err = client.SuggestGasTipCap(context.Background())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get priority fee: %w", err)
+	}
+
+	// Twice the base fee survives six consecutive full blocks
+	feeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	if c.IsSet("max-fee-per-gas") {
+		feeCap, err = parseAmount(c.String("max-fee-per-gas"), 9)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max fee per gas: %w", err)
+		}
+	}
+	if feeCap.Cmp(tipCap) < 0 {
+		return nil, fmt.Errorf("max fee per gas is below the priority fee")
+	}
+
+	id, err := resolveChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   id,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	}), nil
+}
+
+// signTransaction signs tx with the configured HSM, or with the unlocked
+// keystore account when no HSM is configured.
+func signTransaction(keyStore *keystore.KeyStore, account accounts.Account, tx *types.Transaction) (*types.Transaction, error) {
+	id, err := resolveChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := accountSigner(keyStore, account).SignTx(tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signedTx, nil
+}
+
+// waitForSuccess blocks until tx is mined and returns an error if it reverted
+func waitForSuccess(client *ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	recordReceipt(tx, receipt)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// waitMined waits up to timeout for tx to be mined and prints its block and
+// gas used. A reverted transaction is returned as an error with the revert
+// reason, found by replaying the call against the block's parent state.
+func waitMined(client *ethclient.Client, tx *types.Transaction, timeout time.Duration) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fmt.Printf("Waiting for %s to be mined...\n", tx.Hash().Hex())
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not mined: %w", tx.Hash().Hex(), err)
+	}
+
+	fmt.Printf("Mined in block %d, gas used %d\n", receipt.BlockNumber.Uint64(), receipt.GasUsed)
+	recordReceipt(tx, receipt)
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return receipt, nil
+	}
+
+	reason := "unknown reason"
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err == nil {
+		_, err = client.CallContract(context.Background(), ethereum.CallMsg{
+			From:     from,
+			To:       tx.To(),
+			Gas:      tx.Gas(),
+			GasPrice: tx.GasPrice(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+		if err != nil {
+			reason = err.Error()
+		}
+	}
+	return receipt, fmt.Errorf("transaction %s reverted: %s", tx.Hash().Hex(), reason)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newTestAccount imports a fresh key into a temporary keystore and unlocks it
+func newTestAccount(t *testing.T) (*keystore.KeyStore, accounts.Account) {
+	t.Helper()
+	keyStore := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := keyStore.ImportECDSA(key, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keyStore.Unlock(account, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return keyStore, account
+}
+
+// chainIDServer serves eth_chainId with the given hex chain ID and counts
+// the requests it receives
+func chainIDServer(t *testing.T, id string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "eth_chainId" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": id})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// restoreNetworkGlobals puts back the connection settings a test changes
+func restoreNetworkGlobals(t *testing.T) {
+	savedChainID, savedRPCURL, savedNetwork := chainId, rpcURL, network
+	t.Cleanup(func() {
+		chainId, rpcURL, network = savedChainID, savedRPCURL, savedNetwork
+	})
+}
+
+func TestSignTransactionChainID(t *testing.T) {
+	restoreNetworkGlobals(t)
+	keyStore, account := newTestAccount(t)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000AA")
+
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{"legacy", types.NewTransaction(7, to, big.NewInt(1), 21000, big.NewInt(1e9), nil)},
+		{"dynamic fee", types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(10),
+			Nonce:     7,
+			GasTipCap: big.NewInt(1e9),
+			GasFeeCap: big.NewInt(2e9),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(1),
+		})},
+	}
+
+	chainId.SetInt64(10)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedTx, err := signTransaction(keyStore, account, tt.tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !signedTx.Protected() {
+				t.Fatal("signed transaction is not replay protected")
+			}
+			if signedTx.ChainId().Cmp(big.NewInt(10)) != 0 {
+				t.Errorf("embedded chain ID = %s, want 10", signedTx.ChainId())
+			}
+			if tt.tx.Type() == types.LegacyTxType {
+				// EIP-155: v = chainId * 2 + 35 + recovery id
+				v, _, _ := signedTx.RawSignatureValues()
+				if v.Uint64() != 55 && v.Uint64() != 56 {
+					t.Errorf("v = %d, want 55 or 56 for chain ID 10", v)
+				}
+			}
+			sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(10)), signedTx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sender != account.Address {
+				t.Errorf("sender = %s, want %s", sender.Hex(), account.Address.Hex())
+			}
+		})
+	}
+}
+
+func TestSignTransactionResolvesChainIDLazily(t *testing.T) {
+	restoreNetworkGlobals(t)
+	keyStore, account := newTestAccount(t)
+	var requests atomic.Int32
+	rpcURL = chainIDServer(t, "0x2105", &requests).URL
+	chainId.SetInt64(0)
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1e9), nil)
+	for i := 0; i < 2; i++ {
+		signedTx, err := signTransaction(keyStore, account, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signedTx.ChainId().Cmp(big.NewInt(8453)) != 0 {
+			t.Errorf("embedded chain ID = %s, want 8453 from the node", signedTx.ChainId())
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("node asked for the chain ID %d times, want once", got)
+	}
+}
+
+func TestResolveChainIDFlagSkipsNode(t *testing.T) {
+	restoreNetworkGlobals(t)
+	var requests atomic.Int32
+	rpcURL = chainIDServer(t, "0x1", &requests).URL
+	chainId.SetInt64(11155111)
+
+	id, err := resolveChainID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Int64() != 11155111 {
+		t.Errorf("chain ID = %s, want the --chain-id value 11155111", id)
+	}
+	if requests.Load() != 0 {
+		t.Error("node was asked for the chain ID although --chain-id was set")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// recoverTxSigner fetches a mined transaction and recovers the address that
+// signed it from the signature values. The sender reported by the node is
+// returned alongside so the two can be compared.
+func recoverTxSigner(client *ethclient.Client, txHash common.Hash) (recovered common.Address, reported common.Address, err error) {
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return common.Address{}, common.Address{}, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if isPending {
+		return common.Address{}, common.Address{}, fmt.Errorf("transaction %s is still pending", txHash.Hex())
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return common.Address{}, common.Address{}, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	// The latest signer understands legacy, EIP-2930, EIP-1559 and blob transactions
+	signer := types.LatestSignerForChainID(chainID)
+	recovered, err = types.Sender(signer, tx)
+	if err != nil {
+		return common.Address{}, common.Address{}, fmt.Errorf("failed to recover sender: %w", err)
+	}
+
+	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	if err != nil {
+		return common.Address{}, common.Address{}, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	reported, err = client.TransactionSender(context.Background(), tx, receipt.BlockHash, receipt.TransactionIndex)
+	if err != nil {
+		return common.Address{}, common.Address{}, fmt.Errorf("failed to get transaction sender: %w", err)
+	}
+
+	return recovered, reported, nil
+}
+
+func verifyTxSignature(c *cli.Context) error {
+	txHash := c.String("tx-hash")
+	assertSigner := c.String("assert-signer")
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	recovered, reported, err := recoverTxSigner(client, common.HexToHash(txHash))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Recovered signer: %s\n", recovered.Hex())
+	fmt.Printf("Reported sender:  %s\n", reported.Hex())
+	if recovered == reported {
+		fmt.Println("Signature matches the transaction sender")
+	} else {
+		fmt.Println("Signature does NOT match the transaction sender")
+	}
+
+	if assertSigner != "" {
+		expected, err := parseAddress(assertSigner)
+		if err != nil {
+			return err
+		}
+		if recovered != expected {
+			return fmt.Errorf("transaction was signed by %s, expected %s", recovered.Hex(), expected.Hex())
+		}
+	}
+
+	return nil
+}
+
+func verifyTxSender(c *cli.Context) error {
+	txHash := c.String("tx-hash")
+	index := c.Int("index")
+
+	accountList := openKeyStore().Accounts()
+	if index < 0 || index >= len(accountList) {
+		return fmt.Errorf("invalid account index")
+	}
+	expected := accountList[index].Address
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	recovered, _, err := recoverTxSigner(client, common.HexToHash(txHash))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Recovered sender: %s\n", recovered.Hex())
+	fmt.Printf("Account %d:        %s\n", index, expected.Hex())
+	if recovered != expected {
+		fmt.Println("MISMATCH")
+		return fmt.Errorf("transaction was not sent by account %d", index)
+	}
+	fmt.Println("MATCH")
+	return nil
+}
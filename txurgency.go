@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Blocks sampled for the recent fill rate and block time
+const urgencySampleBlocks = 10
+
+// pooledTx is an entry of txpool_inspect
+type pooledTx struct {
+	from  common.Address
+	nonce uint64
+	to    string
+	gas   uint64
+	price *big.Int
+}
+
+// parsePooledTx parses a txpool_inspect summary such as
+// "0xTo: 0 wei + 21000 gas × 1000000000 wei". Dynamic fee transactions are
+// summarized with their max fee per gas.
+func parsePooledTx(from common.Address, nonce uint64, summary string) (pooledTx, bool) {
+	to, rest, ok := strings.Cut(summary, ": ")
+	if !ok {
+		return pooledTx{}, false
+	}
+	_, rest, ok = strings.Cut(rest, " + ")
+	if !ok {
+		return pooledTx{}, false
+	}
+	gasText, priceText, ok := strings.Cut(rest, " gas × ")
+	if !ok {
+		return pooledTx{}, false
+	}
+	gas, err := strconv.ParseUint(gasText, 10, 64)
+	if err != nil {
+		return pooledTx{}, false
+	}
+	price, ok := new(big.Int).SetString(strings.TrimSuffix(priceText, " wei"), 10)
+	if !ok {
+		return pooledTx{}, false
+	}
+	return pooledTx{from: from, nonce: nonce, to: to, gas: gas, price: price}, true
+}
+
+// pendingPool returns the executable transactions of txpool_inspect
+func pendingPool(client *ethclient.Client) ([]pooledTx, error) {
+	var inspect struct {
+		Pending map[common.Address]map[string]string `json:"pending"`
+	}
+	if err := client.Client().CallContext(context.Background(), &inspect, "txpool_inspect"); err != nil {
+		return nil, err
+	}
+
+	var pool []pooledTx
+	for from, byNonce := range inspect.Pending {
+		for nonceText, summary := range byNonce {
+			nonce, err := strconv.ParseUint(nonceText, 10, 64)
+			if err != nil {
+				continue
+			}
+			if entry, ok := parsePooledTx(from, nonce, summary); ok {
+				pool = append(pool, entry)
+			}
+		}
+	}
+	return pool, nil
+}
+
+// urgencyScore maps the blocks until inclusion to 1 (next block) through 10,
+// adding one when higher bids compete for the same contract.
+func urgencyScore(blocks uint64, contested bool) int {
+	score := 1 + int(math.Ceil(math.Log2(float64(blocks))))
+	if contested {
+		score++
+	}
+	return min(max(score, 1), 10)
+}
+
+func txUrgency(c *cli.Context) error {
+	rawTx, err := hexutil.Decode(c.String("tx-hex"))
+	if err != nil {
+		return fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender: %w", err)
+	}
+
+	// Compared the way txpool_inspect reports prices: the max fee per gas
+	bid := tx.GasFeeCap()
+	if c.IsSet("gas-price") {
+		bid, err = parseAmount(c.String("gas-price"), 9)
+		if err != nil {
+			return fmt.Errorf("invalid gas price: %w", err)
+		}
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	pool, err := pendingPool(client)
+	if err != nil {
+		return fmt.Errorf("failed to inspect the mempool, the node must expose txpool_inspect: %w", err)
+	}
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block header: %w", err)
+	}
+
+	// Recent fill rate and block time
+	var gasUsed uint64
+	oldest := head
+	for i := uint64(1); i < urgencySampleBlocks && i <= head.Number.Uint64(); i++ {
+		header, err := client.HeaderByNumber(context.Background(), new(big.Int).Sub(head.Number, new(big.Int).SetUint64(i)))
+		if err != nil {
+			return fmt.Errorf("failed to get block header: %w", err)
+		}
+		gasUsed += header.GasUsed
+		oldest = header
+	}
+	sampled := head.Number.Uint64() - oldest.Number.Uint64()
+	gasPerBlock := float64(head.GasUsed)
+	blockTime := 12.0
+	if sampled > 0 {
+		gasPerBlock = float64(gasUsed+head.GasUsed) / float64(sampled+1)
+		blockTime = float64(head.Time-oldest.Time) / float64(sampled)
+	}
+
+	target := "contract creation"
+	if tx.To() != nil {
+		target = tx.To().Hex()
+	}
+
+	var gasAhead uint64
+	var ahead, competing int
+	for _, entry := range pool {
+		if entry.from == from && entry.nonce == tx.Nonce() {
+			continue
+		}
+		if entry.price.Cmp(bid) <= 0 {
+			continue
+		}
+		ahead++
+		gasAhead += entry.gas
+		if tx.To() != nil && strings.EqualFold(entry.to, target) {
+			competing++
+		}
+	}
+
+	fmt.Printf("Transaction:     %s\n", tx.Hash().Hex())
+	fmt.Printf("Bid:             %s gwei\n", formatBigIntToDecimal(bid, 9))
+	if head.BaseFee != nil {
+		fmt.Printf("Base fee:        %s gwei\n", formatBigIntToDecimal(head.BaseFee, 9))
+	}
+	fmt.Printf("Pending pool:    %d transactions, %d bidding higher (%d gas)\n", len(pool), ahead, gasAhead)
+	if tx.To() != nil {
+		fmt.Printf("Same target:     %d higher bids to %s\n", competing, target)
+	}
+	fmt.Printf("Block fill:      %.0f gas per block, one block every %.1fs\n", gasPerBlock, blockTime)
+
+	if head.BaseFee != nil && bid.Cmp(head.BaseFee) < 0 {
+		fmt.Println("Urgency score:   10/10")
+		fmt.Println("Estimated wait:  not includable until the base fee falls below the bid; bump the gas price")
+		return nil
+	}
+
+	blocks := uint64(1)
+	if gasPerBlock > 0 {
+		blocks += uint64(float64(gasAhead) / gasPerBlock)
+	}
+	score := urgencyScore(blocks, competing > 0)
+	fmt.Printf("Urgency score:   %d/10\n", score)
+	fmt.Printf("Estimated wait:  %d blocks (~%.1f minutes)\n", blocks, float64(blocks)*blockTime/60)
+	if score >= 7 {
+		fmt.Println("Consider bumping the gas price")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/urfave/cli/v2"
+)
+
+// typedMessage is the --message-file of sign-typed-data: the struct types,
+// the primary type and the message to sign
+type typedMessage struct {
+	Types       apitypes.Types            `json:"types"`
+	PrimaryType string                    `json:"primaryType"`
+	Message     apitypes.TypedDataMessage `json:"message"`
+}
+
+// domainType lists the EIP712Domain fields present in domain, in the order
+// the spec gives them
+func domainType(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// loadTypedData combines a domain file and a message file into EIP-712 typed
+// data. The EIP712Domain type is derived from the domain unless the message
+// file declares it.
+func loadTypedData(domainPath, messagePath string) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+
+	domainJSON, err := os.ReadFile(domainPath)
+	if err != nil {
+		return typedData, fmt.Errorf("failed to read domain file: %w", err)
+	}
+	if err := json.Unmarshal(domainJSON, &typedData.Domain); err != nil {
+		return typedData, fmt.Errorf("failed to parse domain file: %w", err)
+	}
+
+	messageJSON, err := os.ReadFile(messagePath)
+	if err != nil {
+		return typedData, fmt.Errorf("failed to read message file: %w", err)
+	}
+	var message typedMessage
+	if err := json.Unmarshal(messageJSON, &message); err != nil {
+		return typedData, fmt.Errorf("failed to parse message file: %w", err)
+	}
+	if message.PrimaryType == "" {
+		return typedData, fmt.Errorf("message file has no primaryType")
+	}
+	if _, ok := message.Types[message.PrimaryType]; !ok {
+		return typedData, fmt.Errorf("primary type %s is not defined in the message types", message.PrimaryType)
+	}
+
+	typedData.Types = message.Types
+	typedData.PrimaryType = message.PrimaryType
+	typedData.Message = message.Message
+	if _, ok := typedData.Types["EIP712Domain"]; !ok {
+		typedData.Types["EIP712Domain"] = domainType(typedData.Domain)
+	}
+	return typedData, nil
+}
+
+// typedDataDigest hashes typed data as EIP-712 specifies, returning the
+// domain separator, hashStruct(message) and the digest that is signed
+func typedDataDigest(typedData apitypes.TypedData) (domainSeparator, structHash, digest []byte, err error) {
+	domainSeparator, err = typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to hash domain: %w", err)
+	}
+	structHash, err = typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to hash %s: %w", typedData.PrimaryType, err)
+	}
+	// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+	digest = crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+	return domainSeparator, structHash, digest, nil
+}
+
+func signTypedData(c *cli.Context) error {
+	typedData, err := loadTypedData(c.String("domain-file"), c.String("message-file"))
+	if err != nil {
+		return err
+	}
+
+	domainSeparator, structHash, digest, err := typedDataDigest(typedData)
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, c.Int("index"))
+	if err != nil {
+		return err
+	}
+
+	signature, err := signHash(keyStore, account, digest)
+	if err != nil {
+		return fmt.Errorf("failed to sign typed data: %w", err)
+	}
+	signature[64] += 27
+
+	fmt.Printf("Signer:           %s\n", account.Address.Hex())
+	fmt.Printf("Domain separator: %s\n", hexutil.Encode(domainSeparator))
+	fmt.Printf("Struct hash:      %s\n", hexutil.Encode(structHash))
+	fmt.Printf("Digest:           %s\n", hexutil.Encode(digest))
+	fmt.Printf("r:                %s\n", hexutil.Encode(signature[:32]))
+	fmt.Printf("s:                %s\n", hexutil.Encode(signature[32:64]))
+	fmt.Printf("v:                %d\n", signature[64])
+	fmt.Printf("Signature:        %s\n", hexutil.Encode(signature))
+	return nil
+}
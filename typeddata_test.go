@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The Mail example of the EIP-712 specification, split into the domain and
+// message files sign-typed-data reads
+const (
+	mailDomainJSON = `{
+  "name": "Ether Mail",
+  "version": "1",
+  "chainId": 1,
+  "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+}`
+	mailMessageJSON = `{
+  "types": {
+    "Person": [
+      { "name": "name", "type": "string" },
+      { "name": "wallet", "type": "address" }
+    ],
+    "Mail": [
+      { "name": "from", "type": "Person" },
+      { "name": "to", "type": "Person" },
+      { "name": "contents", "type": "string" }
+    ]
+  },
+  "primaryType": "Mail",
+  "message": {
+    "from": { "name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" },
+    "to": { "name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB" },
+    "contents": "Hello, Bob!"
+  }
+}`
+)
+
+func TestTypedDataMailExample(t *testing.T) {
+	dir := t.TempDir()
+	domainPath := filepath.Join(dir, "domain.json")
+	messagePath := filepath.Join(dir, "message.json")
+	if err := os.WriteFile(domainPath, []byte(mailDomainJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(messagePath, []byte(mailMessageJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	typedData, err := loadTypedData(domainPath, messagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domainSeparator, structHash, digest, err := typedDataDigest(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, check := range []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"domain separator", domainSeparator, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"},
+		{"hashStruct(message)", structHash, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"},
+		{"digest", digest, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"},
+	} {
+		if got := hexutil.Encode(check.got); got != check.want {
+			t.Errorf("%s = %s, want %s", check.name, got, check.want)
+		}
+	}
+
+	// The spec signs with the private key keccak256("cow")
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyStore := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := keyStore.ImportECDSA(key, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keyStore.Unlock(account, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if account.Address.Hex() != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Fatalf("signer = %s, want the spec's Cow wallet", account.Address.Hex())
+	}
+
+	signature, err := signHash(keyStore, account, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hexutil.Encode(signature[:32]), "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"; got != want {
+		t.Errorf("r = %s, want %s", got, want)
+	}
+	if got, want := hexutil.Encode(signature[32:64]), "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"; got != want {
+		t.Errorf("s = %s, want %s", got, want)
+	}
+	if v := signature[64] + 27; v != 28 {
+		t.Errorf("v = %d, want 28", v)
+	}
+}
+
+func TestLoadTypedDataDerivesDomainType(t *testing.T) {
+	dir := t.TempDir()
+	domainPath := filepath.Join(dir, "domain.json")
+	messagePath := filepath.Join(dir, "message.json")
+	if err := os.WriteFile(domainPath, []byte(`{"name": "Ether Mail", "chainId": 1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(messagePath, []byte(mailMessageJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	typedData, err := loadTypedData(domainPath, messagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := typedData.Types["EIP712Domain"]
+	if len(fields) != 2 || fields[0].Name != "name" || fields[1].Name != "chainId" {
+		t.Errorf("EIP712Domain = %+v, want name and chainId only", fields)
+	}
+
+	if err := os.WriteFile(messagePath, []byte(`{"types": {}, "primaryType": "Mail", "message": {}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTypedData(domainPath, messagePath); err == nil {
+		t.Error("undefined primary type accepted")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	slotsPerEpoch = 32
+	// 32 ETH in Gwei, the stake APR is measured against
+	validatorStakeGwei = 32e9
+	epochsPerYear      = 365.25 * 24 * 3600 / (12 * slotsPerEpoch)
+)
+
+// ethStoreHosts maps networks to the beaconcha.in instance publishing their
+// ETH.STORE network-wide APR
+var ethStoreHosts = map[string]string{
+	"mainnet": "https://beaconcha.in",
+	"holesky": "https://holesky.beaconcha.in",
+	"sepolia": "https://sepolia.beaconcha.in",
+}
+
+// networkConsensusAPR returns the latest network-wide consensus layer APR
+// from beaconcha.in's ETH.STORE
+func networkConsensusAPR() (float64, error) {
+	host, ok := ethStoreHosts[network]
+	if !ok {
+		return 0, fmt.Errorf("no network APR published for %s", network)
+	}
+
+	var response struct {
+		Data struct {
+			CLAPR float64 `json:"cl_apr"`
+		} `json:"data"`
+	}
+	if err := httpGetJSON(host+"/api/v1/ethstore/latest", &response); err != nil {
+		return 0, err
+	}
+	return response.Data.CLAPR, nil
+}
+
+// sumGwei adds decimal Gwei amounts, which may be negative or empty
+func sumGwei(amounts ...string) (int64, error) {
+	var total int64
+	for _, amount := range amounts {
+		if amount == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(amount, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid reward %q", amount)
+		}
+		total += value
+	}
+	return total, nil
+}
+
+func validatorAPR(c *cli.Context) error {
+	epochs := c.Uint64("epochs")
+	if epochs == 0 {
+		return fmt.Errorf("--epochs must be positive")
+	}
+
+	client, err := newBeaconClient()
+	if err != nil {
+		return err
+	}
+
+	validator, err := client.Validator(c.String("validator-index"))
+	if err != nil {
+		return fmt.Errorf("failed to get validator: %w", err)
+	}
+
+	head, err := client.Block("head")
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %w", err)
+	}
+	slot, err := strconv.ParseUint(head.Message.Slot, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid head slot %q", head.Message.Slot)
+	}
+
+	// Rewards are known once the epoch after has been processed
+	current := slot / slotsPerEpoch
+	if current < epochs+2 {
+		return fmt.Errorf("the chain has fewer than %d completed epochs", epochs)
+	}
+	last := current - 2
+
+	var mu sync.Mutex
+	var rewards int64
+	errs := runConcurrently(int(epochs), 10, func(i int) error {
+		epoch := last - uint64(i)
+		epochRewards, err := client.AttestationRewards(epoch, []string{validator.Index})
+		if err != nil {
+			return fmt.Errorf("epoch %d: %w", epoch, err)
+		}
+		for _, reward := range epochRewards {
+			total, err := sumGwei(reward.Head, reward.Target, reward.Source, reward.InclusionDelay, reward.Inactivity)
+			if err != nil {
+				return fmt.Errorf("epoch %d: %w", epoch, err)
+			}
+			mu.Lock()
+			rewards += total
+			mu.Unlock()
+		}
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to get attestation rewards: %w", err)
+		}
+	}
+
+	apr := float64(rewards) / validatorStakeGwei * epochsPerYear / float64(epochs) * 100
+
+	networkAPR := "-"
+	if value, err := networkConsensusAPR(); err == nil {
+		networkAPR = fmt.Sprintf("%.2f%%", value*100)
+	} else {
+		fmt.Printf("Warning: network APR unavailable: %v\n", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VALIDATOR\tSTATUS\tBALANCE\tREWARDS\tEPOCHS\tAPR\tNETWORK APR")
+	fmt.Fprintf(w, "%s\t%s\t%s ETH\t%s ETH\t%d-%d\t%.2f%%\t%s\n", validator.Index, validator.Status,
+		formatGwei(validator.Balance), formatBigIntToDecimal(big.NewInt(rewards), 9), last-epochs+1, last, apr, networkAPR)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println("APR counts attestation rewards against a 32 ETH stake; block proposals, sync committee duty and execution layer tips are not included")
+	return nil
+}
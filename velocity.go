@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// tokenVelocityReport summarises transfer activity over a block range
+type tokenVelocityReport struct {
+	Token           string  `json:"token"`
+	FromBlock       uint64  `json:"fromBlock"`
+	ToBlock         uint64  `json:"toBlock"`
+	Transfers       int     `json:"transfers"`
+	UniqueSenders   int     `json:"uniqueSenders"`
+	UniqueReceivers int     `json:"uniqueReceivers"`
+	Volume          string  `json:"volume"`
+	TotalSupply     string  `json:"totalSupply"`
+	AverageTransfer string  `json:"averageTransfer"`
+	Velocity        float64 `json:"velocity"`
+}
+
+func tokenVelocity(c *cli.Context) error {
+	fromBlock := c.Uint64("from-block")
+	toBlock := c.Uint64("to-block")
+	output := c.String("output")
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	logs, err := fetchLogs(client, []common.Address{tokenAddress}, [][]common.Hash{{transferTopic}}, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	volume := new(big.Int)
+	transfers := 0
+	senders := map[common.Address]bool{}
+	receivers := map[common.Address]bool{}
+	for _, log := range logs {
+		// ERC-721 transfers index the token ID and carry no data
+		if len(log.Topics) != 3 || len(log.Data) != 32 {
+			continue
+		}
+		from := common.BytesToAddress(log.Topics[1].Bytes())
+		to := common.BytesToAddress(log.Topics[2].Bytes())
+		// Mints and burns change supply rather than circulate it
+		if from == (common.Address{}) || to == (common.Address{}) {
+			continue
+		}
+
+		volume.Add(volume, new(big.Int).SetBytes(log.Data))
+		senders[from] = true
+		receivers[to] = true
+		transfers++
+	}
+
+	decimals, err := tokenDecimals(client, tokenAddress)
+	if err != nil {
+		return err
+	}
+	tokenContract, err := Token.ERCToken(tokenAddress.Hex(), decimals, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	totalSupply, err := tokenContract.TotalSupply()
+	if err != nil {
+		return fmt.Errorf("failed to get total supply: %w", err)
+	}
+
+	average := new(big.Int)
+	if transfers > 0 {
+		average.Div(volume, big.NewInt(int64(transfers)))
+	}
+	velocity := 0.0
+	if totalSupply.Sign() > 0 {
+		velocity, _ = new(big.Float).Quo(new(big.Float).SetInt(volume), new(big.Float).SetInt(totalSupply)).Float64()
+	}
+
+	if toBlock == 0 {
+		toBlock, err = client.BlockNumber(c.Context)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %w", err)
+		}
+	}
+
+	report := tokenVelocityReport{
+		Token:           tokenAddress.Hex(),
+		FromBlock:       fromBlock,
+		ToBlock:         toBlock,
+		Transfers:       transfers,
+		UniqueSenders:   len(senders),
+		UniqueReceivers: len(receivers),
+		Volume:          formatBigIntToDecimal(volume, decimals),
+		TotalSupply:     formatBigIntToDecimal(totalSupply, decimals),
+		AverageTransfer: formatBigIntToDecimal(average, decimals),
+		Velocity:        velocity,
+	}
+
+	if output == "json" {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	fmt.Printf("Blocks:            %d - %d\n", report.FromBlock, report.ToBlock)
+	fmt.Printf("Transfers:         %d\n", report.Transfers)
+	fmt.Printf("Unique senders:    %d\n", report.UniqueSenders)
+	fmt.Printf("Unique receivers:  %d\n", report.UniqueReceivers)
+	fmt.Printf("Volume:            %s\n", report.Volume)
+	fmt.Printf("Total supply:      %s\n", report.TotalSupply)
+	fmt.Printf("Average transfer:  %s\n", report.AverageTransfer)
+	fmt.Printf("Velocity:          %.4f\n", report.Velocity)
+	return nil
+}
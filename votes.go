@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// ERC20Votes (EIP-5805) delegation and checkpoint functions
+const erc20VotesABI = `[
+  {
+    "inputs": [{ "name": "delegatee", "type": "address" }],
+    "name": "delegate",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "account", "type": "address" }],
+    "name": "delegates",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "account", "type": "address" }],
+    "name": "getVotes",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      { "name": "account", "type": "address" },
+      { "name": "timepoint", "type": "uint256" }
+    ],
+    "name": "getPastVotes",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "timepoint", "type": "uint256" }],
+    "name": "getPastTotalSupply",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "totalSupply",
+    "outputs": [{ "name": "", "type": "uint256" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "CLOCK_MODE",
+    "outputs": [{ "name": "", "type": "string" }],
+    "stateMutability": "view",
+    "type": "function"
+  }
+]`
+
+var erc20Votes = mustParseABI(erc20VotesABI)
+
+// currentDelegate returns the delegatee of account, failing with a clear
+// error when the token does not implement ERC20Votes
+func currentDelegate(client *ethclient.Client, token, account common.Address) (common.Address, error) {
+	result, err := callContract(client, token, erc20Votes, "delegates", account)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%s does not look like an ERC20Votes token: %w", token.Hex(), err)
+	}
+	return result[0].(common.Address), nil
+}
+
+// votingShare returns votes as a percentage of supply
+func votingShare(votes, supply *big.Int) float64 {
+	if supply.Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(votes), new(big.Float).SetInt(supply)).Float64()
+	return share * 100
+}
+
+func delegateVotes(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	token, err := parseAddress(c.String("token"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	delegatee, err := resolveAddress(context.Background(), client, c.String("delegatee"))
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	previous, err := currentDelegate(client, token, account.Address)
+	if err != nil {
+		return err
+	}
+	if previous == delegatee {
+		fmt.Printf("Votes of %s are already delegated to %s\n", account.Address.Hex(), delegatee.Hex())
+		return nil
+	}
+
+	data, err := erc20Votes.Pack("delegate", delegatee)
+	if err != nil {
+		return fmt.Errorf("failed to pack delegate data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, token, big.NewInt(0), data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Delegation transaction sent: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("Delegate: %s -> %s\n", previous.Hex(), delegatee.Hex())
+	return nil
+}
+
+func checkVotingPower(c *cli.Context) error {
+	token, err := parseAddress(c.String("token"))
+	if err != nil {
+		return err
+	}
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	decimals, err := tokenDecimals(client, token)
+	if err != nil {
+		return err
+	}
+
+	result, err := callContract(client, token, erc20Votes, "getVotes", address)
+	if err != nil {
+		return fmt.Errorf("%s does not look like an ERC20Votes token: %w", token.Hex(), err)
+	}
+	votes := result[0].(*big.Int)
+	result, err = callContract(client, token, erc20Votes, "totalSupply")
+	if err != nil {
+		return err
+	}
+	supply := result[0].(*big.Int)
+
+	fmt.Printf("Current voting power: %s of %s (%.4f%%)\n",
+		formatBigIntToDecimal(votes, decimals), formatBigIntToDecimal(supply, decimals), votingShare(votes, supply))
+
+	if !c.IsSet("block") {
+		return nil
+	}
+
+	// EIP-6372 tokens may checkpoint by timestamp rather than block number
+	timepoint := new(big.Int).SetUint64(c.Uint64("block"))
+	unit := "block"
+	if result, err := callContract(client, token, erc20Votes, "CLOCK_MODE"); err == nil && result[0].(string) == "mode=timestamp" {
+		unit = "timestamp"
+	}
+
+	result, err = callContract(client, token, erc20Votes, "getPastVotes", address, timepoint)
+	if err != nil {
+		return fmt.Errorf("failed to get votes at %s %s (it must be in the past): %w", unit, timepoint, err)
+	}
+	pastVotes := result[0].(*big.Int)
+	result, err = callContract(client, token, erc20Votes, "getPastTotalSupply", timepoint)
+	if err != nil {
+		return fmt.Errorf("failed to get total supply at %s %s: %w", unit, timepoint, err)
+	}
+	pastSupply := result[0].(*big.Int)
+
+	fmt.Printf("Voting power at %s %s: %s of %s (%.4f%%)\n", unit, timepoint,
+		formatBigIntToDecimal(pastVotes, decimals), formatBigIntToDecimal(pastSupply, decimals), votingShare(pastVotes, pastSupply))
+	return nil
+}
+
+func showDelegates(c *cli.Context) error {
+	token, err := parseAddress(c.String("token"))
+	if err != nil {
+		return err
+	}
+	address, err := parseAddress(c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	delegatee, err := currentDelegate(client, token, address)
+	if err != nil {
+		return err
+	}
+
+	switch delegatee {
+	case common.Address{}:
+		fmt.Printf("%s has not delegated; its balance carries no votes until it delegates\n", address.Hex())
+	case address:
+		fmt.Printf("%s delegates to itself\n", address.Hex())
+	default:
+		fmt.Printf("%s delegates to %s\n", address.Hex(), delegatee.Hex())
+	}
+	return nil
+}
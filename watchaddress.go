@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Reconnection delays double from the minimum up to the maximum
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 2 * time.Minute
+)
+
+// addressWatcher prints transactions and token transfers involving an address
+type addressWatcher struct {
+	address   common.Address
+	token     *common.Address
+	decimals  int
+	lastBlock uint64
+}
+
+// scanBlock prints the transactions of a block sent from or to the watched
+// address, and its token transfers when a token is watched.
+func (w *addressWatcher) scanBlock(client *ethclient.Client, number uint64) error {
+	block, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+
+	for _, tx := range block.Transactions() {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			continue
+		}
+		incoming := tx.To() != nil && *tx.To() == w.address
+		if from != w.address && !incoming {
+			continue
+		}
+
+		direction := "OUT"
+		if incoming {
+			direction = "IN"
+		}
+		to := "contract creation"
+		if tx.To() != nil {
+			to = tx.To().Hex()
+		}
+		fmt.Printf("[block %d] %s %s %s -> %s %s ETH\n", number, direction, tx.Hash().Hex(), from.Hex(), to, formatBigIntToDecimal(tx.Value(), 18))
+	}
+
+	if w.token == nil {
+		return nil
+	}
+
+	// Transfers from the address, then to it
+	topic := common.BytesToHash(w.address.Bytes())
+	for _, topics := range [][][]common.Hash{
+		{{transferTopic}, {topic}},
+		{{transferTopic}, nil, {topic}},
+	} {
+		logs, err := fetchLogs(client, []common.Address{*w.token}, topics, number, number)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if len(log.Topics) < 3 {
+				continue
+			}
+			from := common.BytesToAddress(log.Topics[1].Bytes())
+			to := common.BytesToAddress(log.Topics[2].Bytes())
+			direction := "OUT"
+			if to == w.address {
+				direction = "IN"
+			}
+			amount := new(big.Int).SetBytes(log.Data)
+			fmt.Printf("[block %d] %s token transfer %s %s -> %s %s\n", number, direction, log.TxHash.Hex(), from.Hex(), to.Hex(), formatBigIntToDecimal(amount, w.decimals))
+		}
+	}
+	return nil
+}
+
+// watch subscribes to new heads and scans every block since the last one
+// seen. It returns when the subscription fails.
+func (w *addressWatcher) watch(endpoint string, connected func()) error {
+	client, err := ethclient.Dial(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the websocket endpoint: %w", err)
+	}
+	defer client.Close()
+
+	headers := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+	defer sub.Unsubscribe()
+	connected()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			return fmt.Errorf("subscription dropped: %w", err)
+		case header := <-headers:
+			head := header.Number.Uint64()
+			if w.lastBlock == 0 {
+				w.lastBlock = head - 1
+			}
+			for number := w.lastBlock + 1; number <= head; number++ {
+				if err := w.scanBlock(client, number); err != nil {
+					return err
+				}
+				w.lastBlock = number
+			}
+		}
+	}
+}
+
+func watchAddress(c *cli.Context) error {
+	var watcher addressWatcher
+
+	switch {
+	case c.IsSet("address"):
+		address, err := parseAddress(c.String("address"))
+		if err != nil {
+			return err
+		}
+		watcher.address = address
+	case c.IsSet("index"):
+		index := c.Int("index")
+		accountList := openKeyStore().Accounts()
+		if index < 0 || index >= len(accountList) {
+			return fmt.Errorf("invalid account index")
+		}
+		watcher.address = accountList[index].Address
+	default:
+		return fmt.Errorf("one of --index and --address is required")
+	}
+
+	endpoint, err := websocketURL()
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("token-address") {
+		tokenAddress, err := parseAddress(c.String("token-address"))
+		if err != nil {
+			return err
+		}
+		watcher.token = &tokenAddress
+
+		client, err := dialClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+		}
+		watcher.decimals, err = decimalsFlag(c, client, tokenAddress)
+		client.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Watching %s\n", watcher.address.Hex())
+	delay := minReconnectDelay
+	for {
+		err := watcher.watch(endpoint, func() { delay = minReconnectDelay })
+		fmt.Printf("%v; reconnecting in %s\n", err, delay)
+		time.Sleep(delay)
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+const watchlistFile = "watchlist.json"
+
+// loadWatchlist reads the token watchlist. A missing file is an empty list.
+func loadWatchlist() ([]common.Address, error) {
+	path, err := dataFilePath(watchlistFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []common.Address
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse watchlist: %w", err)
+	}
+	return tokens, nil
+}
+
+// saveWatchlist writes the token watchlist
+func saveWatchlist(tokens []common.Address) error {
+	path, err := dataFilePath(watchlistFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode watchlist: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write watchlist: %w", err)
+	}
+	return nil
+}
+
+func watchlistAdd(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	tokens, err := loadWatchlist()
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token == tokenAddress {
+			fmt.Printf("%s is already in the watchlist\n", tokenAddress.Hex())
+			return nil
+		}
+	}
+
+	if err := saveWatchlist(append(tokens, tokenAddress)); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s to the watchlist\n", tokenAddress.Hex())
+	return nil
+}
+
+func watchlistRemove(c *cli.Context) error {
+	tokenAddress, err := parseAddress(c.String("token-address"))
+	if err != nil {
+		return err
+	}
+
+	tokens, err := loadWatchlist()
+	if err != nil {
+		return err
+	}
+
+	remaining := tokens[:0]
+	for _, token := range tokens {
+		if token != tokenAddress {
+			remaining = append(remaining, token)
+		}
+	}
+	if len(remaining) == len(tokens) {
+		return fmt.Errorf("%s is not in the watchlist", tokenAddress.Hex())
+	}
+
+	if err := saveWatchlist(remaining); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s from the watchlist\n", tokenAddress.Hex())
+	return nil
+}
+
+func watchlistList(c *cli.Context) error {
+	tokens, err := loadWatchlist()
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) == 0 {
+		fmt.Println("Watchlist is empty.")
+		return nil
+	}
+	for _, token := range tokens {
+		fmt.Println(token.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	Token "eth-manage/token"
+)
+
+// feeWrapperBin is the FeeWrapper init code. contracts/FeeWrapper.sol is its
+// source and documents its behaviour; go generate rebuilds it with the pinned
+// compiler settings, and wrapper_test.go checks the bundled code on a
+// simulated chain. The constructor takes (address underlying, uint256 feeBps)
+// with feeBps capped at 10000.
+//
+//go:generate solc --optimize --optimize-runs 200 --evm-version paris --bin --abi --overwrite -o contracts contracts/FeeWrapper.sol
+//go:embed contracts/FeeWrapper.bin
+var feeWrapperBin string
+
+var feeWrapperCode = hexutil.MustDecode("0x" + strings.TrimSpace(feeWrapperBin))
+
+const feeWrapperABI = `[
+  {
+    "inputs": [
+      { "name": "underlying", "type": "address" },
+      { "name": "feeBps", "type": "uint256" }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "constructor"
+  },
+  {
+    "inputs": [],
+    "name": "underlying",
+    "outputs": [{ "name": "", "type": "address" }],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "amount", "type": "uint256" }],
+    "name": "deposit",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [{ "name": "amount", "type": "uint256" }],
+    "name": "withdraw",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+var feeWrapper = mustParseABI(feeWrapperABI)
+
+func createWrapper(c *cli.Context) error {
+	fromIndex := c.Int("from")
+	feeBps := c.Uint64("fee-bps")
+
+	if feeBps > 10000 {
+		return fmt.Errorf("fee-bps must be at most 10000")
+	}
+
+	underlying, err := parseAddress(c.String("underlying"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	args, err := feeWrapper.Pack("", underlying, new(big.Int).SetUint64(feeBps))
+	if err != nil {
+		return fmt.Errorf("failed to pack constructor arguments: %w", err)
+	}
+
+	signedTx, wrapper, err := deployContract(client, keyStore, account, append(append([]byte{}, feeWrapperCode...), args...))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deployment transaction sent: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("Wrapper contract address: %s\n", wrapper.Hex())
+	fmt.Printf("Transfer fee: %d bps, paid to %s\n", feeBps, account.Address.Hex())
+	return nil
+}
+
+// wrapperAmount reads the underlying token of a wrapper and converts the
+// --amount flag to base units with the underlying token's decimals.
+func wrapperAmount(c *cli.Context, client *ethclient.Client, wrapper common.Address) (common.Address, *big.Int, error) {
+	result, err := callContract(client, wrapper, feeWrapper, "underlying")
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	underlying := result[0].(common.Address)
+
+	decimals, err := tokenDecimals(client, underlying)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	amount, err := parseAmount(c.String("amount"), decimals)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return underlying, amount, nil
+}
+
+func wrapTokens(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	wrapper, err := parseAddress(c.String("wrapper"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	underlying, amount, err := wrapperAmount(c, client, wrapper)
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	tokenContract, err := Token.ERCToken(underlying.Hex(), 0, client)
+	if err != nil {
+		return fmt.Errorf("failed to create token contract: %w", err)
+	}
+	approveData, err := tokenContract.ABI.Pack("approve", wrapper, amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack approve data: %w", err)
+	}
+
+	approveTx, err := sendTransaction(client, keyStore, account, underlying, big.NewInt(0), approveData)
+	if err != nil {
+		return fmt.Errorf("failed to approve wrapper: %w", err)
+	}
+	fmt.Printf("Approval transaction sent: %s\n", approveTx.Hash().Hex())
+
+	// The deposit cannot be estimated until the allowance is in place
+	if _, err := waitForSuccess(client, approveTx); err != nil {
+		return fmt.Errorf("approval failed: %w", err)
+	}
+
+	depositData, err := feeWrapper.Pack("deposit", amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack deposit data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, wrapper, big.NewInt(0), depositData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrap transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
+
+func unwrapTokens(c *cli.Context) error {
+	fromIndex := c.Int("from")
+
+	wrapper, err := parseAddress(c.String("wrapper"))
+	if err != nil {
+		return err
+	}
+
+	client, err := dialClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	_, amount, err := wrapperAmount(c, client, wrapper)
+	if err != nil {
+		return err
+	}
+
+	keyStore := openKeyStore()
+	account, err := unlockAccount(keyStore, fromIndex)
+	if err != nil {
+		return err
+	}
+
+	withdrawData, err := feeWrapper.Pack("withdraw", amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack withdraw data: %w", err)
+	}
+
+	signedTx, err := sendTransaction(client, keyStore, account, wrapper, big.NewInt(0), withdrawData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Unwrap transaction sent: %s\n", signedTx.Hash().Hex())
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	Token "eth-manage/token"
+)
+
+// deployFeeWrapper deploys the bundled FeeWrapper init code and waits for it
+func deployFeeWrapper(t *testing.T, chain *testChain, deployer accounts.Account, underlying common.Address, feeBps int64) common.Address {
+	t.Helper()
+	args, err := feeWrapper.Pack("", underlying, big.NewInt(feeBps))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, address, err := deployContract(chain.client, chain.keyStore, deployer, append(append([]byte{}, feeWrapperCode...), args...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(t, tx)
+	return address
+}
+
+// callBig calls a view method returning a single uint256
+func callBig(t *testing.T, chain *testChain, contract common.Address, contractABI abi.ABI, method string, args ...interface{}) *big.Int {
+	t.Helper()
+	result, err := callContract(chain.client, contract, contractABI, method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result[0].(*big.Int)
+}
+
+func TestFeeWrapper(t *testing.T) {
+	erc20, err := Token.ERCToken(common.Address{}.Hex(), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A zero-fee wrapper is a plain ERC-20 whose balances live in slot 4, so
+	// its runtime code serves as the underlying token with balances set in
+	// genesis
+	bootstrap := newTestChain(t, 1, nil)
+	plain := deployFeeWrapper(t, bootstrap, bootstrap.accounts[0], common.Address{}, 0)
+	tokenCode, err := bootstrap.client.CodeAt(context.Background(), plain, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	supply := bigInt(t, "10000000000000000000000000")
+	token := common.HexToAddress("0x00000000000000000000000000000000000070CE")
+	chain := newTestChain(t, 3, func(accounts []accounts.Account) types.GenesisAlloc {
+		return types.GenesisAlloc{token: {
+			Code: tokenCode,
+			Storage: map[common.Hash]common.Hash{
+				common.BigToHash(big.NewInt(3)):                                   common.BigToHash(supply),
+				mappingSlot(accounts[1].Address, common.BigToHash(big.NewInt(4))): common.BigToHash(supply),
+			},
+		}}
+	})
+	deployer, user, recipient := chain.accounts[0], chain.accounts[1], chain.accounts[2]
+
+	send := func(from accounts.Account, to common.Address, contractABI abi.ABI, method string, args ...interface{}) error {
+		t.Helper()
+		data, err := contractABI.Pack(method, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := sendTransaction(chain.client, chain.keyStore, from, to, big.NewInt(0), data)
+		if err != nil {
+			return err
+		}
+		chain.mine(t, tx)
+		return nil
+	}
+	expect := func(contract common.Address, holder accounts.Account, want *big.Int) {
+		t.Helper()
+		if got := callBig(t, chain, contract, erc20.ABI, "balanceOf", holder.Address); got.Cmp(want) != 0 {
+			t.Errorf("balance of %s at %s = %s, want %s", holder.Address.Hex(), contract.Hex(), got, want)
+		}
+	}
+
+	wrapper := deployFeeWrapper(t, chain, deployer, token, 30)
+
+	// Wrapping is 1:1 and takes no fee
+	if err := send(user, token, erc20.ABI, "approve", wrapper, supply); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(user, wrapper, feeWrapper, "deposit", supply); err != nil {
+		t.Fatal(err)
+	}
+	expect(wrapper, user, supply)
+	expect(token, user, new(big.Int))
+	if got := callBig(t, chain, token, erc20.ABI, "balanceOf", wrapper); got.Cmp(supply) != 0 {
+		t.Errorf("wrapper holds %s underlying, want %s", got, supply)
+	}
+	if got := callBig(t, chain, wrapper, erc20.ABI, "totalSupply"); got.Cmp(supply) != 0 {
+		t.Errorf("wrapped supply = %s, want %s", got, supply)
+	}
+
+	// 30 bps of every transfer goes to the deployer, rounded down
+	transfers := []struct {
+		amount string
+		fee    string
+	}{
+		{"1", "0"},
+		{"333", "0"},
+		{"334", "1"},
+		{"9999", "29"},
+		{"10000", "30"},
+		{"1000000000000000000000001", "3000000000000000000000"},
+	}
+	received, fees := new(big.Int), new(big.Int)
+	for _, tt := range transfers {
+		amount, fee := bigInt(t, tt.amount), bigInt(t, tt.fee)
+		if err := send(user, wrapper, erc20.ABI, "transfer", recipient.Address, amount); err != nil {
+			t.Fatalf("transfer of %s failed: %v", tt.amount, err)
+		}
+		received.Add(received, new(big.Int).Sub(amount, fee))
+		fees.Add(fees, fee)
+		expect(wrapper, recipient, received)
+		expect(wrapper, deployer, fees)
+	}
+
+	// Unwrapping burns the wrapped tokens and returns the underlying ones
+	if err := send(recipient, wrapper, feeWrapper, "withdraw", received); err != nil {
+		t.Fatal(err)
+	}
+	expect(wrapper, recipient, new(big.Int))
+	expect(token, recipient, received)
+	if got, want := callBig(t, chain, wrapper, erc20.ABI, "totalSupply"), new(big.Int).Sub(supply, received); got.Cmp(want) != 0 {
+		t.Errorf("wrapped supply after withdraw = %s, want %s", got, want)
+	}
+
+	if err := send(recipient, wrapper, feeWrapper, "withdraw", big.NewInt(1)); err == nil {
+		t.Error("withdrawing more than the wrapped balance succeeded")
+	}
+
+	args, err := feeWrapper.Pack("", token, big.NewInt(10001))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := deployContract(chain.client, chain.keyStore, deployer, append(append([]byte{}, feeWrapperCode...), args...)); err == nil {
+		t.Error("deploying with a fee above 10000 bps succeeded")
+	}
+}